@@ -172,8 +172,8 @@ func run(args []string, stdout, stderr io.Writer) int {
 			// Default to true, only false when explicitly set to false
 			failOnVuln := !context.IsSet("fail-on-vuln") || context.Bool("fail-on-vuln")
 
-			// if vulnerability exists it should return error
-			if len(diffVulns.Results) > 0 && failOnVuln {
+			// if vulnerability exists it should return error, not counting those that were filtered out
+			if unfiltered := diffVulns.WithoutFiltered(); len(unfiltered.Results) > 0 && failOnVuln {
 				return osvscanner.VulnerabilitiesFoundErr
 			}
 
@@ -0,0 +1,73 @@
+package explain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/osv"
+	"github.com/google/osv-scanner/pkg/reporter"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
+	return &cli.Command{
+		Name:      "explain",
+		Usage:     "explains a single vulnerability from a scan, including how to remediate it",
+		ArgsUsage: "<vulnerability id>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "package",
+				Usage: "the Package URL of the installed package (e.g. pkg:npm/lodash@4.17.20) to suggest the version to upgrade it to",
+			},
+		},
+		Action: func(context *cli.Context) error {
+			var err error
+			*r, err = action(context, stdout, stderr)
+
+			return err
+		},
+	}
+}
+
+func action(context *cli.Context, stdout, stderr io.Writer) (reporter.Reporter, error) {
+	r := reporter.NewTableReporter(stdout, stderr, reporter.InfoLevel, false, 0)
+
+	if context.NArg() != 1 {
+		return r, errors.New("exactly one vulnerability id must be given")
+	}
+
+	var pkg *models.PackageInfo
+	if purl := context.String("package"); purl != "" {
+		info, err := models.PURLToPackage(purl)
+		if err != nil {
+			return r, fmt.Errorf("invalid package URL %s: %w", purl, err)
+		}
+		if info.Version == "" {
+			return r, fmt.Errorf("invalid package URL %s: a version is required", purl)
+		}
+		pkg = &info
+	}
+
+	id := context.Args().First()
+	vuln, err := osv.Get(id)
+	if err != nil {
+		return r, fmt.Errorf("failed to look up %s: %w", id, err)
+	}
+
+	termWidth := 0
+	if stdoutAsFile, ok := stdout.(*os.File); ok {
+		termWidth, _, err = term.GetSize(int(stdoutAsFile.Fd()))
+		if err != nil { // If output is not a terminal,
+			termWidth = 0
+		}
+	}
+
+	output.PrintExplainTable(*vuln, pkg, stdout, termWidth)
+
+	return r, nil
+}
@@ -31,6 +31,9 @@ type osvFixOptions struct {
 	Lockfile   string
 	LockfileRW lockfile.LockfileIO
 	RelockCmd  string
+	// DryRun reports the changes that would be made without rewriting any files,
+	// which is the default in the non-interactive mode unless --apply is set
+	DryRun bool
 }
 
 func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
@@ -105,6 +108,11 @@ func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
 				Usage:    "apply the top N patches",
 				Value:    -1,
 			},
+			&cli.BoolFlag{
+				Category: autoModeCategory,
+				Name:     "apply",
+				Usage:    "rewrite the manifest or lockfile with the patches, rather than only reporting the changes that would be made",
+			},
 
 			&cli.BoolFlag{
 				// TODO: allow for finer control e.g. specific packages, major/minor/patch
@@ -179,6 +187,7 @@ func action(ctx *cli.Context, stdout, stderr io.Writer) (reporter.Reporter, erro
 		Manifest:  ctx.String("manifest"),
 		Lockfile:  ctx.String("lockfile"),
 		RelockCmd: ctx.String("relock-cmd"),
+		DryRun:    !ctx.Bool("apply"),
 		Client: client.ResolutionClient{
 			VulnerabilityClient: client.NewOSVClient(),
 		},
@@ -224,6 +233,10 @@ func action(ctx *cli.Context, stdout, stderr io.Writer) (reporter.Reporter, erro
 	}
 
 	if !ctx.Bool("non-interactive") {
+		if ctx.Bool("apply") {
+			return nil, errors.New("--apply can only be used in the non-interactive mode")
+		}
+
 		return nil, interactiveMode(ctx.Context, opts)
 	}
 
@@ -47,6 +47,12 @@ func autoInPlace(ctx context.Context, r reporter.Reporter, opts osvFixOptions, m
 	r.Infof("UNFIXABLE-VULNS: %d\n", len(res.Unfixable))
 	// TODO: Print the FIXED-VULN-IDS, REMAINING-VULN-IDS, UNFIXABLE-VULN-IDS
 
+	if opts.DryRun {
+		r.Infof("Not rewriting %s as this is a dry run, use --apply to rewrite it\n", opts.Lockfile)
+
+		return nil
+	}
+
 	r.Infof("Rewriting %s...\n", opts.Lockfile)
 
 	return lf.Overwrite(opts.LockfileRW, opts.Lockfile, patches)
@@ -125,8 +131,11 @@ func autoRelock(ctx context.Context, r reporter.Reporter, opts osvFixOptions, ma
 		return err
 	}
 
-	if err := opts.Client.WriteCache(manif.FilePath); err != nil {
-		r.Warnf("WARNING: failed to write resolution cache: %v\n", err)
+	// a dry run does not write anything to disk, including the resolution cache
+	if !opts.DryRun {
+		if err := opts.Client.WriteCache(manif.FilePath); err != nil {
+			r.Warnf("WARNING: failed to write resolution cache: %v\n", err)
+		}
 	}
 
 	if len(allPatches) == 0 {
@@ -148,6 +157,12 @@ func autoRelock(ctx context.Context, r reporter.Reporter, opts osvFixOptions, ma
 	// TODO: Print the FIXED-VULN-IDS, REMAINING-VULN-IDS, UNFIXABLE-VULN-IDS
 	// TODO: Consider potentially introduced vulnerabilities
 
+	if opts.DryRun {
+		r.Infof("Not rewriting %s as this is a dry run, use --apply to rewrite it\n", opts.Manifest)
+
+		return nil
+	}
+
 	r.Infof("Rewriting %s...\n", opts.Manifest)
 	if err := manifest.Overwrite(opts.ManifestRW, opts.Manifest, manifest.ManifestPatch{Manifest: &manif, Deps: depPatches}); err != nil {
 		return err
@@ -44,12 +44,24 @@ func TestRun_Fix(t *testing.T) {
 	}{
 		{
 			name:     "fix non-interactive in-place package-lock.json",
-			args:     []string{"", "fix", "--non-interactive", "--strategy=in-place"},
+			args:     []string{"", "fix", "--non-interactive", "--strategy=in-place", "--apply"},
 			exit:     0,
 			lockfile: "./fix/fixtures/in-place-npm/package-lock.json",
 		},
 		{
 			name:     "fix non-interactive relock package.json",
+			args:     []string{"", "fix", "--non-interactive", "--strategy=relock", "--apply"},
+			exit:     0,
+			manifest: "./fix/fixtures/relock-npm/package.json",
+		},
+		{
+			name:     "fix non-interactive in-place package-lock.json with dry run",
+			args:     []string{"", "fix", "--non-interactive", "--strategy=in-place"},
+			exit:     0,
+			lockfile: "./fix/fixtures/in-place-npm/package-lock.json",
+		},
+		{
+			name:     "fix non-interactive relock package.json with dry run",
 			args:     []string{"", "fix", "--non-interactive", "--strategy=relock"},
 			exit:     0,
 			manifest: "./fix/fixtures/relock-npm/package.json",
@@ -91,6 +103,19 @@ func TestRun_Fix(t *testing.T) {
 			if manifest != "" {
 				matchFile(t, manifest)
 			}
+
+			// a dry run should not write anything else to disk, such as the resolution cache
+			if !slices.Contains(tt.args, "--apply") {
+				entries, err := os.ReadDir(testDir)
+				if err != nil {
+					t.Fatalf("could not read test directory: %v", err)
+				}
+				for _, entry := range entries {
+					if path := filepath.Join(testDir, entry.Name()); path != lockfile && path != manifest {
+						t.Errorf("dry run wrote %s", entry.Name())
+					}
+				}
+			}
 		})
 	}
 }
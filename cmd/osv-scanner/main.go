@@ -6,9 +6,11 @@ import (
 	"os"
 	"slices"
 
+	"github.com/google/osv-scanner/cmd/osv-scanner/explain"
 	"github.com/google/osv-scanner/cmd/osv-scanner/fix"
 	"github.com/google/osv-scanner/cmd/osv-scanner/scan"
 	"github.com/google/osv-scanner/cmd/osv-scanner/update"
+	"github.com/google/osv-scanner/cmd/osv-scanner/vuln"
 	"github.com/google/osv-scanner/internal/version"
 	"github.com/google/osv-scanner/pkg/osv"
 	"github.com/google/osv-scanner/pkg/osvscanner"
@@ -17,17 +19,12 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-var (
-	commit = "n/a"
-	date   = "n/a"
-)
-
 func run(args []string, stdout, stderr io.Writer) int {
 	var r reporter.Reporter
 	cli.VersionPrinter = func(ctx *cli.Context) {
 		// Use the app Writer and ErrWriter since they will be the writers to keep parallel tests consistent
 		r = reporter.NewTableReporter(ctx.App.Writer, ctx.App.ErrWriter, reporter.InfoLevel, false, 0)
-		r.Infof("osv-scanner version: %s\ncommit: %s\nbuilt at: %s\n", ctx.App.Version, commit, date)
+		r.Infof("osv-scanner version: %s\ncommit: %s\nbuilt at: %s\n", ctx.App.Version, version.Commit, version.BuildDate)
 	}
 
 	osv.RequestUserAgent = "osv-scanner/" + version.OSVVersion
@@ -44,23 +41,50 @@ func run(args []string, stdout, stderr io.Writer) int {
 			scan.Command(stdout, stderr, &r),
 			fix.Command(stdout, stderr, &r),
 			update.Command(stdout, stderr, &r),
+			vuln.Command(stdout, stderr, &r),
+			explain.Command(stdout, stderr, &r),
 		},
 	}
 
 	args = insertDefaultCommand(args, app.Commands, app.DefaultCommand, stdout, stderr)
+	// the arguments are recorded in the metadata of the reports, for provenance
+	if len(args) > 0 {
+		app.Metadata = map[string]any{"args": args[1:]}
+	}
 
 	if err := app.Run(args); err != nil {
 		if r == nil {
 			r = reporter.NewTableReporter(stdout, stderr, reporter.InfoLevel, false, 0)
 		}
+		var resultErr *osvscanner.ResultError
 		switch {
+		// the exit codes set for severities take precedence over those of parse failures,
+		// as they were explicitly asked for
+		case errors.As(err, &resultErr):
+			return resultErr.ExitCode
+		case errors.Is(err, osvscanner.ErrParseFailures):
+			// the scan still succeeded for the other files, so the exit code also reflects their results
+			if errors.Is(err, osvscanner.VulnerabilitiesFoundErr) {
+				return 3
+			}
+
+			return 2
 		case errors.Is(err, osvscanner.VulnerabilitiesFoundErr):
 			return 1
 		case errors.Is(err, osvscanner.NoPackagesFoundErr):
 			r.Errorf("No package sources found, --help for usage information.\n")
 			return 128
+		case errors.Is(err, osvscanner.ErrTooFewPackages), errors.Is(err, osvscanner.ErrMissingEcosystems):
+			r.Errorf("%v\n", err)
+			return 128
 		case errors.Is(err, osvscanner.ErrAPIFailed):
 			r.Errorf("%v\n", err)
+
+			var unreachable *osv.UnreachableError
+			if errors.As(err, &unreachable) {
+				r.Errorf("Check your network connection and proxy settings, or use --experimental-offline to scan against local databases that have already been downloaded with --experimental-local-db\n")
+			}
+
 			return 129
 		}
 		r.Errorf("%v\n", err)
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"archive/zip"
 	"bytes"
 	"errors"
 	"os"
@@ -79,6 +80,16 @@ func normalizeTempDirectory(t *testing.T, str string) string {
 	return re.ReplaceAllString(str, "<tempdir>")
 }
 
+// normalizeScanTimestamps replaces the time the scan was run at in the metadata
+// of the json and sarif outputs with "<timestamp>", as it changes with each run
+func normalizeScanTimestamps(t *testing.T, str string) string {
+	t.Helper()
+
+	re := cachedregexp.MustCompile(`("(?:scanned_at|startTimeUtc)":\s*)"[^"]*"`)
+
+	return re.ReplaceAllString(str, `$1"<timestamp>"`)
+}
+
 // normalizeErrors attempts to replace error messages on alternative OSs with their
 // known linux equivalents, to ensure tests pass across different OSs
 func normalizeErrors(t *testing.T, str string) string {
@@ -102,6 +113,7 @@ func normalizeStdStream(t *testing.T, std *bytes.Buffer) string {
 		normalizeRootDirectory,
 		normalizeTempDirectory,
 		normalizeUserCacheDirectory,
+		normalizeScanTimestamps,
 		normalizeErrors,
 	} {
 		str = normalizer(t, str)
@@ -184,6 +196,12 @@ func TestRun(t *testing.T) {
 			args: []string{"", "./fixtures/locks-many-with-invalid"},
 			exit: 127,
 		},
+		// lockfiles that cannot be parsed are reported as warnings when parse errors are allowed
+		{
+			name: "lockfiles that cannot be parsed are reported as warnings when parse errors are allowed",
+			args: []string{"", "--allow-parse-errors", "./fixtures/locks-many-with-invalid"},
+			exit: 2,
+		},
 		// only the files in the given directories are checked by default (no recursion)
 		{
 			name: "only the files in the given directories are checked by default (no recursion)",
@@ -695,6 +713,110 @@ func TestRun_OCIImage(t *testing.T) {
 	}
 }
 
+// writeEmptyBundle writes an offline vulnerabilities bundle without any vulnerabilities,
+// so that scans using it do not need network access
+func writeEmptyBundle(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("could not create bundle: %v", err)
+	}
+	defer f.Close()
+
+	if err := zip.NewWriter(f).Close(); err != nil {
+		t.Fatalf("could not write bundle: %v", err)
+	}
+
+	return path
+}
+
+// Do not make this test parallel because it calls t.Setenv()
+func TestRun_EnvironmentVariables(t *testing.T) {
+	bundle := writeEmptyBundle(t)
+
+	tests := []struct {
+		name       string
+		env        map[string]string
+		args       []string
+		exit       int
+		wantStdout string
+		wantStderr string
+		// skipStdout is not expected to be in stdout, if it is set
+		skipStdout string
+	}{
+		{
+			name: "format from the environment",
+			env: map[string]string{
+				"OSV_SCANNER_FORMAT":                  "json",
+				"OSV_SCANNER_OFFLINE_VULNERABILITIES": bundle,
+			},
+			args:       []string{"", "./fixtures/locks-many/composer.lock"},
+			exit:       0,
+			wantStdout: `"results": []`,
+		},
+		{
+			name: "flags take precedence over the environment",
+			env: map[string]string{
+				"OSV_SCANNER_FORMAT":                  "json",
+				"OSV_SCANNER_OFFLINE_VULNERABILITIES": bundle,
+			},
+			args:       []string{"", "--format", "table", "./fixtures/locks-many/composer.lock"},
+			exit:       0,
+			wantStdout: "No issues found",
+		},
+		{
+			name: "config files are used without the environment",
+			env: map[string]string{
+				"OSV_SCANNER_OFFLINE_VULNERABILITIES": bundle,
+			},
+			args:       []string{"", "./fixtures/locks-many/composer.lock"},
+			exit:       0,
+			wantStdout: "Loaded filter from: fixtures/locks-many/osv-scanner.toml",
+		},
+		{
+			name: "config from the environment takes precedence over config files",
+			env: map[string]string{
+				"OSV_SCANNER_CONFIG":                  "./fixtures/osv-scanner-empty-config.toml",
+				"OSV_SCANNER_OFFLINE_VULNERABILITIES": bundle,
+			},
+			args:       []string{"", "./fixtures/locks-many/composer.lock"},
+			exit:       0,
+			wantStdout: "No issues found",
+			skipStdout: "Loaded filter from: fixtures/locks-many/osv-scanner.toml",
+		},
+		{
+			name: "invalid values from the environment are reported",
+			env: map[string]string{
+				"OSV_SCANNER_FORMAT": "unknown",
+			},
+			args:       []string{"", "./fixtures/locks-many/composer.lock"},
+			exit:       127,
+			wantStderr: "unsupported output format \"unknown\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			stdout, stderr := runCli(t, cliTestCase{name: tt.name, args: tt.args, exit: tt.exit})
+
+			if !strings.Contains(stdout, tt.wantStdout) {
+				t.Errorf("Expected stdout to contain %q, but got:\n%s", tt.wantStdout, stdout)
+			}
+			if !strings.Contains(stderr, tt.wantStderr) {
+				t.Errorf("Expected stderr to contain %q, but got:\n%s", tt.wantStderr, stderr)
+			}
+			if tt.skipStdout != "" && strings.Contains(stdout, tt.skipStdout) {
+				t.Errorf("Expected stdout to not contain %q, but got:\n%s", tt.skipStdout, stdout)
+			}
+		})
+	}
+}
+
 // Tests all subcommands here.
 func TestRun_SubCommands(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,48 @@
+package scan
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// parseAPIHeaders parses the "Name: Value" entries of the --api-header flag into the headers
+// to send with each request to the OSV API.
+//
+// The values may be secrets such as tokens, so they are never included in errors
+func parseAPIHeaders(entries []string) (http.Header, error) {
+	headers := http.Header{}
+
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, errors.New("invalid --api-header entry - must be like Name: Value")
+		}
+
+		name = strings.TrimSpace(name)
+		if name == "" || strings.ContainsFunc(name, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) {
+			return nil, fmt.Errorf("invalid --api-header entry for \"%s\" - the name must not be empty or contain whitespace", name)
+		}
+
+		value = strings.TrimSpace(value)
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return nil, fmt.Errorf("invalid --api-header entry for \"%s\" - the value must not contain line breaks", name)
+		}
+
+		headers.Add(name, value)
+	}
+
+	return headers, nil
+}
+
+// redactAPIHeader replaces the value of an --api-header entry, keeping the name of the header
+// so that it is still clear what was sent
+func redactAPIHeader(entry string) string {
+	if name, _, ok := strings.Cut(entry, ":"); ok {
+		return name + ": <redacted>"
+	}
+
+	return "<redacted>"
+}
@@ -0,0 +1,71 @@
+package scan
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAPIHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []string
+		want    http.Header
+		wantErr bool
+	}{
+		{
+			name:    "none",
+			entries: nil,
+			want:    http.Header{},
+		},
+		{
+			name:    "headers",
+			entries: []string{"Authorization: Bearer secret-token", "x-mirror-id:abc", "X-Mirror-Id: def", "X-Empty:"},
+			want: http.Header{
+				"Authorization": {"Bearer secret-token"},
+				"X-Mirror-Id":   {"abc", "def"},
+				"X-Empty":       {""},
+			},
+		},
+		{
+			name:    "missing separator",
+			entries: []string{"Bearer secret-token"},
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			entries: []string{": secret-token"},
+			wantErr: true,
+		},
+		{
+			name:    "name with whitespace",
+			entries: []string{"Auth orization: secret-token"},
+			wantErr: true,
+		},
+		{
+			name:    "value with a line break",
+			entries: []string{"Authorization: secret-token\r\nX-Other: value"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseAPIHeaders(tt.entries)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAPIHeaders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && strings.Contains(err.Error(), "secret-token") {
+				t.Errorf("parseAPIHeaders() error = %v, which includes the value of the header", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAPIHeaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
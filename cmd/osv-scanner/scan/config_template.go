@@ -0,0 +1,34 @@
+package scan
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/osv-scanner/pkg/config"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// writeConfigTemplate writes the commented config template to path, or to stdout if path is "-",
+// without overwriting a file that already exists so that an existing config is never lost
+func writeConfigTemplate(r reporter.Reporter, stdout io.Writer, path string) error {
+	if path == "-" {
+		_, err := io.WriteString(stdout, config.Template)
+
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write config template: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := io.WriteString(f, config.Template); err != nil {
+		return fmt.Errorf("failed to write config template: %w", err)
+	}
+
+	r.Infof("Wrote a config template to %s\n", path)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package scan
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/config"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func TestWriteConfigTemplate(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "osv-scanner.toml")
+	stdout := &bytes.Buffer{}
+
+	if err := writeConfigTemplate(&reporter.VoidReporter{}, stdout, path); err != nil {
+		t.Fatalf("writeConfigTemplate() error = %v", err)
+	}
+
+	if stdout.Len() > 0 {
+		t.Errorf("writeConfigTemplate() printed %q to stdout when writing to a file", stdout.String())
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read the written template: %v", err)
+	}
+
+	if string(got) != config.Template {
+		t.Errorf("writeConfigTemplate() wrote %q, want the template", string(got))
+	}
+
+	// an existing config should never be overwritten
+	if err := os.WriteFile(path, []byte("ExcludePaths = []\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeConfigTemplate(&reporter.VoidReporter{}, stdout, path); err == nil {
+		t.Errorf("writeConfigTemplate() did not return an error when the file already exists")
+	}
+
+	got, _ = os.ReadFile(path)
+	if string(got) != "ExcludePaths = []\n" {
+		t.Errorf("writeConfigTemplate() overwrote the existing file with %q", string(got))
+	}
+}
+
+func TestWriteConfigTemplate_Stdout(t *testing.T) {
+	t.Parallel()
+
+	stdout := &bytes.Buffer{}
+
+	if err := writeConfigTemplate(&reporter.VoidReporter{}, stdout, "-"); err != nil {
+		t.Fatalf("writeConfigTemplate() error = %v", err)
+	}
+
+	if stdout.String() != config.Template {
+		t.Errorf("writeConfigTemplate() printed %q, want the template", stdout.String())
+	}
+}
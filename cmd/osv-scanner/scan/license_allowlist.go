@@ -0,0 +1,58 @@
+package scan
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// expandLicenseAllowlist replaces any entries of the allowlist that start with "@" with the licenses
+// listed in the file at the rest of the entry, combining them with the other entries.
+func expandLicenseAllowlist(entries []string) ([]string, error) {
+	allowlist := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		path, isFile := strings.CutPrefix(entry, "@")
+		if !isFile {
+			allowlist = append(allowlist, entry)
+			continue
+		}
+
+		licenses, err := readLicenseAllowlist(path)
+		if err != nil {
+			return nil, err
+		}
+		allowlist = append(allowlist, licenses...)
+	}
+
+	return allowlist, nil
+}
+
+// readLicenseAllowlist reads the licenses in an allowlist file, which has one SPDX license per line,
+// ignoring empty lines and comments starting with "#"
+func readLicenseAllowlist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read license allowlist: %w", err)
+	}
+	defer f.Close()
+
+	var licenses []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+
+		if line != "" {
+			licenses = append(licenses, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read license allowlist %s: %w", path, err)
+	}
+
+	return licenses, nil
+}
@@ -0,0 +1,52 @@
+package scan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandLicenseAllowlist(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "inline entries",
+			entries: []string{"MIT", "ISC"},
+			want:    []string{"MIT", "ISC"},
+		},
+		{
+			name:    "file",
+			entries: []string{"@fixtures/license-allowlist.txt"},
+			want:    []string{"MIT", "Apache-2.0", "BSD-3-Clause"},
+		},
+		{
+			name:    "file and inline entries",
+			entries: []string{"ISC", "@fixtures/license-allowlist.txt", "0BSD"},
+			want:    []string{"ISC", "MIT", "Apache-2.0", "BSD-3-Clause", "0BSD"},
+		},
+		{
+			name:    "missing file",
+			entries: []string{"@fixtures/does-not-exist.txt"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := expandLicenseAllowlist(tt.entries)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandLicenseAllowlist() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandLicenseAllowlist() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
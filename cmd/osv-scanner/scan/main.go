@@ -7,7 +7,11 @@ import (
 	"os"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/google/osv-scanner/internal/version"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/osv"
 	"github.com/google/osv-scanner/pkg/osvscanner"
 	"github.com/google/osv-scanner/pkg/reporter"
 	"github.com/google/osv-scanner/pkg/spdx"
@@ -28,27 +32,47 @@ func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
 				Usage:     "scan docker image with this name",
 				TakesFile: false,
 			},
+			&cli.StringSliceFlag{
+				Name:      "k8s",
+				Usage:     "scan the docker images referenced by the containers in this Kubernetes manifest",
+				TakesFile: true,
+			},
 			&cli.StringSliceFlag{
 				Name:      "lockfile",
 				Aliases:   []string{"L"},
 				Usage:     "scan package lockfile on this path",
 				TakesFile: true,
 			},
+			&cli.StringFlag{
+				Name:      "paths-from",
+				Usage:     "scan the package lockfiles listed in this file, one per line, or from stdin if set to -",
+				TakesFile: true,
+			},
 			&cli.StringSliceFlag{
 				Name:      "sbom",
 				Aliases:   []string{"S"},
 				Usage:     "scan sbom file on this path",
 				TakesFile: true,
 			},
+			&cli.StringSliceFlag{
+				Name:  "purl",
+				Usage: "scan the package with this Package URL (such as pkg:npm/foo@1.2.3), can be repeated",
+			},
+			&cli.StringSliceFlag{
+				Name:  "query-hash",
+				Usage: "scan the code built from this full version control hash (such as a git commit), can be repeated",
+			},
 			&cli.StringFlag{
 				Name:      "config",
 				Usage:     "set/override config file",
+				EnvVars:   []string{"OSV_SCANNER_CONFIG"},
 				TakesFile: true,
 			},
 			&cli.StringFlag{
 				Name:    "format",
 				Aliases: []string{"f"},
 				Usage:   "sets the output format; value can be: " + strings.Join(reporter.Format(), ", "),
+				EnvVars: []string{"OSV_SCANNER_FORMAT"},
 				Value:   "table",
 				Action: func(context *cli.Context, s string) error {
 					if slices.Contains(reporter.Format(), s) {
@@ -62,9 +86,211 @@ func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
 				Name:  "json",
 				Usage: "sets output to json (deprecated, use --format json instead)",
 			},
+			&cli.BoolFlag{
+				Name:  "only-fixable",
+				Usage: "only report vulnerabilities that have a fixed version available",
+			},
+			&cli.BoolFlag{
+				Name:  "strict-matching",
+				Usage: "re-check that each vulnerability affects the installed version of the package using the versioning rules of its ecosystem, removing those that do not",
+			},
+			&cli.BoolFlag{
+				Name:  "resolve-constraints",
+				Usage: "resolve the version constraints of packages in manifests such as composer.json to the latest versions that satisfy them",
+			},
+			&cli.StringFlag{
+				Name:  "modified-since",
+				Usage: "only report vulnerabilities that have been published or modified since this date (YYYY-MM-DD) or time (RFC 3339)",
+				Action: func(context *cli.Context, s string) error {
+					_, err := parseModifiedSince(s)
+					return err
+				},
+			},
+			&cli.BoolFlag{
+				Name:  "include-dev-dependencies",
+				Usage: "scan packages that are only development dependencies, set to false to skip them",
+				Value: true,
+			},
+			&cli.StringFlag{
+				Name:  "strict-ecosystems",
+				Usage: "report packages in ecosystems that are not supported by OSV, and so are not checked for vulnerabilities; value can be: warn, error",
+				Action: func(context *cli.Context, s string) error {
+					if s == osvscanner.StrictEcosystemsWarn || s == osvscanner.StrictEcosystemsError {
+						return nil
+					}
+
+					return fmt.Errorf("unsupported --strict-ecosystems value \"%s\" - must be one of: %s, %s", s, osvscanner.StrictEcosystemsWarn, osvscanner.StrictEcosystemsError)
+				},
+			},
+			&cli.StringSliceFlag{
+				Name:  "prefer-lockfile",
+				Usage: "when a directory has more than one lockfile for the same ecosystem, only scan the one whose name comes first in this list, such as package-lock.json,yarn.lock",
+			},
+			&cli.BoolFlag{
+				Name:  "check-drift",
+				Usage: "warn when a lockfile disagrees with the manifest alongside it about the versions of direct dependencies, such as package-lock.json with package.json",
+			},
+			&cli.BoolFlag{
+				Name:  "fail-on-drift",
+				Usage: "like --check-drift, but reports lockfiles that disagree with their manifest as errors so that the scan fails",
+			},
+			&cli.BoolFlag{
+				Name:  "report-duplicate-versions",
+				Usage: "warn about packages that are found at more than one version across the scanned sources, marking which are vulnerable",
+			},
+			&cli.BoolFlag{
+				Name:  "scan-runtimes",
+				Usage: "check the Node.js, Python and Go runtimes pinned by .nvmrc, .node-version, .python-version and go.mod files, reporting them separately from dependencies",
+			},
+			&cli.IntFlag{
+				Name:  "min-packages",
+				Usage: "fail the scan if fewer than this many packages are found in total, to catch scans that accidentally find nothing",
+				Action: func(context *cli.Context, n int) error {
+					if n < 0 {
+						return fmt.Errorf("--min-packages must not be negative, got %d", n)
+					}
+
+					return nil
+				},
+			},
+			&cli.StringSliceFlag{
+				Name:  "require-ecosystems",
+				Usage: "fail the scan if no packages are found in any of these ecosystems (such as npm,go,pypi), to catch lockfiles that are missing",
+				Action: func(context *cli.Context, ecosystems []string) error {
+					for _, ecosystem := range ecosystems {
+						if !slices.ContainsFunc(models.Ecosystems, func(e models.Ecosystem) bool {
+							return strings.EqualFold(ecosystem, string(e))
+						}) {
+							return fmt.Errorf("--require-ecosystems has an unknown ecosystem %q", ecosystem)
+						}
+					}
+
+					return nil
+				},
+			},
+			&cli.StringFlag{
+				Name:  "fail-on",
+				Usage: "which vulnerabilities cause a non-zero exit code, where called excludes those that call analysis determined are not called; value can be: called, any",
+				Value: osvscanner.FailOnCalled,
+				Action: func(context *cli.Context, s string) error {
+					if s == osvscanner.FailOnCalled || s == osvscanner.FailOnAny {
+						return nil
+					}
+
+					return fmt.Errorf("unsupported --fail-on value \"%s\" - must be one of: %s, %s", s, osvscanner.FailOnCalled, osvscanner.FailOnAny)
+				},
+			},
+			&cli.StringSliceFlag{
+				Name:    "api-header",
+				Usage:   "add a header to each request made to the OSV API, such as \"Authorization: Bearer <token>\" for a mirror that requires authentication; can be repeated",
+				EnvVars: []string{"OSV_SCANNER_API_HEADER"},
+			},
+			&cli.StringFlag{
+				Name:    "user-agent",
+				Usage:   "append a product such as \"acme-ci/1.0\" to the User-Agent of requests made by osv-scanner, which otherwise identifies only the version of osv-scanner",
+				EnvVars: []string{"OSV_SCANNER_USER_AGENT"},
+			},
+			&cli.StringSliceFlag{
+				Name:    "severity-exit-codes",
+				Usage:   "exit with a different code depending on the severity of the vulnerabilities that fail the scan, such as critical=2; buckets can be: " + strings.Join(osvscanner.SeverityBuckets, ", "),
+				EnvVars: []string{"OSV_SCANNER_SEVERITY_EXIT_CODES"},
+			},
+			&cli.StringFlag{
+				Name:  "cvss-version",
+				Usage: "which version of CVSS vector to use for the severity score of vulnerabilities that have more than one, where auto uses the highest across all of them; value can be: auto, v2, v3, v4",
+				Value: osvscanner.CVSSVersionAuto,
+				Action: func(context *cli.Context, s string) error {
+					switch s {
+					case osvscanner.CVSSVersionAuto, osvscanner.CVSSVersionV2, osvscanner.CVSSVersionV3, osvscanner.CVSSVersionV4:
+						return nil
+					}
+
+					return fmt.Errorf("unsupported --cvss-version value \"%s\" - must be one of: %s, %s, %s, %s", s, osvscanner.CVSSVersionAuto, osvscanner.CVSSVersionV2, osvscanner.CVSSVersionV3, osvscanner.CVSSVersionV4)
+				},
+			},
+			&cli.BoolFlag{
+				Name:  "show-aliases",
+				Usage: "include the aliases (such as CVE IDs) of each vulnerability in the table and markdown output",
+			},
+			&cli.BoolFlag{
+				Name:  "stream",
+				Usage: "print the results of each source as soon as it has been scanned (table, markdown and json formats only)",
+			},
+			&cli.BoolFlag{
+				Name:  "json-compact",
+				Usage: "print the json output on a single line without indentation, for tools that read compact or line-delimited JSON",
+			},
+			&cli.BoolFlag{
+				Name:  "show-dependency-relationship",
+				Usage: "indicate whether each package is a direct or transitive dependency, for lockfiles that record it",
+			},
+			&cli.BoolFlag{
+				Name:  "include-graph",
+				Usage: "include which packages depend on each other in the JSON output, for lockfiles that record it",
+			},
+			&cli.BoolFlag{
+				Name:  "show-filtered",
+				Usage: "include the vulnerabilities ignored by config files and inline comments in the json and sarif output, marked as filtered along with why",
+			},
+			&cli.BoolFlag{
+				Name:  "show-affected-ranges",
+				Usage: "show the version ranges of each vulnerable package that are affected, such as \">=1.0.0, <1.2.3\"",
+			},
+			&cli.BoolFlag{
+				Name:  "show-summary",
+				Usage: "include the one-line summary of each vulnerability in the table, markdown and json output",
+			},
+			&cli.BoolFlag{
+				Name:  "show-related",
+				Usage: "include the ids of vulnerabilities that are related to each vulnerability (but are not aliases of it) in the table, markdown and json output",
+			},
+			&cli.BoolFlag{
+				Name:  "show-call-paths",
+				Usage: "include the chain of function calls that reaches each called vulnerable symbol found by call analysis in the table, markdown and json output",
+			},
+			&cli.IntFlag{
+				Name:  "max-vulns",
+				Usage: "limit the number of vulnerabilities shown in the table and markdown output, with 0 meaning no limit",
+				Action: func(context *cli.Context, n int) error {
+					if n < 0 {
+						return fmt.Errorf("--max-vulns must not be negative, got %d", n)
+					}
+
+					return nil
+				},
+			},
+			&cli.IntFlag{
+				Name:  "parallelism",
+				Usage: "number of files to extract packages from at the same time, with 0 meaning the number of CPUs",
+				Action: func(context *cli.Context, n int) error {
+					if n < 0 {
+						return fmt.Errorf("--parallelism must not be negative, got %d", n)
+					}
+
+					return nil
+				},
+			},
+			&cli.BoolFlag{
+				Name:  "allow-parse-errors",
+				Usage: "continue scanning when a lockfile cannot be parsed, reporting the results of the other files and exiting with a distinct code",
+			},
+			&cli.StringFlag{
+				Name:      "lockfile-path-prefix-strip",
+				Usage:     "shows the paths of sources relative to this path rather than the working directory",
+				TakesFile: true,
+			},
 			&cli.StringFlag{
 				Name:      "output",
-				Usage:     "saves the result to the given file path",
+				Usage:     "saves the result to the given file path, which is gzip compressed if it ends in .gz",
+				TakesFile: true,
+			},
+			&cli.BoolFlag{
+				Name:  "profile",
+				Usage: "print a breakdown of the time spent extracting packages from each file, querying for vulnerabilities and fetching licenses once the scan is done, except with the json format",
+			},
+			&cli.StringFlag{
+				Name:      "profile-json",
+				Usage:     "write the breakdown of the time spent in each phase of the scan to the given file path as json",
 				TakesFile: true,
 			},
 			&cli.BoolFlag{
@@ -72,12 +298,31 @@ func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
 				Usage: "skip scanning git repositories",
 				Value: false,
 			},
+			&cli.BoolFlag{
+				Name:  "skip-git-submodules",
+				Usage: "skip scanning the submodules of git repositories, while still scanning the repositories themselves",
+				Value: false,
+			},
 			&cli.BoolFlag{
 				Name:    "recursive",
 				Aliases: []string{"r"},
 				Usage:   "check subdirectories",
 				Value:   false,
 			},
+			&cli.IntFlag{
+				Name:  "max-depth",
+				Usage: "limits how many directories deep --recursive checks subdirectories, with 0 only checking the given directories",
+				Action: func(context *cli.Context, n int) error {
+					if n < 0 {
+						return fmt.Errorf("--max-depth must not be negative, got %d", n)
+					}
+					if !context.Bool("recursive") {
+						return errors.New("--max-depth can only be used with --recursive")
+					}
+
+					return nil
+				},
+			},
 			&cli.BoolFlag{
 				Name:  "experimental-call-analysis",
 				Usage: "[Deprecated] attempt call analysis on code to detect only active vulnerabilities",
@@ -88,6 +333,10 @@ func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
 				Usage: "also scan files that would be ignored by .gitignore",
 				Value: false,
 			},
+			&cli.StringSliceFlag{
+				Name:  "exclude",
+				Usage: "skip paths matching this glob (such as \"**/testdata/**\") when scanning directories; can be repeated",
+			},
 			&cli.StringSliceFlag{
 				Name:  "call-analysis",
 				Usage: "attempt call analysis on code to detect only active vulnerabilities",
@@ -96,24 +345,93 @@ func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
 				Name:  "no-call-analysis",
 				Usage: "disables call graph analysis",
 			},
+			&cli.BoolFlag{
+				Name:  "only-called",
+				Usage: "only show the vulnerabilities that call analysis determined are being called",
+			},
+			&cli.BoolFlag{
+				Name:  "only-uncalled",
+				Usage: "only show the vulnerabilities that call analysis determined are not being called",
+			},
+			&cli.StringSliceFlag{
+				Name:  "only-ecosystems",
+				Usage: "only show the packages from these ecosystems, while still scanning every package",
+			},
+			&cli.StringFlag{
+				Name:    "webhook-url",
+				Usage:   "post a summary of the results to this webhook url after the scan, such as for a chat channel",
+				EnvVars: []string{"OSV_SCANNER_WEBHOOK_URL"},
+			},
+			&cli.StringFlag{
+				Name:  "webhook-on",
+				Usage: "when to post the summary to the webhook; value can be: " + reporter.WebhookOnAlways + ", " + reporter.WebhookOnFindings,
+				Value: reporter.WebhookOnAlways,
+			},
 			&cli.StringFlag{
 				Name:  "verbosity",
 				Usage: "specify the level of information that should be provided during runtime; value can be: " + strings.Join(reporter.VerbosityLevels(), ", "),
 				Value: "info",
 			},
+			&cli.StringFlag{
+				Name:  "log-format",
+				Usage: "sets the format of the information provided during runtime, separately from the results; value can be: " + strings.Join(reporter.LogFormats(), ", "),
+				Value: "text",
+				Action: func(context *cli.Context, s string) error {
+					if slices.Contains(reporter.LogFormats(), s) {
+						return nil
+					}
+
+					return fmt.Errorf("unsupported log format \"%s\" - must be one of: %s", s, strings.Join(reporter.LogFormats(), ", "))
+				},
+			},
 			&cli.BoolFlag{
 				Name:  "experimental-local-db",
 				Usage: "checks for vulnerabilities using local databases",
 			},
 			&cli.BoolFlag{
-				Name:  "experimental-offline",
-				Usage: "checks for vulnerabilities using local databases that are already cached",
+				Name:    "experimental-offline",
+				Usage:   "checks for vulnerabilities using local databases that are already cached",
+				EnvVars: []string{"OSV_SCANNER_OFFLINE"},
+			},
+			&cli.StringSliceFlag{
+				Name:  "offline-db-ecosystems",
+				Usage: "only download and check against the local databases of these ecosystems, skipping packages from other ecosystems",
+			},
+			&cli.DurationFlag{
+				Name:  "offline-db-timeout",
+				Usage: "the maximum time to spend downloading each local database, including retries, such as 10m; an interrupted download is resumed by the next scan, and 0 means no limit",
 			},
 			&cli.StringFlag{
 				Name:   "experimental-local-db-path",
 				Usage:  "sets the path that local databases should be stored",
 				Hidden: true,
 			},
+			&cli.StringFlag{
+				Name:      "offline-vulnerabilities",
+				Usage:     "checks for vulnerabilities using only the bundle at this path, without network access",
+				EnvVars:   []string{"OSV_SCANNER_OFFLINE_VULNERABILITIES"},
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Name:      "dump-config",
+				Usage:     "writes the config that would be used to scan the given paths to this path as TOML, and exits without scanning",
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Name:      "write-config-template",
+				Usage:     "writes a commented osv-scanner.toml template to this path (or stdout if \"-\"), and exits without scanning",
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Name:      "validate-config",
+				Usage:     "checks the config file at this path for problems such as malformed ids and expired ignores, and exits without scanning",
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Name:      "export-offline-vulnerabilities",
+				Usage:     "saves the vulnerabilities found by the scan to a bundle at this path, for use with --offline-vulnerabilities",
+				TakesFile: true,
+			},
 			&cli.BoolFlag{
 				Name:  "experimental-all-packages",
 				Usage: "when json output is selected, prints all packages",
@@ -124,7 +442,11 @@ func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
 			},
 			&cli.StringSliceFlag{
 				Name:  "experimental-licenses",
-				Usage: "report on licenses based on an allowlist",
+				Usage: "report on licenses based on an allowlist, with @path/to/file loading the allowlist from a file",
+			},
+			&cli.StringSliceFlag{
+				Name:  "license-denylist",
+				Usage: "report packages with any of the given licenses, with @path/to/file loading the denylist from a file",
 			},
 			&cli.StringFlag{
 				Name:      "experimental-oci-image",
@@ -133,7 +455,7 @@ func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
 				Hidden:    true,
 			},
 		},
-		ArgsUsage: "[directory1 directory2...]",
+		ArgsUsage: "[directory1 directory2...] (or .zip/.tar.gz archives of source code)",
 		Action: func(c *cli.Context) error {
 			var err error
 			*r, err = action(c, stdout, stderr)
@@ -154,11 +476,15 @@ func action(context *cli.Context, stdout, stderr io.Writer) (reporter.Reporter,
 
 	termWidth := 0
 	var err error
+	var outputFile io.WriteCloser
 	if outputPath != "" { // Output is definitely a file
-		stdout, err = os.Create(outputPath)
+		outputFile, err = createOutputFile(outputPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create output file: %w", err)
+			return nil, err
 		}
+		// Closing is checked after the results are written, this only catches early returns
+		defer outputFile.Close()
+		stdout = outputFile
 	} else { // Output might be a terminal
 		if stdoutAsFile, ok := stdout.(*os.File); ok {
 			termWidth, _, err = term.GetSize(int(stdoutAsFile.Fd()))
@@ -171,26 +497,77 @@ func action(context *cli.Context, stdout, stderr io.Writer) (reporter.Reporter,
 	if context.Bool("experimental-licenses-summary") && context.IsSet("experimental-licenses") {
 		return nil, errors.New("--experimental-licenses-summary and --experimental-licenses flags cannot be set")
 	}
-	allowlist := context.StringSlice("experimental-licenses")
-	if context.IsSet("experimental-licenses") {
-		if len(allowlist) == 0 ||
-			(len(allowlist) == 1 && allowlist[0] == "") {
-			return nil, errors.New("--experimental-licenses requires at least one value")
-		}
-		if unrecognized := spdx.Unrecognized(allowlist); len(unrecognized) > 0 {
-			return nil, fmt.Errorf("--experimental-licenses requires comma-separated spdx licenses. The following license(s) are not recognized as spdx: %s", strings.Join(unrecognized, ","))
-		}
+	if context.IsSet("license-denylist") && context.IsSet("experimental-licenses") {
+		return nil, errors.New("--license-denylist and --experimental-licenses flags cannot both be set")
+	}
+	if context.Bool("experimental-licenses-summary") && context.IsSet("license-denylist") {
+		return nil, errors.New("--experimental-licenses-summary and --license-denylist flags cannot be set")
+	}
+	if context.Bool("only-called") && context.Bool("only-uncalled") {
+		return nil, errors.New("--only-called and --only-uncalled flags cannot both be set")
+	}
+	allowlist, err := parseLicensesFlag(context, "experimental-licenses")
+	if err != nil {
+		return nil, err
+	}
+	denylist, err := parseLicensesFlag(context, "license-denylist")
+	if err != nil {
+		return nil, err
+	}
+
+	severityExitCodes, err := parseSeverityExitCodes(context.StringSlice("severity-exit-codes"))
+	if err != nil {
+		return nil, err
+	}
+
+	apiHeaders, err := parseAPIHeaders(context.StringSlice("api-header"))
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent, err := appendUserAgent(osv.RequestUserAgent, context.String("user-agent"))
+	if err != nil {
+		return nil, err
 	}
 
 	verbosityLevel, err := reporter.ParseVerbosityLevel(context.String("verbosity"))
 	if err != nil {
 		return nil, err
 	}
-	r, err := reporter.New(format, stdout, stderr, verbosityLevel, termWidth)
+	r, err := reporter.NewWithOptions(format, stdout, stderr, verbosityLevel, termWidth, reporter.Options{
+		ShowAliases:                context.Bool("show-aliases"),
+		BasePath:                   context.String("lockfile-path-prefix-strip"),
+		ShowDependencyRelationship: context.Bool("show-dependency-relationship"),
+		ShowAffectedRanges:         context.Bool("show-affected-ranges"),
+		ShowSummary:                context.Bool("show-summary"),
+		ShowRelated:                context.Bool("show-related"),
+		ShowCallPaths:              context.Bool("show-call-paths"),
+		Stream:                     context.Bool("stream"),
+		MaxVulns:                   context.Int("max-vulns"),
+		LogFormat:                  context.String("log-format"),
+		OnlyCalled:                 context.Bool("only-called"),
+		OnlyUncalled:               context.Bool("only-uncalled"),
+		OnlyEcosystems:             context.StringSlice("only-ecosystems"),
+		JSONCompact:                context.Bool("json-compact"),
+		WebhookURL:                 context.String("webhook-url"),
+		WebhookOn:                  context.String("webhook-on"),
+		Metadata:                   scanMetadata(context),
+	})
 	if err != nil {
 		return r, err
 	}
 
+	// only the names are logged, as the values of the headers may be secrets
+	for name := range apiHeaders {
+		r.Verbosef("Adding the %s header to requests made to the OSV API\n", name)
+	}
+	osv.RequestHeaders = apiHeaders
+
+	if userAgent != osv.RequestUserAgent {
+		r.Verbosef("Using the User-Agent %q for requests\n", userAgent)
+	}
+	osv.RequestUserAgent = userAgent
+
 	var callAnalysisStates map[string]bool
 	if context.IsSet("experimental-call-analysis") {
 		callAnalysisStates = createCallAnalysisStates([]string{"all"}, context.StringSlice("no-call-analysis"))
@@ -199,20 +576,78 @@ func action(context *cli.Context, stdout, stderr io.Writer) (reporter.Reporter,
 		callAnalysisStates = createCallAnalysisStates(context.StringSlice("call-analysis"), context.StringSlice("no-call-analysis"))
 	}
 
-	vulnResult, err := osvscanner.DoScan(osvscanner.ScannerActions{
-		LockfilePaths:        context.StringSlice("lockfile"),
-		SBOMPaths:            context.StringSlice("sbom"),
-		DockerContainerNames: context.StringSlice("docker"),
-		Recursive:            context.Bool("recursive"),
-		SkipGit:              context.Bool("skip-git"),
-		NoIgnore:             context.Bool("no-ignore"),
-		ConfigOverridePath:   context.String("config"),
-		DirectoryPaths:       context.Args().Slice(),
-		CallAnalysisStates:   callAnalysisStates,
+	lockfilePaths := context.StringSlice("lockfile")
+	if context.IsSet("paths-from") {
+		paths, err := readPathsFrom(context.String("paths-from"), context.App.Reader)
+		if err != nil {
+			return r, err
+		}
+		lockfilePaths = append(lockfilePaths, paths...)
+	}
+
+	var modifiedSince time.Time
+	if context.IsSet("modified-since") {
+		modifiedSince, err = parseModifiedSince(context.String("modified-since"))
+		if err != nil {
+			return r, err
+		}
+	}
+
+	// a max depth of 0 only checks the given directories, the same as not being recursive
+	recursive := context.Bool("recursive") && !(context.IsSet("max-depth") && context.Int("max-depth") == 0)
+
+	actions := osvscanner.ScannerActions{
+		LockfilePaths:           lockfilePaths,
+		SBOMPaths:               context.StringSlice("sbom"),
+		DockerContainerNames:    context.StringSlice("docker"),
+		KubernetesManifestPaths: context.StringSlice("k8s"),
+		PURLs:                   context.StringSlice("purl"),
+		GitCommits:              context.StringSlice("query-hash"),
+		StrictEcosystems:        context.String("strict-ecosystems"),
+		FailOn:                  context.String("fail-on"),
+		CVSSVersion:             context.String("cvss-version"),
+		SeverityExitCodes:       severityExitCodes,
+		CheckDrift:              context.Bool("check-drift"),
+		FailOnDrift:             context.Bool("fail-on-drift"),
+		ReportDuplicateVersions: context.Bool("report-duplicate-versions"),
+		PreferLockfiles:         context.StringSlice("prefer-lockfile"),
+		ScanRuntimes:            context.Bool("scan-runtimes"),
+		MinPackages:             context.Int("min-packages"),
+		RequireEcosystems:       context.StringSlice("require-ecosystems"),
+		Parallelism:             context.Int("parallelism"),
+		AllowParseErrors:        context.Bool("allow-parse-errors"),
+		Recursive:               recursive,
+		MaxDepth:                context.Int("max-depth"),
+		SkipGit:                 context.Bool("skip-git"),
+		SkipGitSubmodules:       context.Bool("skip-git-submodules"),
+		NoIgnore:                context.Bool("no-ignore"),
+		ExcludePatterns:         context.StringSlice("exclude"),
+		ConfigOverridePath:      context.String("config"),
+		DirectoryPaths:          context.Args().Slice(),
+		CallAnalysisStates:      callAnalysisStates,
+		OnlyFixable:             context.Bool("only-fixable"),
+		StrictMatching:          context.Bool("strict-matching"),
+		ModifiedSince:           modifiedSince,
+		ResolveConstraints:      context.Bool("resolve-constraints"),
+
+		ExcludeDevDependencies: !context.Bool("include-dev-dependencies"),
+
+		ShowDependencyRelationship: context.Bool("show-dependency-relationship"),
+		ShowAffectedRanges:         context.Bool("show-affected-ranges"),
+		ShowSummary:                context.Bool("show-summary"),
+		ShowRelated:                context.Bool("show-related"),
+		ShowCallPaths:              context.Bool("show-call-paths"),
+		ShowFiltered:               context.Bool("show-filtered"),
+		IncludeGraph:               context.Bool("include-graph"),
+
+		OfflineVulnerabilitiesPath:       context.String("offline-vulnerabilities"),
+		ExportOfflineVulnerabilitiesPath: context.String("export-offline-vulnerabilities"),
 		ExperimentalScannerActions: osvscanner.ExperimentalScannerActions{
-			LocalDBPath:    context.String("experimental-local-db-path"),
-			CompareLocally: context.Bool("experimental-local-db"),
-			CompareOffline: context.Bool("experimental-offline"),
+			LocalDBPath:       context.String("experimental-local-db-path"),
+			LocalDBEcosystems: context.StringSlice("offline-db-ecosystems"),
+			LocalDBTimeout:    context.Duration("offline-db-timeout"),
+			CompareLocally:    context.Bool("experimental-local-db"),
+			CompareOffline:    context.Bool("experimental-offline"),
 			// License summary mode causes all
 			// packages to appear in the json as
 			// every package has a license - even
@@ -220,12 +655,35 @@ func action(context *cli.Context, stdout, stderr io.Writer) (reporter.Reporter,
 			ShowAllPackages: context.Bool("experimental-all-packages") ||
 				context.Bool("experimental-licenses-summary"),
 			ScanLicensesSummary:   context.Bool("experimental-licenses-summary"),
-			ScanLicensesAllowlist: context.StringSlice("experimental-licenses"),
+			ScanLicensesAllowlist: allowlist,
+			ScanLicensesDenylist:  denylist,
 			ScanOCIImage:          context.String("experimental-oci-image"),
 		},
-	}, r)
+	}
+
+	if context.IsSet("write-config-template") {
+		return r, writeConfigTemplate(r, stdout, context.String("write-config-template"))
+	}
+
+	if context.IsSet("validate-config") {
+		return r, osvscanner.ValidateConfig(r, context.String("validate-config"))
+	}
+
+	if context.IsSet("dump-config") {
+		return r, osvscanner.DumpConfig(r, actions, context.String("dump-config"))
+	}
+
+	if context.Bool("profile") || context.IsSet("profile-json") {
+		actions.Profile = &osvscanner.Profile{}
+	}
+
+	vulnResult, err := osvscanner.DoScan(actions, r)
 
-	if err != nil && !errors.Is(err, osvscanner.VulnerabilitiesFoundErr) {
+	// the results of the sources that were scanned before the OSV API became unreachable are still reported
+	var unreachable *osv.UnreachableError
+	partial := errors.As(err, &unreachable) && len(vulnResult.Results) > 0
+
+	if err != nil && !partial && !errors.Is(err, osvscanner.VulnerabilitiesFoundErr) && !errors.Is(err, osvscanner.ErrParseFailures) {
 		return r, err
 	}
 
@@ -233,6 +691,91 @@ func action(context *cli.Context, stdout, stderr io.Writer) (reporter.Reporter,
 		return r, fmt.Errorf("failed to write output: %w", errPrint)
 	}
 
+	if outputFile != nil {
+		if errClose := outputFile.Close(); errClose != nil {
+			return r, fmt.Errorf("failed to write output: %w", errClose)
+		}
+	}
+
+	if errProfile := reportProfile(r, actions.Profile, format, context.Bool("profile"), context.String("profile-json")); errProfile != nil {
+		return r, errProfile
+	}
+
 	// This may be nil.
 	return r, err
 }
+
+// reportProfile prints the breakdown of the time spent in each phase of the scan if it was requested
+// (unless the output is json, which it would get mixed up with), and writes it to profileJSONPath if it is set
+func reportProfile(r reporter.Reporter, profile *osvscanner.Profile, format string, printProfile bool, profileJSONPath string) error {
+	if profile == nil {
+		return nil
+	}
+
+	if printProfile && format != "json" {
+		profile.Print(r)
+	}
+
+	if profileJSONPath == "" {
+		return nil
+	}
+
+	f, err := os.Create(profileJSONPath)
+	if err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	defer f.Close()
+
+	if err := profile.WriteJSON(f); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	return nil
+}
+
+// parseLicensesFlag expands and validates the licenses given to the named flag
+func parseLicensesFlag(context *cli.Context, name string) ([]string, error) {
+	licenses, err := expandLicenseAllowlist(context.StringSlice(name))
+	if err != nil {
+		return nil, err
+	}
+	if context.IsSet(name) {
+		if len(licenses) == 0 ||
+			(len(licenses) == 1 && licenses[0] == "") {
+			return nil, fmt.Errorf("--%s requires at least one value", name)
+		}
+		if unrecognized := spdx.Unrecognized(licenses); len(unrecognized) > 0 {
+			return nil, fmt.Errorf("--%s requires comma-separated spdx licenses. The following license(s) are not recognized as spdx: %s", name, strings.Join(unrecognized, ","))
+		}
+	}
+
+	return licenses, nil
+}
+
+// parseModifiedSince parses the value of the --modified-since flag, which is either a date
+// (taken to be the start of that day in UTC) or a time in RFC 3339 format
+func parseModifiedSince(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--modified-since must be a date (YYYY-MM-DD) or RFC 3339 time, got %q", s)
+	}
+
+	return t, nil
+}
+
+// scanMetadata describes the build of osv-scanner and the arguments it was run with, for provenance
+func scanMetadata(context *cli.Context) *models.ScanMetadata {
+	args, _ := context.App.Metadata["args"].([]string)
+
+	return &models.ScanMetadata{
+		Version:   version.OSVVersion,
+		Commit:    version.Commit,
+		BuildDate: version.BuildDate,
+		ScannedAt: time.Now().UTC(),
+		Args:      redactSecretFlags(args),
+	}
+}
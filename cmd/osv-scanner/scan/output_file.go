@@ -0,0 +1,36 @@
+package scan
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// gzipFile compresses everything written to it into the underlying file
+type gzipFile struct {
+	*gzip.Writer
+	file *os.File
+}
+
+// Close flushes the remaining compressed output and closes the underlying file
+func (g gzipFile) Close() error {
+	return errors.Join(g.Writer.Close(), g.file.Close())
+}
+
+// createOutputFile creates the file that the results are saved to, which
+// is transparently gzip compressed if the path ends in ".gz"
+func createOutputFile(path string) (io.WriteCloser, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if strings.HasSuffix(path, ".gz") {
+		return gzipFile{Writer: gzip.NewWriter(f), file: f}, nil
+	}
+
+	return f, nil
+}
@@ -0,0 +1,71 @@
+package scan
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateOutputFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		filename string
+		gzipped  bool
+	}{
+		{
+			name:     "plain",
+			filename: "results.json",
+			gzipped:  false,
+		},
+		{
+			name:     "gzip",
+			filename: "results.json.gz",
+			gzipped:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), tt.filename)
+
+			out, err := createOutputFile(path)
+			if err != nil {
+				t.Fatalf("createOutputFile() error = %v", err)
+			}
+			if _, err := io.WriteString(out, "{}\n"); err != nil {
+				t.Fatalf("failed to write output: %v", err)
+			}
+			if err := out.Close(); err != nil {
+				t.Fatalf("failed to close output: %v", err)
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatalf("failed to open output: %v", err)
+			}
+			defer f.Close()
+
+			var in io.Reader = f
+			if tt.gzipped {
+				in, err = gzip.NewReader(f)
+				if err != nil {
+					t.Fatalf("output is not gzipped: %v", err)
+				}
+			}
+
+			got, err := io.ReadAll(in)
+			if err != nil {
+				t.Fatalf("failed to read output: %v", err)
+			}
+			if string(got) != "{}\n" {
+				t.Errorf("output = %q, want %q", got, "{}\n")
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package scan
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readPathsFrom reads the newline-delimited lockfile paths from the file at path,
+// or from stdin if path is "-", ignoring empty lines and lines starting with "#".
+//
+// Each path supports the same "parse-as:path" syntax as the --lockfile flag.
+func readPathsFrom(path string, stdin io.Reader) ([]string, error) {
+	in := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read paths: %w", err)
+		}
+		defer f.Close()
+
+		in = f
+	}
+
+	var paths []string
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line != "" && !strings.HasPrefix(line, "#") {
+			paths = append(paths, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read paths from %s: %w", path, err)
+	}
+
+	return paths, nil
+}
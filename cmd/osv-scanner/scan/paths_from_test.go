@@ -0,0 +1,50 @@
+package scan
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadPathsFrom(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		path    string
+		stdin   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "file",
+			path: "fixtures/paths-from.txt",
+			want: []string{"path/to/package-lock.json", "requirements.txt:path/to/extra-requirements.txt"},
+		},
+		{
+			name:  "stdin",
+			path:  "-",
+			stdin: "# comment\r\nCargo.lock\r\n\r\n:my:dir/yarn.lock\r\n",
+			want:  []string{"Cargo.lock", ":my:dir/yarn.lock"},
+		},
+		{
+			name:    "missing file",
+			path:    "fixtures/does-not-exist.txt",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := readPathsFrom(tt.path, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readPathsFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readPathsFrom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package scan
+
+import (
+	"slices"
+	"strings"
+)
+
+// secretFlags are the flags whose values may be secrets, with how to redact each value
+var secretFlags = map[string]func(value string) string{
+	"api-header": redactAPIHeader,
+	// webhook urls such as those of Slack and Teams include their token, so the whole url is secret
+	"webhook-url": func(string) string { return "<redacted>" },
+}
+
+// redactSecretFlags replaces the values of the secretFlags in args, so that the
+// arguments can be recorded without leaking any secrets
+func redactSecretFlags(args []string) []string {
+	redacted := slices.Clone(args)
+	for i := 0; i < len(redacted); i++ {
+		arg := redacted[i]
+
+		// arguments after a lone "--" are never flags
+		if arg == "--" {
+			break
+		}
+
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		flag, value, hasValue := strings.Cut(arg, "=")
+		redact, ok := secretFlags[strings.TrimLeft(flag, "-")]
+		if !ok {
+			continue
+		}
+
+		if hasValue {
+			redacted[i] = flag + "=" + redact(value)
+		} else if i+1 < len(redacted) {
+			redacted[i+1] = redact(redacted[i+1])
+			i++
+		}
+	}
+
+	return redacted
+}
@@ -0,0 +1,42 @@
+package scan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactSecretFlags(t *testing.T) {
+	t.Parallel()
+
+	args := []string{
+		"scan",
+		"--api-header", "Authorization: Bearer secret-token",
+		"-api-header=X-Token: secret-token",
+		"--api-header=secret-token",
+		"--webhook-url", "https://hooks.slack.com/services/T000/B000/secret-token",
+		"--webhook-url=https://example.com/hook?token=secret-token",
+		"--webhook-on", "findings",
+		"--format", "json",
+		"--", "--api-header", "not-a-flag",
+	}
+
+	want := []string{
+		"scan",
+		"--api-header", "Authorization: <redacted>",
+		"-api-header=X-Token: <redacted>",
+		"--api-header=<redacted>",
+		"--webhook-url", "<redacted>",
+		"--webhook-url=<redacted>",
+		"--webhook-on", "findings",
+		"--format", "json",
+		"--", "--api-header", "not-a-flag",
+	}
+
+	if got := redactSecretFlags(args); !reflect.DeepEqual(got, want) {
+		t.Errorf("redactSecretFlags() = %v, want %v", got, want)
+	}
+
+	if args[2] != "Authorization: Bearer secret-token" {
+		t.Errorf("redactSecretFlags() modified the original args")
+	}
+}
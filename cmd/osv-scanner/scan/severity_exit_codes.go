@@ -0,0 +1,39 @@
+package scan
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scanner/pkg/osvscanner"
+)
+
+// parseSeverityExitCodes parses the "bucket=code" entries of the --severity-exit-codes flag
+// into the exit code to use for each severity bucket
+func parseSeverityExitCodes(entries []string) (map[string]int, error) {
+	exitCodes := map[string]int{}
+
+	for _, entry := range entries {
+		bucket, codeStr, ok := strings.Cut(entry, "=")
+		bucket = strings.ToLower(strings.TrimSpace(bucket))
+
+		if !ok || !slices.Contains(osvscanner.SeverityBuckets, bucket) {
+			return nil, fmt.Errorf(
+				"invalid --severity-exit-codes entry \"%s\" - must be like bucket=code, where bucket is one of: %s",
+				entry,
+				strings.Join(osvscanner.SeverityBuckets, ", "),
+			)
+		}
+
+		// exit codes above 126 are reserved for errors that are not related to the results
+		code, err := strconv.Atoi(strings.TrimSpace(codeStr))
+		if err != nil || code < 1 || code > 126 {
+			return nil, fmt.Errorf("invalid --severity-exit-codes entry \"%s\" - the exit code must be between 1 and 126", entry)
+		}
+
+		exitCodes[bucket] = code
+	}
+
+	return exitCodes, nil
+}
@@ -0,0 +1,62 @@
+package scan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSeverityExitCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []string
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name:    "none",
+			entries: nil,
+			want:    map[string]int{},
+		},
+		{
+			name:    "buckets",
+			entries: []string{"critical=2", " High = 3", "unknown=4"},
+			want:    map[string]int{"critical": 2, "high": 3, "unknown": 4},
+		},
+		{
+			name:    "unknown bucket",
+			entries: []string{"severe=2"},
+			wantErr: true,
+		},
+		{
+			name:    "missing code",
+			entries: []string{"critical"},
+			wantErr: true,
+		},
+		{
+			name:    "code is not a number",
+			entries: []string{"critical=two"},
+			wantErr: true,
+		},
+		{
+			name:    "reserved code",
+			entries: []string{"critical=128"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseSeverityExitCodes(tt.entries)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSeverityExitCodes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSeverityExitCodes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package scan
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// appendUserAgent appends the product given to the --user-agent flag to the base User-Agent,
+// which is kept so that requests from osv-scanner can still be identified by the servers
+func appendUserAgent(base, product string) (string, error) {
+	product = strings.TrimSpace(product)
+	if product == "" {
+		return base, nil
+	}
+
+	if strings.ContainsFunc(product, unicode.IsControl) {
+		return "", errors.New("invalid --user-agent value - must not contain line breaks or other control characters")
+	}
+
+	if base == "" {
+		return product, nil
+	}
+
+	return base + " " + product, nil
+}
@@ -0,0 +1,61 @@
+package scan
+
+import "testing"
+
+func TestAppendUserAgent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		base    string
+		product string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "appended to the base",
+			base:    "osv-scanner/1.2.3",
+			product: "acme-ci/4.5 (+https://ci.example.com)",
+			want:    "osv-scanner/1.2.3 acme-ci/4.5 (+https://ci.example.com)",
+		},
+		{
+			name:    "surrounding whitespace is trimmed",
+			base:    "osv-scanner/1.2.3",
+			product: "  acme-ci/4.5 ",
+			want:    "osv-scanner/1.2.3 acme-ci/4.5",
+		},
+		{
+			name:    "empty product",
+			base:    "osv-scanner/1.2.3",
+			product: "  ",
+			want:    "osv-scanner/1.2.3",
+		},
+		{
+			name:    "empty base",
+			base:    "",
+			product: "acme-ci/4.5",
+			want:    "acme-ci/4.5",
+		},
+		{
+			name:    "line break",
+			base:    "osv-scanner/1.2.3",
+			product: "acme-ci/4.5\r\nX-Injected: true",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := appendUserAgent(tt.base, tt.product)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("appendUserAgent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("appendUserAgent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package vuln
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/pkg/osv"
+	"github.com/google/osv-scanner/pkg/reporter"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+var formats = []string{"table", "json"}
+
+func Command(stdout, stderr io.Writer, r *reporter.Reporter) *cli.Command {
+	return &cli.Command{
+		Name:      "vuln",
+		Usage:     "looks up a single vulnerability by its ID in the OSV database and prints its details",
+		ArgsUsage: "<vulnerability id>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "sets the output format; value can be: " + strings.Join(formats, ", "),
+				Value:   "table",
+				Action: func(context *cli.Context, s string) error {
+					for _, format := range formats {
+						if s == format {
+							return nil
+						}
+					}
+
+					return fmt.Errorf("unsupported output format \"%s\" - must be one of: %s", s, strings.Join(formats, ", "))
+				},
+			},
+		},
+		Action: func(context *cli.Context) error {
+			var err error
+			*r, err = action(context, stdout, stderr)
+
+			return err
+		},
+	}
+}
+
+func action(context *cli.Context, stdout, stderr io.Writer) (reporter.Reporter, error) {
+	r := reporter.NewTableReporter(stdout, stderr, reporter.InfoLevel, false, 0)
+
+	if context.NArg() != 1 {
+		return r, errors.New("exactly one vulnerability id must be given")
+	}
+
+	id := context.Args().First()
+	vuln, err := osv.Get(id)
+	if err != nil {
+		return r, fmt.Errorf("failed to look up %s: %w", id, err)
+	}
+
+	if context.String("format") == "json" {
+		return r, output.PrintVulnerabilityJSON(*vuln, stdout)
+	}
+
+	termWidth := 0
+	if stdoutAsFile, ok := stdout.(*os.File); ok {
+		termWidth, _, err = term.GetSize(int(stdoutAsFile.Fd()))
+		if err != nil { // If output is not a terminal,
+			termWidth = 0
+		}
+	}
+
+	output.PrintVulnerabilityTable(*vuln, stdout, termWidth)
+
+	return r, nil
+}
@@ -57,7 +57,7 @@ func DiffVulnerabilityResults(oldRes, newRes models.VulnerabilityResults) models
 			// Rebuild the groups lost in the previous step
 			groups := grouper.Group(grouper.ConvertVulnerabilityToIDAliases(resultPV.Vulnerabilities))
 			for i, group := range groups {
-				groups[i].MaxSeverity = output.MaxSeverity(group, *resultPV)
+				groups[i].MaxSeverity = output.MaxSeverity(group, *resultPV, "")
 			}
 			resultPV.Groups = groups
 		}
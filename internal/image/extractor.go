@@ -27,6 +27,47 @@ func findArtifactExtractor(path string) (lockfile.Extractor, string) {
 	return nil, ""
 }
 
+// findPackageLayers returns the layer that introduced each of the packages, which is the
+// earliest layer in which the artifact at path contains the same version of the package
+func findPackageLayers(path string, img *Image, extractor lockfile.Extractor, packages []lockfile.PackageDetails) PackageLayers {
+	remaining := make(map[string]struct{}, len(packages))
+	for _, pkg := range packages {
+		remaining[packageLayerKey(pkg)] = struct{}{}
+	}
+
+	packageLayers := make(PackageLayers, len(packages))
+
+	for i, layer := range img.flattenedLayers {
+		if len(remaining) == 0 {
+			break
+		}
+
+		f, err := OpenLayerFile(path, layer)
+		if err != nil {
+			// the artifact does not exist yet in this layer
+			continue
+		}
+
+		layerPackages, err := extractor.Extract(f)
+		f.Close()
+		if err != nil {
+			continue
+		}
+
+		for _, pkg := range layerPackages {
+			key := packageLayerKey(pkg)
+			if _, ok := remaining[key]; !ok {
+				continue
+			}
+
+			packageLayers[key] = img.layers[i]
+			delete(remaining, key)
+		}
+	}
+
+	return packageLayers
+}
+
 func extractArtifactDeps(path string, img *Image) (lockfile.Lockfile, error) {
 	extractor, extractedAs := findArtifactExtractor(path)
 
@@ -26,13 +26,39 @@ const filePermission = 0600
 
 type ScanResults struct {
 	Lockfiles []lockfile.Lockfile
-	ImagePath string
+	// PackageLayers is the layer that introduced each package of each lockfile, keyed by the lockfile path
+	PackageLayers map[string]PackageLayers
+	ImagePath     string
+}
+
+// LayerInfo describes a layer of an image
+type LayerInfo struct {
+	// Digest is the digest of the uncompressed layer (its diff ID)
+	Digest string
+	// Command is the command that created the layer, as recorded in the history of the image
+	Command string
+}
+
+// PackageLayers maps packages to the layer that introduced them
+type PackageLayers map[string]LayerInfo
+
+func packageLayerKey(pkg lockfile.PackageDetails) string {
+	return pkg.Name + "@" + pkg.Version
+}
+
+// Get returns the layer that introduced the package, if known
+func (pl PackageLayers) Get(pkg lockfile.PackageDetails) (LayerInfo, bool) {
+	layer, ok := pl[packageLayerKey(pkg)]
+
+	return layer, ok
 }
 
 type Image struct {
 	flattenedLayers []fileMap
-	innerImage      *v1.Image
-	extractDir      string
+	// layers describes each layer, in the same order as flattenedLayers
+	layers     []LayerInfo
+	innerImage *v1.Image
+	extractDir string
 }
 
 func (img *Image) LastLayer() fileMap {
@@ -63,6 +89,12 @@ func loadImage(imagePath string) (Image, error) {
 		extractDir:      tempPath,
 		innerImage:      &image,
 		flattenedLayers: make([]fileMap, len(layers)),
+		layers:          make([]LayerInfo, len(layers)),
+	}
+
+	commands, err := layerCommands(image, len(layers))
+	if err != nil {
+		return Image{}, err
 	}
 
 	// Reverse loop through the layers to start from the latest layer first
@@ -74,6 +106,11 @@ func loadImage(imagePath string) (Image, error) {
 			return Image{}, err
 		}
 
+		outputImage.layers[i] = LayerInfo{
+			Digest:  hash.String(),
+			Command: commands[i],
+		}
+
 		dirPath := filepath.Join(tempPath, hashStr)
 		err = os.Mkdir(dirPath, dirPermission)
 		if err != nil {
@@ -198,6 +235,31 @@ func loadImage(imagePath string) (Image, error) {
 	return outputImage, nil
 }
 
+// layerCommands returns the command that created each of the layers of the image,
+// skipping history entries that did not create a layer (such as ENV instructions)
+func layerCommands(image v1.Image, layerCount int) ([]string, error) {
+	config, err := image.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	commands := make([]string, 0, layerCount)
+	for _, history := range config.History {
+		if history.EmptyLayer {
+			continue
+		}
+
+		commands = append(commands, history.CreatedBy)
+	}
+
+	// The history is optional, so it might not describe every layer
+	if len(commands) != layerCount {
+		return make([]string, layerCount), nil
+	}
+
+	return commands, nil
+}
+
 func inWhiteoutDir(fileMap fileMap, filePath string) bool {
 	for {
 		if filePath == "" {
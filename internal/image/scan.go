@@ -20,7 +20,8 @@ func ScanImage(r reporter.Reporter, imagePath string) (ScanResults, error) {
 	allFiles := img.LastLayer().AllFiles()
 
 	scannedLockfiles := ScanResults{
-		ImagePath: imagePath,
+		PackageLayers: map[string]PackageLayers{},
+		ImagePath:     imagePath,
 	}
 	for _, file := range allFiles {
 		if file.fileType != RegularFile {
@@ -30,13 +31,16 @@ func ScanImage(r reporter.Reporter, imagePath string) (ScanResults, error) {
 		parsedLockfile, err := extractArtifactDeps(file.virtualPath, &img)
 		if err != nil {
 			if !errors.Is(err, lockfile.ErrExtractorNotFound) {
-				r.Errorf("Attempted to extract lockfile but failed: %s - %v\n", file.virtualPath, err)
+				r.Warnf("Attempted to extract lockfile but failed: %s - %v\n", file.virtualPath, err)
 			}
 
 			continue
 		}
 
 		scannedLockfiles.Lockfiles = append(scannedLockfiles.Lockfiles, parsedLockfile)
+		scannedLockfiles.PackageLayers[parsedLockfile.FilePath] = findPackageLayers(
+			parsedLockfile.FilePath, &img, artifactExtractors[parsedLockfile.ParsedAs], parsedLockfile.Packages,
+		)
 	}
 
 	err = img.Cleanup()
@@ -0,0 +1,103 @@
+package local
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/osv"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// bundleDBName is the name of databases loaded from an offline vulnerabilities bundle
+const bundleDBName = "bundle"
+
+// WriteBundle writes the given vulnerabilities to a bundle at the given path,
+// which can then be used to scan without network access using MakeBundleRequest.
+//
+// A bundle uses the same format as the zip archives of the OSV database,
+// with each vulnerability stored as a "<id>.json" file in the root of the zip.
+func WriteBundle(path string, vulnerabilities []models.Vulnerability) error {
+	ids := make([]string, 0, len(vulnerabilities))
+	byID := make(map[string]models.Vulnerability, len(vulnerabilities))
+
+	for _, vulnerability := range vulnerabilities {
+		if _, ok := byID[vulnerability.ID]; !ok {
+			ids = append(ids, vulnerability.ID)
+		}
+
+		byID[vulnerability.ID] = vulnerability
+	}
+
+	slices.Sort(ids)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("could not create bundle: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create bundle: %w", err)
+	}
+	defer f.Close()
+
+	zipWriter := zip.NewWriter(f)
+
+	for _, id := range ids {
+		// ids should never contain a path separator, but better to be safe
+		w, err := zipWriter.Create(strings.ReplaceAll(id, "/", "_") + ".json")
+		if err != nil {
+			return fmt.Errorf("could not write %s to bundle: %w", id, err)
+		}
+
+		if err := json.NewEncoder(w).Encode(byID[id]); err != nil {
+			return fmt.Errorf("could not write %s to bundle: %w", id, err)
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("could not write bundle: %w", err)
+	}
+
+	return f.Close()
+}
+
+// NewBundleDB loads the vulnerabilities in the bundle at the given path,
+// without making any network requests
+func NewBundleDB(path string) (*ZipDB, error) {
+	db := &ZipDB{
+		Name:     bundleDBName,
+		Offline:  true,
+		StoredAt: path,
+	}
+	if err := db.load(); err != nil {
+		return nil, fmt.Errorf("unable to load offline vulnerabilities bundle: %w", err)
+	}
+
+	return db, nil
+}
+
+// MakeBundleRequest checks the queries against the vulnerabilities in the bundle at
+// the given path, rather than the databases of each ecosystem
+func MakeBundleRequest(r reporter.Reporter, query osv.BatchedQuery, bundlePath string) (*osv.HydratedBatchedResponse, []models.LocalDatabase, error) {
+	db, err := NewBundleDB(bundlePath)
+
+	if err != nil {
+		return &osv.HydratedBatchedResponse{}, nil, err
+	}
+
+	r.Infof("Loaded %d %s (%s) from offline vulnerabilities bundle %s\n", len(db.vulnerabilities), output.Form(len(db.vulnerabilities), "vulnerability", "vulnerabilities"), describeVersion(db), bundlePath)
+
+	resp, err := makeRequest(r, query, func(lockfile.Ecosystem) (*ZipDB, error) {
+		return db, nil
+	})
+
+	return resp, []models.LocalDatabase{db.Describe()}, err
+}
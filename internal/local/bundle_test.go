@@ -0,0 +1,102 @@
+package local_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/internal/local"
+	"github.com/google/osv-scanner/internal/testutility"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/osv"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func TestWriteBundle_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	osvs := []models.Vulnerability{
+		{ID: "GHSA-2"},
+		{ID: "GHSA-1"},
+		// duplicates should only be written once
+		{ID: "GHSA-2"},
+	}
+
+	bundlePath := filepath.Join(testutility.CreateTestDir(t), "nested", "bundle.zip")
+
+	if err := local.WriteBundle(bundlePath, osvs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	db, err := local.NewBundleDB(bundlePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectDBToHaveOSVs(t, db, []models.Vulnerability{{ID: "GHSA-1"}, {ID: "GHSA-2"}})
+}
+
+func TestNewBundleDB_DoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	_, err := local.NewBundleDB(filepath.Join(testutility.CreateTestDir(t), "bundle.zip"))
+
+	if !errors.Is(err, local.ErrOfflineDatabaseNotFound) {
+		t.Errorf("expected \"%v\" error but got \"%v\"", local.ErrOfflineDatabaseNotFound, err)
+	}
+}
+
+func TestMakeBundleRequest(t *testing.T) {
+	t.Parallel()
+
+	affected := func(ecosystem, name string) []models.Affected {
+		return []models.Affected{{
+			Package: models.Package{Ecosystem: models.Ecosystem(ecosystem), Name: name},
+			Ranges: []models.Range{{
+				Type:   models.RangeSemVer,
+				Events: []models.Event{{Introduced: "0"}, {Fixed: "2.0.0"}},
+			}},
+		}}
+	}
+
+	bundlePath := filepath.Join(testutility.CreateTestDir(t), "bundle.zip")
+
+	err := local.WriteBundle(bundlePath, []models.Vulnerability{
+		{ID: "GHSA-1", Affected: affected("npm", "mine1")},
+		{ID: "GHSA-2", Affected: affected("Go", "mine1")},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, _, err := local.MakeBundleRequest(&reporter.VoidReporter{}, osv.BatchedQuery{
+		Queries: []*osv.Query{
+			osv.MakePkgRequest(lockfile.PackageDetails{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"}),
+			osv.MakePkgRequest(lockfile.PackageDetails{Name: "mine1", Version: "2.0.0", Ecosystem: "Go"}),
+			osv.MakePkgRequest(lockfile.PackageDetails{Name: "mine2", Version: "1.0.0", Ecosystem: "PyPI"}),
+		},
+	}, bundlePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results but got %d", len(resp.Results))
+	}
+
+	if len(resp.Results[0].Vulns) != 1 || resp.Results[0].Vulns[0].ID != "GHSA-1" {
+		t.Errorf("expected only GHSA-1 to affect mine1@1.0.0 but got %v", resp.Results[0].Vulns)
+	}
+
+	if len(resp.Results[1].Vulns) != 0 {
+		t.Errorf("expected nothing to affect mine1@2.0.0 but got %v", resp.Results[1].Vulns)
+	}
+
+	if len(resp.Results[2].Vulns) != 0 {
+		t.Errorf("expected nothing to affect mine2@1.0.0 but got %v", resp.Results[2].Vulns)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/osv-scanner/pkg/lockfile"
 	"github.com/google/osv-scanner/pkg/models"
@@ -15,8 +18,8 @@ import (
 const zippedDBRemoteHost = "https://osv-vulnerabilities.storage.googleapis.com"
 const envKeyLocalDBCacheDirectory = "OSV_SCANNER_LOCAL_DB_CACHE_DIRECTORY"
 
-func loadDB(dbBasePath string, ecosystem lockfile.Ecosystem, offline bool) (*ZipDB, error) {
-	return NewZippedDB(dbBasePath, string(ecosystem), fmt.Sprintf("%s/%s/all.zip", zippedDBRemoteHost, ecosystem), offline)
+func loadDB(dbBasePath string, ecosystem lockfile.Ecosystem, offline bool, timeout time.Duration) (*ZipDB, error) {
+	return NewZippedDB(dbBasePath, string(ecosystem), fmt.Sprintf("%s/%s/all.zip", zippedDBRemoteHost, ecosystem), offline, timeout)
 }
 
 func toPackageDetails(query *osv.Query) (lockfile.PackageDetails, error) {
@@ -88,14 +91,52 @@ func setupLocalDBDirectory(localDBPath string) (string, error) {
 	return "", err
 }
 
-func MakeRequest(r reporter.Reporter, query osv.BatchedQuery, offline bool, localDBPath string) (*osv.HydratedBatchedResponse, error) {
-	results := make([]osv.Response, 0, len(query.Queries))
+// errEcosystemNotIncluded is returned when loading the database of an ecosystem
+// that has not been included in the ecosystems to download
+var errEcosystemNotIncluded = errors.New("ecosystem is not included")
+
+// isEcosystemIncluded checks if the ecosystem is in the list of ecosystems to download,
+// ignoring any release suffix (e.g. "Debian:11"); an empty list includes every ecosystem
+func isEcosystemIncluded(ecosystems []string, ecosystem lockfile.Ecosystem) bool {
+	if len(ecosystems) == 0 {
+		return true
+	}
+
+	base, _, _ := strings.Cut(string(ecosystem), ":")
+
+	return slices.ContainsFunc(ecosystems, func(e string) bool {
+		return strings.EqualFold(e, string(ecosystem)) || strings.EqualFold(e, base)
+	})
+}
+
+// describeVersion returns a human-readable version of the database based on
+// when it was last modified, for including in log messages
+func describeVersion(db *ZipDB) string {
+	lastModified := db.LastModified()
+
+	if lastModified.IsZero() {
+		return "unknown version"
+	}
+
+	return lastModified.UTC().Format(time.DateOnly)
+}
+
+// MakeRequest checks the queries against the local databases of their ecosystems, downloading
+// them if needed; if ecosystems is not empty, only the databases of those ecosystems are loaded
+// and packages from other ecosystems are skipped.
+//
+// Downloading each database gives up after timeout, unless it is 0.
+//
+// The databases that were loaded are returned in the order they were loaded
+func MakeRequest(r reporter.Reporter, query osv.BatchedQuery, offline bool, localDBPath string, ecosystems []string, timeout time.Duration) (*osv.HydratedBatchedResponse, []models.LocalDatabase, error) {
 	dbs := make(map[lockfile.Ecosystem]*ZipDB)
+	skipped := make(map[lockfile.Ecosystem]bool)
+	var loaded []models.LocalDatabase
 
 	dbBasePath, err := setupLocalDBDirectory(localDBPath)
 
 	if err != nil {
-		return &osv.HydratedBatchedResponse{}, fmt.Errorf("could not create %s: %w", dbBasePath, err)
+		return &osv.HydratedBatchedResponse{}, nil, fmt.Errorf("could not create %s: %w", dbBasePath, err)
 	}
 
 	loadDBFromCache := func(ecosystem lockfile.Ecosystem) (*ZipDB, error) {
@@ -103,25 +144,48 @@ func MakeRequest(r reporter.Reporter, query osv.BatchedQuery, offline bool, loca
 			return db, nil
 		}
 
-		db, err := loadDB(dbBasePath, ecosystem, offline)
+		if !isEcosystemIncluded(ecosystems, ecosystem) {
+			if !skipped[ecosystem] {
+				r.Warnf("Skipping packages from the %s ecosystem as it is not included in the local db ecosystems\n", ecosystem)
+				skipped[ecosystem] = true
+			}
+
+			return nil, errEcosystemNotIncluded
+		}
+
+		db, err := loadDB(dbBasePath, ecosystem, offline, timeout)
 
 		if err != nil {
 			return nil, err
 		}
 
-		r.Infof("Loaded %s local db from %s\n", db.Name, db.StoredAt)
+		r.Infof("Loaded %s local db (%s) from %s\n", db.Name, describeVersion(db), db.StoredAt)
+
+		if db.Verified {
+			r.Verbosef("Verified the checksum of the %s local db against %s\n", db.Name, db.ArchiveURL)
+		}
 
 		dbs[ecosystem] = db
+		loaded = append(loaded, db.Describe())
 
 		return db, nil
 	}
 
+	resp, err := makeRequest(r, query, loadDBFromCache)
+
+	return resp, loaded, err
+}
+
+// makeRequest checks each query against the database returned by getDB for its ecosystem
+func makeRequest(r reporter.Reporter, query osv.BatchedQuery, getDB func(ecosystem lockfile.Ecosystem) (*ZipDB, error)) (*osv.HydratedBatchedResponse, error) {
+	results := make([]osv.Response, 0, len(query.Queries))
+
 	for _, query := range query.Queries {
 		pkg, err := toPackageDetails(query)
 
 		if err != nil {
 			// currently, this will actually only error if the PURL cannot be parses
-			r.Errorf("skipping %s as it is not a valid PURL: %v\n", query.Package.PURL, err)
+			r.Warnf("skipping %s as it is not a valid PURL: %v\n", query.Package.PURL, err)
 			results = append(results, osv.Response{Vulns: []models.Vulnerability{}})
 
 			continue
@@ -140,7 +204,13 @@ func MakeRequest(r reporter.Reporter, query osv.BatchedQuery, offline bool, loca
 			continue
 		}
 
-		db, err := loadDBFromCache(pkg.Ecosystem)
+		db, err := getDB(pkg.Ecosystem)
+
+		if errors.Is(err, errEcosystemNotIncluded) {
+			results = append(results, osv.Response{Vulns: []models.Vulnerability{}})
+
+			continue
+		}
 
 		if err != nil {
 			// currently, this will actually only error if the PURL cannot be parses
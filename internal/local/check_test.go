@@ -0,0 +1,80 @@
+package local_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/osv-scanner/internal/local"
+	"github.com/google/osv-scanner/internal/testutility"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/osv"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func TestMakeRequest_WithEcosystems(t *testing.T) {
+	t.Parallel()
+
+	testDir := testutility.CreateTestDir(t)
+
+	cacheWrite(t, filepath.Join(testDir, "osv-scanner", "npm", "all.zip"), zipOSVs(t, map[string]models.Vulnerability{
+		"GHSA-2.json": {
+			ID:       "GHSA-2",
+			Modified: time.Date(2023, time.July, 4, 0, 0, 0, 0, time.UTC),
+		},
+		"GHSA-1.json": {
+			ID:       "GHSA-1",
+			Modified: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC),
+			Affected: []models.Affected{{
+				Package: models.Package{Ecosystem: "npm", Name: "mine1"},
+				Ranges: []models.Range{{
+					Type:   models.RangeSemVer,
+					Events: []models.Event{{Introduced: "0"}},
+				}},
+			}},
+		},
+	}))
+
+	r := &reporter.VoidReporter{}
+
+	// the PyPI database has not been downloaded, so this would error if it was loaded
+	resp, databases, err := local.MakeRequest(r, osv.BatchedQuery{
+		Queries: []*osv.Query{
+			osv.MakePkgRequest(lockfile.PackageDetails{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"}),
+			osv.MakePkgRequest(lockfile.PackageDetails{Name: "mine2", Version: "1.0.0", Ecosystem: "PyPI"}),
+		},
+	}, true, testDir, []string{"npm"}, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.HasErrored() {
+		t.Errorf("expected no errors to be reported")
+	}
+
+	if len(resp.Results) != 2 {
+		t.Fatalf("expected 2 results but got %d", len(resp.Results))
+	}
+
+	if len(resp.Results[0].Vulns) != 1 {
+		t.Errorf("expected mine1 to have 1 vulnerability but got %v", resp.Results[0].Vulns)
+	}
+
+	if len(resp.Results[1].Vulns) != 0 {
+		t.Errorf("expected mine2 to be skipped but got %v", resp.Results[1].Vulns)
+	}
+
+	want := []models.LocalDatabase{{
+		Name:               "npm",
+		Path:               filepath.Join(testDir, "osv-scanner", "npm", "all.zip"),
+		LastModified:       time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC),
+		VulnerabilityCount: 2,
+	}}
+
+	if !reflect.DeepEqual(databases, want) {
+		t.Errorf("expected databases to be %v but got %v", want, databases)
+	}
+}
@@ -11,10 +11,12 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/google/osv-scanner/internal/utility/vulns"
 	"github.com/google/osv-scanner/pkg/lockfile"
@@ -31,14 +33,48 @@ type ZipDB struct {
 	Offline bool
 	// the path to the zip archive on disk
 	StoredAt string
+	// whether the checksum of the zip archive was verified against the one published by the db host
+	Verified bool
+	// the maximum time to spend downloading the zip archive, including any retries, with 0 meaning no limit
+	Timeout time.Duration
 	// the vulnerabilities that are loaded into this database
 	vulnerabilities []models.Vulnerability
 }
 
 var ErrOfflineDatabaseNotFound = errors.New("no offline version of the OSV database is available")
 
-func fetchRemoteArchiveCRC32CHash(url string) (uint32, error) {
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodHead, url, nil)
+// ErrChecksumMismatch is returned when a downloaded database does not match the checksum published by the db host
+var ErrChecksumMismatch = errors.New("checksum of the downloaded OSV database archive does not match")
+
+// the number of times to try downloading a database before giving up if its checksum does not match
+const maxDownloadAttempts = 2
+
+// the number of times to try resuming the download of a database if the connection fails
+const maxDownloadRetries = 5
+
+// downloadRetryDelay is how long to wait before the first retry of a download,
+// which is doubled for each retry after that
+const downloadRetryDelay = 500 * time.Millisecond
+
+// errNoCRC32CHash is returned when the db host does not publish a crc32c checksum for an archive
+var errNoCRC32CHash = errors.New("could not find crc32c= checksum")
+
+// retryableError is a failure to download an archive that may succeed if it is tried again,
+// such as when the connection is dropped part way through
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e retryableError) Unwrap() error {
+	return e.err
+}
+
+func fetchRemoteArchiveCRC32CHash(ctx context.Context, url string) (uint32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
 
 	if err != nil {
 		return 0, err
@@ -55,7 +91,12 @@ func fetchRemoteArchiveCRC32CHash(url string) (uint32, error) {
 		return 0, fmt.Errorf("db host returned %s", resp.Status)
 	}
 
-	for _, value := range resp.Header.Values("x-goog-hash") {
+	return parseCRC32CHash(resp.Header)
+}
+
+// parseCRC32CHash returns the crc32c checksum published by the db host in the given headers
+func parseCRC32CHash(header http.Header) (uint32, error) {
+	for _, value := range header.Values("x-goog-hash") {
 		if strings.HasPrefix(value, "crc32c=") {
 			value = strings.TrimPrefix(value, "crc32c=")
 			out, err := base64.StdEncoding.DecodeString(value)
@@ -68,7 +109,7 @@ func fetchRemoteArchiveCRC32CHash(url string) (uint32, error) {
 		}
 	}
 
-	return 0, errors.New("could not find crc32c= checksum")
+	return 0, errNoCRC32CHash
 }
 
 func fetchLocalArchiveCRC32CHash(data []byte) uint32 {
@@ -86,19 +127,144 @@ func (db *ZipDB) fetchZip() ([]byte, error) {
 		return cache, nil
 	}
 
+	ctx := context.Background()
+	if db.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, db.Timeout)
+		defer cancel()
+	}
+
 	if err == nil {
-		remoteHash, err := fetchRemoteArchiveCRC32CHash(db.ArchiveURL)
+		remoteHash, err := fetchRemoteArchiveCRC32CHash(ctx, db.ArchiveURL)
 
 		if err != nil {
 			return nil, err
 		}
 
 		if fetchLocalArchiveCRC32CHash(cache) == remoteHash {
+			db.Verified = true
+
 			return cache, nil
 		}
 	}
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, db.ArchiveURL, nil)
+	var body []byte
+
+	for attempt := 1; ; attempt++ {
+		body, err = db.downloadZip(ctx)
+
+		if !errors.Is(err, ErrChecksumMismatch) || attempt >= maxDownloadAttempts {
+			break
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("could not download OSV database archive within %s, run again to resume the download: %w", db.Timeout, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the archive is only moved into place once it is complete, so that an interrupted
+	// download does not leave behind a truncated archive to be loaded next time
+	if err := os.Rename(db.partPath(), db.StoredAt); err != nil {
+		_ = os.Remove(db.partPath())
+		_, _ = fmt.Fprintf(os.Stderr, "Failed to save database to %s: %v\n", db.StoredAt, err)
+	}
+
+	return body, nil
+}
+
+// partPath is where the zip archive is downloaded to before it is complete
+func (db *ZipDB) partPath() string {
+	return db.StoredAt + ".part"
+}
+
+// downloadZip fetches the zip archive from the db host, verifying it against
+// the checksum published by the host if there is one.
+//
+// The archive is downloaded to a .part file next to where it is stored, resuming
+// from the end of the file if a previous download was interrupted (including by a
+// previous run), and retrying with increasing delays if the connection fails
+func (db *ZipDB) downloadZip(ctx context.Context) ([]byte, error) {
+	if err := os.MkdirAll(path.Dir(db.StoredAt), 0750); err != nil {
+		return nil, fmt.Errorf("could not save OSV database archive: %w", err)
+	}
+
+	var header http.Header
+	var err error
+	delay := downloadRetryDelay
+
+	for retry := 0; ; retry++ {
+		header, err = db.downloadPart(ctx)
+
+		var retryable retryableError
+		if !errors.As(err, &retryable) || retry >= maxDownloadRetries || ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := os.ReadFile(db.partPath())
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read OSV database archive: %w", err)
+	}
+
+	remoteHash, err := parseCRC32CHash(header)
+
+	// not every host publishes a checksum, in which case we cannot verify the download
+	if errors.Is(err, errNoCRC32CHash) {
+		return body, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fetchLocalArchiveCRC32CHash(body) != remoteHash {
+		// the download may have been resumed from a different version of the archive,
+		// so the next attempt needs to start again from scratch
+		_ = os.Remove(db.partPath())
+
+		return nil, ErrChecksumMismatch
+	}
+
+	db.Verified = true
+
+	return body, nil
+}
+
+// downloadPart downloads the rest of the zip archive to the end of the .part file,
+// using a range request if the file is not empty, and returns the headers of the response
+func (db *ZipDB) downloadPart(ctx context.Context) (http.Header, error) {
+	//nolint:gosec // being world readable is fine
+	f, err := os.OpenFile(db.partPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not save OSV database archive: %w", err)
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return nil, fmt.Errorf("could not save OSV database archive: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, db.ArchiveURL, nil)
 
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve OSV database archive: %w", err)
@@ -108,37 +274,88 @@ func (db *ZipDB) fetchZip() ([]byte, error) {
 		req.Header.Set("User-Agent", osv.RequestUserAgent)
 	}
 
+	if info.Size() > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", info.Size()))
+	}
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve OSV database archive: %w", err)
+		err = fmt.Errorf("could not retrieve OSV database archive: %w", err)
+
+		if isConnectionError(err) {
+			return nil, retryableError{err}
+		}
+
+		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+	case resp.StatusCode == http.StatusOK:
+		// the host does not support range requests, so the whole archive is being sent again
+		if err := f.Truncate(0); err != nil {
+			return nil, fmt.Errorf("could not save OSV database archive: %w", err)
+		}
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// the .part file is at least as long as the archive, so it is not
+		// known whether it is complete and the download has to start again
+		if err := f.Truncate(0); err != nil {
+			return nil, fmt.Errorf("could not save OSV database archive: %w", err)
+		}
+
+		return nil, retryableError{fmt.Errorf("db host returned %s", resp.Status)}
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, retryableError{fmt.Errorf("db host returned %s", resp.Status)}
+	default:
 		return nil, fmt.Errorf("db host returned %s", resp.Status)
 	}
 
-	var body []byte
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		err = fmt.Errorf("could not read OSV database archive from response: %w", err)
 
-	body, err = io.ReadAll(resp.Body)
+		if isConnectionError(err) {
+			return nil, retryableError{err}
+		}
 
-	if err != nil {
-		return nil, fmt.Errorf("could not read OSV database archive from response: %w", err)
+		return nil, err
 	}
 
-	err = os.MkdirAll(path.Dir(db.StoredAt), 0750)
+	return resp.Header, nil
+}
 
-	if err == nil {
-		//nolint:gosec // being world readable is fine
-		err = os.WriteFile(db.StoredAt, body, 0644)
-	}
+// isConnectionError returns true if the error is from the connection to the db host failing,
+// such as being refused or dropped part way through a response
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
 
-	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Failed to save database to %s: %v\n", db.StoredAt, err)
+	return errors.As(err, &opErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// LastModified returns the most recent modified time of the vulnerabilities
+// in the database, which is used as its version since the archives are not
+// otherwise versioned
+func (db *ZipDB) LastModified() time.Time {
+	var latest time.Time
+
+	for _, vulnerability := range db.vulnerabilities {
+		if vulnerability.Modified.After(latest) {
+			latest = vulnerability.Modified
+		}
 	}
 
-	return body, nil
+	return latest
+}
+
+// Describe returns a summary of the database, for including in the results of a scan
+func (db *ZipDB) Describe() models.LocalDatabase {
+	return models.LocalDatabase{
+		Name:               db.Name,
+		Path:               db.StoredAt,
+		LastModified:       db.LastModified(),
+		VulnerabilityCount: len(db.vulnerabilities),
+	}
 }
 
 // Loads the given zip file into the database as an OSV.
@@ -202,12 +419,13 @@ func (db *ZipDB) load() error {
 	return nil
 }
 
-func NewZippedDB(dbBasePath, name, url string, offline bool) (*ZipDB, error) {
+func NewZippedDB(dbBasePath, name, url string, offline bool, timeout time.Duration) (*ZipDB, error) {
 	db := &ZipDB{
 		Name:       name,
 		ArchiveURL: url,
 		Offline:    offline,
 		StoredAt:   path.Join(dbBasePath, name, "all.zip"),
+		Timeout:    timeout,
 	}
 	if err := db.load(); err != nil {
 		return nil, fmt.Errorf("unable to fetch OSV database: %w", err)
@@ -3,10 +3,12 @@ package local_test
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"hash/crc32"
 	"net/http"
 	"net/http/httptest"
@@ -14,7 +16,11 @@ import (
 	"path"
 	"reflect"
 	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/osv-scanner/internal/local"
 	"github.com/google/osv-scanner/internal/testutility"
@@ -145,7 +151,7 @@ func TestNewZippedDB_Offline_WithoutCache(t *testing.T) {
 		t.Errorf("a server request was made when running offline")
 	})
 
-	_, err := local.NewZippedDB(testDir, "my-db", ts.URL, true)
+	_, err := local.NewZippedDB(testDir, "my-db", ts.URL, true, 0)
 
 	if !errors.Is(err, local.ErrOfflineDatabaseNotFound) {
 		t.Errorf("expected \"%v\" error but got \"%v\"", local.ErrOfflineDatabaseNotFound, err)
@@ -177,7 +183,7 @@ func TestNewZippedDB_Offline_WithCache(t *testing.T) {
 		"GHSA-5.json": {ID: "GHSA-5"},
 	}))
 
-	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, true)
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, true, 0)
 
 	if err != nil {
 		t.Fatalf("unexpected error \"%v\"", err)
@@ -195,7 +201,7 @@ func TestNewZippedDB_BadZip(t *testing.T) {
 		_, _ = w.Write([]byte("this is not a zip"))
 	})
 
-	_, err := local.NewZippedDB(testDir, "my-db", ts.URL, false)
+	_, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
 
 	if err == nil {
 		t.Errorf("expected an error but did not get one")
@@ -207,7 +213,7 @@ func TestNewZippedDB_UnsupportedProtocol(t *testing.T) {
 
 	testDir := testutility.CreateTestDir(t)
 
-	_, err := local.NewZippedDB(testDir, "my-db", "file://hello-world", false)
+	_, err := local.NewZippedDB(testDir, "my-db", "file://hello-world", false, 0)
 
 	if err == nil {
 		t.Errorf("expected an error but did not get one")
@@ -237,7 +243,7 @@ func TestNewZippedDB_Online_WithoutCache(t *testing.T) {
 		})
 	})
 
-	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false)
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
 
 	if err != nil {
 		t.Fatalf("unexpected error \"%v\"", err)
@@ -269,7 +275,7 @@ func TestNewZippedDB_Online_WithoutCacheAndNoHashHeader(t *testing.T) {
 		}))
 	})
 
-	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false)
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
 
 	if err != nil {
 		t.Fatalf("unexpected error \"%v\"", err)
@@ -307,7 +313,7 @@ func TestNewZippedDB_Online_WithSameCache(t *testing.T) {
 
 	cacheWrite(t, determineStoredAtPath(testDir, "my-db"), cache)
 
-	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false)
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
 
 	if err != nil {
 		t.Fatalf("unexpected error \"%v\"", err)
@@ -345,7 +351,7 @@ func TestNewZippedDB_Online_WithDifferentCache(t *testing.T) {
 		"GHSA-3.json": {ID: "GHSA-3"},
 	}))
 
-	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false)
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
 
 	if err != nil {
 		t.Fatalf("unexpected error \"%v\"", err)
@@ -375,7 +381,7 @@ func TestNewZippedDB_Online_WithCacheButNoHashHeader(t *testing.T) {
 		"GHSA-3.json": {ID: "GHSA-3"},
 	}))
 
-	_, err := local.NewZippedDB(testDir, "my-db", ts.URL, false)
+	_, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
 
 	if err == nil {
 		t.Errorf("expected an error but did not get one")
@@ -403,7 +409,7 @@ func TestNewZippedDB_Online_WithBadCache(t *testing.T) {
 
 	cacheWriteBad(t, determineStoredAtPath(testDir, "my-db"), "this is not json!")
 
-	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false)
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
 
 	if err != nil {
 		t.Fatalf("unexpected error \"%v\"", err)
@@ -412,6 +418,253 @@ func TestNewZippedDB_Online_WithBadCache(t *testing.T) {
 	expectDBToHaveOSVs(t, db, osvs)
 }
 
+func TestNewZippedDB_Online_WithCorruptedDownload(t *testing.T) {
+	t.Parallel()
+
+	osvs := []models.Vulnerability{{ID: "GHSA-1"}, {ID: "GHSA-2"}}
+
+	testDir := testutility.CreateTestDir(t)
+
+	var downloads atomic.Int32
+
+	ts := createZipServer(t, func(w http.ResponseWriter, r *http.Request) {
+		z := zipOSVs(t, map[string]models.Vulnerability{
+			"GHSA-1.json": {ID: "GHSA-1"},
+			"GHSA-2.json": {ID: "GHSA-2"},
+		})
+
+		w.Header().Add("x-goog-hash", "crc32c="+computeCRC32CHash(t, z))
+
+		// only the first download is truncated
+		if downloads.Add(1) == 1 {
+			z = z[:len(z)/2]
+		}
+
+		_, _ = w.Write(z)
+	})
+
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error \"%v\"", err)
+	}
+
+	if downloads.Load() != 2 {
+		t.Errorf("expected the database to be downloaded twice, but it was downloaded %d times", downloads.Load())
+	}
+
+	if !db.Verified {
+		t.Errorf("expected the database to have been verified")
+	}
+
+	expectDBToHaveOSVs(t, db, osvs)
+}
+
+func TestNewZippedDB_Online_WithPersistentlyCorruptedDownload(t *testing.T) {
+	t.Parallel()
+
+	testDir := testutility.CreateTestDir(t)
+
+	ts := createZipServer(t, func(w http.ResponseWriter, r *http.Request) {
+		z := zipOSVs(t, map[string]models.Vulnerability{
+			"GHSA-1.json": {ID: "GHSA-1"},
+		})
+
+		w.Header().Add("x-goog-hash", "crc32c="+computeCRC32CHash(t, z))
+
+		_, _ = w.Write(z[:len(z)/2])
+	})
+
+	_, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
+
+	if !errors.Is(err, local.ErrChecksumMismatch) {
+		t.Errorf("expected \"%v\" error but got \"%v\"", local.ErrChecksumMismatch, err)
+	}
+
+	if _, err := os.Stat(determineStoredAtPath(testDir, "my-db")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the corrupted database to not be saved")
+	}
+}
+
+func TestNewZippedDB_Online_WithPartialDownload(t *testing.T) {
+	t.Parallel()
+
+	osvs := []models.Vulnerability{{ID: "GHSA-1"}}
+
+	testDir := testutility.CreateTestDir(t)
+
+	ts := createZipServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = writeOSVsZip(t, w, map[string]models.Vulnerability{
+			"GHSA-1.json": {ID: "GHSA-1"},
+		})
+	})
+
+	partPath := determineStoredAtPath(testDir, "my-db") + ".part"
+
+	// the host does not support range requests, so this is replaced rather than resumed
+	cacheWriteBad(t, partPath, "this is not a zip")
+
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error \"%v\"", err)
+	}
+
+	if _, err := os.Stat(partPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the partial download to have been moved into place")
+	}
+
+	if !db.Verified {
+		t.Errorf("expected the database to have been verified")
+	}
+
+	expectDBToHaveOSVs(t, db, osvs)
+}
+
+// createRangeZipServer serves the zip of the given vulnerabilities with support for range requests,
+// recording the Range header of each request
+func createRangeZipServer(t *testing.T, osvs map[string]models.Vulnerability, ranges *[]string, handler func(w http.ResponseWriter, r *http.Request, z []byte) bool) *httptest.Server {
+	t.Helper()
+
+	z := zipOSVs(t, osvs)
+
+	var mu sync.Mutex
+
+	return createZipServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			mu.Lock()
+			*ranges = append(*ranges, r.Header.Get("Range"))
+			mu.Unlock()
+		}
+
+		w.Header().Add("x-goog-hash", "crc32c="+computeCRC32CHash(t, z))
+
+		if handler != nil && handler(w, r, z) {
+			return
+		}
+
+		http.ServeContent(w, r, "all.zip", time.Time{}, bytes.NewReader(z))
+	})
+}
+
+func TestNewZippedDB_Online_WithInterruptedDownload(t *testing.T) {
+	t.Parallel()
+
+	osvs := []models.Vulnerability{{ID: "GHSA-1"}, {ID: "GHSA-2"}}
+
+	testDir := testutility.CreateTestDir(t)
+
+	var ranges []string
+	var downloads atomic.Int32
+
+	ts := createRangeZipServer(t, map[string]models.Vulnerability{
+		"GHSA-1.json": {ID: "GHSA-1"},
+		"GHSA-2.json": {ID: "GHSA-2"},
+	}, &ranges, func(w http.ResponseWriter, r *http.Request, z []byte) bool {
+		// the connection is dropped part way through the first download
+		if downloads.Add(1) == 1 {
+			w.Header().Set("Content-Length", strconv.Itoa(len(z)))
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(z[:100])
+			w.(http.Flusher).Flush()
+
+			panic(http.ErrAbortHandler)
+		}
+
+		return false
+	})
+
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error \"%v\"", err)
+	}
+
+	if want := []string{"", "bytes=100-"}; !reflect.DeepEqual(ranges, want) {
+		t.Errorf("expected the download to be resumed with %v, but got %v", want, ranges)
+	}
+
+	if !db.Verified {
+		t.Errorf("expected the database to have been verified")
+	}
+
+	expectDBToHaveOSVs(t, db, osvs)
+}
+
+func TestNewZippedDB_Online_WithDownloadFromPreviousRun(t *testing.T) {
+	t.Parallel()
+
+	osvs := []models.Vulnerability{{ID: "GHSA-1"}}
+
+	testDir := testutility.CreateTestDir(t)
+
+	vulns := map[string]models.Vulnerability{"GHSA-1.json": {ID: "GHSA-1"}}
+
+	var ranges []string
+
+	ts := createRangeZipServer(t, vulns, &ranges, nil)
+
+	// a previous run was interrupted after downloading the first half of the archive
+	z := zipOSVs(t, vulns)
+	cacheWrite(t, determineStoredAtPath(testDir, "my-db")+".part", z[:len(z)/2])
+
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error \"%v\"", err)
+	}
+
+	if want := []string{fmt.Sprintf("bytes=%d-", len(z)/2)}; !reflect.DeepEqual(ranges, want) {
+		t.Errorf("expected the download to be resumed with %v, but got %v", want, ranges)
+	}
+
+	expectDBToHaveOSVs(t, db, osvs)
+}
+
+func TestNewZippedDB_Online_WithStaleDownloadFromPreviousRun(t *testing.T) {
+	t.Parallel()
+
+	osvs := []models.Vulnerability{{ID: "GHSA-1"}}
+
+	testDir := testutility.CreateTestDir(t)
+
+	var ranges []string
+
+	ts := createRangeZipServer(t, map[string]models.Vulnerability{"GHSA-1.json": {ID: "GHSA-1"}}, &ranges, nil)
+
+	// the archive has changed since a previous run was interrupted, so resuming the download
+	// would result in a corrupted archive that does not match the checksum
+	cacheWriteBad(t, determineStoredAtPath(testDir, "my-db")+".part", "this is not a zip")
+
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error \"%v\"", err)
+	}
+
+	if want := []string{"bytes=17-", ""}; !reflect.DeepEqual(ranges, want) {
+		t.Errorf("expected the download to be started again after resuming it failed, but got %v", ranges)
+	}
+
+	expectDBToHaveOSVs(t, db, osvs)
+}
+
+func TestNewZippedDB_Online_WithTimeout(t *testing.T) {
+	t.Parallel()
+
+	testDir := testutility.CreateTestDir(t)
+
+	ts := createZipServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	_, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 50*time.Millisecond)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected \"%v\" error but got \"%v\"", context.DeadlineExceeded, err)
+	}
+}
+
 func TestNewZippedDB_FileChecks(t *testing.T) {
 	t.Parallel()
 
@@ -429,7 +682,7 @@ func TestNewZippedDB_FileChecks(t *testing.T) {
 		})
 	})
 
-	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false)
+	db, err := local.NewZippedDB(testDir, "my-db", ts.URL, false, 0)
 
 	if err != nil {
 		t.Fatalf("unexpected error \"%v\"", err)
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// PrintExplainTable prints the details of a single vulnerability like PrintVulnerabilityTable,
+// along with its maximum severity and how to remediate it.
+//
+// If pkg is not nil, the remediation is specific to the installed version of that package.
+func PrintExplainTable(vuln models.Vulnerability, pkg *models.PackageInfo, outputWriter io.Writer, terminalWidth int) {
+	outputTable := newTable(outputWriter, terminalWidth)
+	outputTable = vulnerabilityTableBuilder(outputTable, vuln)
+
+	group := models.GroupInfo{IDs: []string{vuln.ID}}
+	if maxSeverity := MaxSeverity(group, models.PackageVulns{Vulnerabilities: []models.Vulnerability{vuln}}, ""); maxSeverity != "" {
+		outputTable.AppendRow(table.Row{"Max CVSS", maxSeverity})
+	}
+
+	outputTable.AppendRow(table.Row{"Remediation", strings.Join(describeRemediation(vuln, pkg), "\n")})
+	outputTable.Render()
+}
+
+// describeRemediation describes how to remediate the vulnerability in each of the packages it affects,
+// or only in pkg if it is not nil, in which case the lowest fixed version above its version is suggested
+func describeRemediation(vuln models.Vulnerability, pkg *models.PackageInfo) []string {
+	fixedVersions := vuln.FixedVersions()
+
+	var described []string
+	for _, a := range vuln.Affected {
+		if a.Package.Name == "" {
+			continue
+		}
+
+		pkgKey := a.Package
+		pkgKey.Purl = ""
+		name := fmt.Sprintf("%s/%s", a.Package.Ecosystem, a.Package.Name)
+
+		var line string
+		switch {
+		case pkg != nil && (pkg.Name != a.Package.Name || pkg.BaseEcosystem() != (models.PackageInfo{Ecosystem: string(a.Package.Ecosystem)}).BaseEcosystem()):
+			continue
+		case pkg != nil:
+			fixed := minFixedVersion(
+				models.GroupInfo{IDs: []string{vuln.ID}},
+				models.PackageVulns{Package: *pkg, Vulnerabilities: []models.Vulnerability{vuln}},
+			)
+			if fixed == noFixedVersion {
+				line = fmt.Sprintf("%s@%s: no fixed version is available", name, pkg.Version)
+			} else {
+				line = fmt.Sprintf("%s@%s: upgrade to %s", name, pkg.Version, fixed)
+			}
+		case len(fixedVersions[pkgKey]) == 0:
+			line = name + ": no fixed version is available"
+		default:
+			line = fmt.Sprintf("%s: fixed in %s", name, strings.Join(fixedVersions[pkgKey], ", "))
+		}
+
+		if !slices.Contains(described, line) {
+			described = append(described, line)
+		}
+	}
+
+	if len(described) == 0 && pkg != nil {
+		described = append(described, fmt.Sprintf("%s/%s is not affected by %s", pkg.Ecosystem, pkg.Name, vuln.ID))
+	}
+
+	return described
+}
@@ -0,0 +1,48 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/internal/testutility"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestPrintExplainTable(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintExplainTable(testVulnerability(), nil, outputWriter, 0)
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
+func TestPrintExplainTable_WithPackage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		pkg  models.PackageInfo
+	}{
+		{
+			name: "affected version",
+			pkg:  models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+		},
+		{
+			name: "unaffected package",
+			pkg:  models.PackageInfo{Name: "express", Version: "4.17.20", Ecosystem: "npm"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			outputWriter := &bytes.Buffer{}
+			output.PrintExplainTable(testVulnerability(), &tt.pkg, outputWriter, 0)
+
+			testutility.NewSnapshot().MatchText(t, outputWriter.String())
+		})
+	}
+}
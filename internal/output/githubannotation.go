@@ -35,20 +35,26 @@ func createSourceRemediationTable(source models.PackageSource, groupFixedVersion
 	return remediationTable
 }
 
+// GHAnnotationOptions controls optional parts of the GitHub annotations output
+type GHAnnotationOptions struct {
+	// BasePath is the path that source paths are shown relative to, defaulting to the working directory
+	BasePath string
+}
+
 // PrintGHAnnotationReport prints Github specific annotations to outputWriter
-func PrintGHAnnotationReport(vulnResult *models.VulnerabilityResults, outputWriter io.Writer) error {
+func PrintGHAnnotationReport(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, options GHAnnotationOptions) error {
 	flattened := vulnResult.Flatten()
 
 	// TODO: Also support last affected
 	groupFixedVersions := GroupFixedVersions(flattened)
-	workingDir := mustGetWorkingDirectory()
+	basePath := sourcePathBase(options.BasePath)
 
 	for _, source := range vulnResult.Results {
 		// TODO: Support docker images
 
 		var artifactPath string
 		var err error
-		artifactPath, err = filepath.Rel(workingDir, source.Source.Path)
+		artifactPath, err = filepath.Rel(basePath, source.Source.Path)
 		if err != nil {
 			artifactPath = source.Source.Path
 		}
@@ -15,7 +15,7 @@ func TestPrintGHAnnotationReport_WithVulnerabilities(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		err := output.PrintGHAnnotationReport(args.vulnResult, outputWriter)
+		err := output.PrintGHAnnotationReport(args.vulnResult, outputWriter, output.GHAnnotationOptions{})
 
 		if err != nil {
 			t.Errorf("Error writing GH annotation output: %s", err)
@@ -32,7 +32,7 @@ func TestPrintGHAnnotationReport_WithLicenseViolations(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		err := output.PrintGHAnnotationReport(args.vulnResult, outputWriter)
+		err := output.PrintGHAnnotationReport(args.vulnResult, outputWriter, output.GHAnnotationOptions{})
 
 		if err != nil {
 			t.Errorf("Error writing GH annotation output: %s", err)
@@ -49,7 +49,7 @@ func TestPrintGHAnnotationReport_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		err := output.PrintGHAnnotationReport(args.vulnResult, outputWriter)
+		err := output.PrintGHAnnotationReport(args.vulnResult, outputWriter, output.GHAnnotationOptions{})
 
 		if err != nil {
 			t.Errorf("Error writing GH annotation output: %s", err)
@@ -4,13 +4,150 @@ import (
 	"encoding/json"
 	"io"
 
+	"github.com/google/osv-scanner/internal/version"
 	"github.com/google/osv-scanner/pkg/models"
 )
 
+// JSONSchemaVersion is the version of the structure of the JSON output.
+//
+// It should be bumped whenever a field is removed or its meaning changed, but
+// not when new fields are added, see docs/output.md for the stability policy.
+const JSONSchemaVersion = "1"
+
+// jsonOutput wraps the results with metadata about the output itself
+type jsonOutput struct {
+	SchemaVersion string               `json:"schema_version"`
+	Version       string               `json:"version"`
+	Metadata      *models.ScanMetadata `json:"metadata,omitempty"`
+	*models.VulnerabilityResults
+	VulnerabilityCount int                 `json:"vulnerability_count"`
+	Summary            jsonSummary         `json:"summary"`
+	LicenseSummary     *jsonLicenseSummary `json:"license_summary,omitempty"`
+}
+
+// jsonSummary is the number of findings in the results, so that a clean run
+// can be identified without walking through each of the results
+type jsonSummary struct {
+	AffectedPackageCount  int `json:"affected_package_count"`
+	LicenseViolationCount int `json:"license_violation_count"`
+}
+
+// countFindings returns the number of vulnerabilities (counting each group of aliases once)
+// across all the packages in the results, along with the number of packages that are affected by them
+// and the number of packages that have a license violation, not counting any that were filtered out
+func countFindings(vulnResult *models.VulnerabilityResults) (int, jsonSummary) {
+	vulnCount := 0
+	summary := jsonSummary{}
+
+	unfiltered := vulnResult.WithoutFiltered()
+	for _, source := range unfiltered.Results {
+		for _, pkg := range source.Packages {
+			vulnCount += len(pkg.Groups)
+			if len(pkg.Vulnerabilities) > 0 {
+				summary.AffectedPackageCount++
+			}
+			if len(pkg.LicenseViolations) > 0 {
+				summary.LicenseViolationCount++
+			}
+		}
+	}
+
+	return vulnCount, summary
+}
+
+// jsonStreamSummary is the metadata written after the results of each source when streaming
+type jsonStreamSummary struct {
+	SchemaVersion              string                            `json:"schema_version"`
+	Version                    string                            `json:"version"`
+	Metadata                   *models.ScanMetadata              `json:"metadata,omitempty"`
+	ExperimentalAnalysisConfig models.ExperimentalAnalysisConfig `json:"experimental_config"`
+	Databases                  []models.LocalDatabase            `json:"databases,omitempty"`
+	PackageCounts              *models.PackageCounts             `json:"package_counts,omitempty"`
+	VulnerabilityCount         int                               `json:"vulnerability_count"`
+	Summary                    jsonSummary                       `json:"summary"`
+	LicenseSummary             *jsonLicenseSummary               `json:"license_summary,omitempty"`
+}
+
+// jsonLicenseSummary is the number of package versions with each license (and each license violation,
+// if there is an allowlist) across all sources, in the same order as the license summary table
+type jsonLicenseSummary struct {
+	Licenses   []licenseCount `json:"licenses"`
+	Violations []licenseCount `json:"violations,omitempty"`
+}
+
+// newJSONLicenseSummary summarizes the licenses of the packages in the results,
+// returning nil if licenses were not scanned
+func newJSONLicenseSummary(vulnResult *models.VulnerabilityResults) *jsonLicenseSummary {
+	licenseConfig := vulnResult.ExperimentalAnalysisConfig.Licenses
+	if !licenseConfig.Summary && !licenseConfig.HasViolationPolicy() {
+		return nil
+	}
+
+	summary := &jsonLicenseSummary{
+		Licenses: licenseCounts(vulnResult, func(pkg models.PackageVulns) []models.License { return pkg.Licenses }),
+	}
+
+	if licenseConfig.HasViolationPolicy() {
+		summary.Violations = licenseCounts(vulnResult, func(pkg models.PackageVulns) []models.License { return pkg.LicenseViolations })
+	}
+
+	return summary
+}
+
+// PrintJSONSourceResult writes the results of a single source to the provided writer
+// as one line of newline-delimited JSON
+func PrintJSONSourceResult(source models.PackageSource, outputWriter io.Writer) error {
+	return json.NewEncoder(outputWriter).Encode(source)
+}
+
+// PrintJSONStreamSummary writes the metadata of the results to the provided writer
+// as one line of newline-delimited JSON, to follow the results of each source
+func PrintJSONStreamSummary(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, options JSONOptions) error {
+	vulnCount, summary := countFindings(vulnResult)
+
+	return json.NewEncoder(outputWriter).Encode(jsonStreamSummary{
+		SchemaVersion:              JSONSchemaVersion,
+		Version:                    version.OSVVersion,
+		Metadata:                   options.Metadata,
+		ExperimentalAnalysisConfig: vulnResult.ExperimentalAnalysisConfig,
+		Databases:                  vulnResult.Databases,
+		PackageCounts:              vulnResult.PackageCounts,
+		VulnerabilityCount:         vulnCount,
+		Summary:                    summary,
+		LicenseSummary:             newJSONLicenseSummary(vulnResult),
+	})
+}
+
+// JSONOptions controls optional parts of the JSON output
+type JSONOptions struct {
+	// Compact prints the results on a single line without indentation
+	Compact bool
+	// Metadata describes the build of osv-scanner and how it was run, which is omitted if nil
+	Metadata *models.ScanMetadata
+}
+
 // PrintJSONResults writes results to the provided writer in JSON format
-func PrintJSONResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer) error {
+func PrintJSONResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, options JSONOptions) error {
 	encoder := json.NewEncoder(outputWriter)
-	encoder.SetIndent("", "  ")
+	if !options.Compact {
+		encoder.SetIndent("", "  ")
+	}
+
+	// always output the results as a list, even if there are none
+	results := *vulnResult
+	if results.Results == nil {
+		results.Results = []models.PackageSource{}
+	}
+
+	vulnCount, summary := countFindings(vulnResult)
 
-	return encoder.Encode(vulnResult)
+	return encoder.Encode(jsonOutput{
+		SchemaVersion:        JSONSchemaVersion,
+		Version:              version.OSVVersion,
+		Metadata:             options.Metadata,
+		VulnerabilityResults: &results,
+		VulnerabilityCount:   vulnCount,
+		Summary:              summary,
+		LicenseSummary:       newJSONLicenseSummary(vulnResult),
+	})
 }
@@ -2,10 +2,15 @@ package output_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/osv-scanner/internal/output"
 	"github.com/google/osv-scanner/internal/testutility"
+	"github.com/google/osv-scanner/pkg/models"
 )
 
 func TestPrintJSONResults_WithVulnerabilities(t *testing.T) {
@@ -15,7 +20,7 @@ func TestPrintJSONResults_WithVulnerabilities(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		err := output.PrintJSONResults(args.vulnResult, outputWriter)
+		err := output.PrintJSONResults(args.vulnResult, outputWriter, output.JSONOptions{})
 
 		if err != nil {
 			t.Errorf("Error writing JSON output: %s", err)
@@ -25,6 +30,27 @@ func TestPrintJSONResults_WithVulnerabilities(t *testing.T) {
 	})
 }
 
+func TestPrintJSONResults_WithMetadata(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	err := output.PrintJSONResults(&models.VulnerabilityResults{}, outputWriter, output.JSONOptions{
+		Metadata: &models.ScanMetadata{
+			Version:   "1.2.3",
+			Commit:    "abc123",
+			BuildDate: "2024-01-02T03:04:05Z",
+			ScannedAt: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+			Args:      []string{"scan", "--format", "json", "-r", "."},
+		},
+	})
+
+	if err != nil {
+		t.Errorf("Error writing JSON output: %s", err)
+	}
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
 func TestPrintJSONResults_WithLicenseViolations(t *testing.T) {
 	t.Parallel()
 
@@ -32,7 +58,7 @@ func TestPrintJSONResults_WithLicenseViolations(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		err := output.PrintJSONResults(args.vulnResult, outputWriter)
+		err := output.PrintJSONResults(args.vulnResult, outputWriter, output.JSONOptions{})
 
 		if err != nil {
 			t.Errorf("Error writing JSON output: %s", err)
@@ -49,7 +75,83 @@ func TestPrintJSONResults_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		err := output.PrintJSONResults(args.vulnResult, outputWriter)
+		err := output.PrintJSONResults(args.vulnResult, outputWriter, output.JSONOptions{})
+
+		if err != nil {
+			t.Errorf("Error writing JSON output: %s", err)
+		}
+
+		testutility.NewSnapshot().MatchText(t, outputWriter.String())
+	})
+}
+
+func TestPrintJSONResults_WithLicenseSummary(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithLicenseViolations(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		vulnResult := *args.vulnResult
+		vulnResult.ExperimentalAnalysisConfig = models.ExperimentalAnalysisConfig{
+			Licenses: models.ExperimentalLicenseConfig{Summary: true},
+		}
+
+		outputWriter := &bytes.Buffer{}
+		err := output.PrintJSONResults(&vulnResult, outputWriter, output.JSONOptions{})
+
+		if err != nil {
+			t.Errorf("Error writing JSON output: %s", err)
+		}
+
+		testutility.NewSnapshot().MatchText(t, outputWriter.String())
+	})
+}
+
+func TestPrintJSONResults_Compact(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithMixedIssues(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		pretty := &bytes.Buffer{}
+		if err := output.PrintJSONResults(args.vulnResult, pretty, output.JSONOptions{}); err != nil {
+			t.Fatalf("Error writing JSON output: %s", err)
+		}
+
+		compact := &bytes.Buffer{}
+		if err := output.PrintJSONResults(args.vulnResult, compact, output.JSONOptions{Compact: true}); err != nil {
+			t.Fatalf("Error writing JSON output: %s", err)
+		}
+
+		if lines := strings.Count(compact.String(), "\n"); lines != 1 {
+			t.Errorf("Expected compact output to be a single line, but got %d lines", lines)
+		}
+
+		want := &bytes.Buffer{}
+		if err := json.Compact(want, pretty.Bytes()); err != nil {
+			t.Fatalf("Pretty output is not valid JSON: %s", err)
+		}
+		want.WriteString("\n")
+
+		if diff := cmp.Diff(want.String(), compact.String()); diff != "" {
+			t.Errorf("Compact output does not match the pretty output (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestPrintJSONStreamSummary_WithLicenseSummary(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithLicenseViolations(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		vulnResult := *args.vulnResult
+		vulnResult.ExperimentalAnalysisConfig = models.ExperimentalAnalysisConfig{
+			Licenses: models.ExperimentalLicenseConfig{Summary: true},
+		}
+
+		outputWriter := &bytes.Buffer{}
+		err := output.PrintJSONStreamSummary(&vulnResult, outputWriter, output.JSONOptions{})
 
 		if err != nil {
 			t.Errorf("Error writing JSON output: %s", err)
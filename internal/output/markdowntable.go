@@ -9,19 +9,33 @@ import (
 )
 
 // PrintTableResults prints the osv scan results into a human friendly table.
-func PrintMarkdownTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer) {
+func PrintMarkdownTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, options TableOptions) {
 	outputTable := table.NewWriter()
 	outputTable.SetOutputMirror(outputWriter)
-	outputTable = tableBuilder(outputTable, vulnResult, false)
+	options.markdown = true
+	outputTable = tableBuilder(outputTable, vulnResult, false, options)
 
 	if outputTable.Length() != 0 {
 		outputTable.RenderMarkdown()
 	}
 
+	outputRemediationTable := table.NewWriter()
+	outputRemediationTable.SetOutputMirror(outputWriter)
+	outputRemediationTable = remediationTableBuilder(outputRemediationTable, vulnResult, options)
+
+	if outputRemediationTable.Length() != 0 {
+		outputRemediationTable.RenderMarkdown()
+	}
+
+	PrintMarkdownLicenseTableResults(vulnResult, outputWriter, options)
+}
+
+// PrintMarkdownLicenseTableResults prints only the license information of the osv scan results into a markdown table.
+func PrintMarkdownLicenseTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, options TableOptions) {
 	outputLicenseTable := table.NewWriter()
 	outputLicenseTable.SetOutputMirror(outputWriter)
 
-	outputLicenseTable = licenseTableBuilder(outputLicenseTable, vulnResult)
+	outputLicenseTable = licenseTableBuilder(outputLicenseTable, vulnResult, options)
 
 	if outputLicenseTable.Length() == 0 {
 		return
@@ -15,7 +15,7 @@ func TestPrintMarkdownTableResults_WithVulnerabilities(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintMarkdownTableResults(args.vulnResult, outputWriter)
+		output.PrintMarkdownTableResults(args.vulnResult, outputWriter, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
@@ -28,7 +28,7 @@ func TestPrintMarkdownTableResults_WithLicenseViolations(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintMarkdownTableResults(args.vulnResult, outputWriter)
+		output.PrintMarkdownTableResults(args.vulnResult, outputWriter, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
@@ -41,7 +41,20 @@ func TestPrintMarkdownTableResults_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintMarkdownTableResults(args.vulnResult, outputWriter)
+		output.PrintMarkdownTableResults(args.vulnResult, outputWriter, output.TableOptions{})
+
+		testutility.NewSnapshot().MatchText(t, outputWriter.String())
+	})
+}
+
+func TestPrintMarkdownTableResults_ShowAliases_WithVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithVulnerabilities(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		output.PrintMarkdownTableResults(args.vulnResult, outputWriter, output.TableOptions{ShowAliases: true})
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
@@ -0,0 +1,128 @@
+package output
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/google/osv-scanner/internal/semantic"
+	"github.com/google/osv-scanner/internal/utility/vulns"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	// noUpgradePath is displayed when no version of the package fixes all of its vulnerabilities
+	noUpgradePath = "No single version fixes all vulnerabilities"
+	// unknownUpgradePath is displayed when the versions of the package's ecosystem cannot be compared
+	unknownUpgradePath = "Unknown (versions cannot be compared)"
+)
+
+// UpgradePath returns the lowest version of the package that is greater than the installed version
+// and is not affected by any of its vulnerabilities, based on the versions the vulnerabilities are fixed in.
+//
+// ok is false if no such version is known, or if the versions of the package's ecosystem cannot be compared.
+func UpgradePath(pkg models.PackageVulns) (version string, ok bool) {
+	ecosystem := pkg.Package.BaseEcosystem()
+	installed, err := semantic.Parse(pkg.Package.Version, ecosystem)
+	if err != nil {
+		return "", false
+	}
+
+	pkgKey := models.Package{
+		Ecosystem: models.Ecosystem(pkg.Package.Ecosystem),
+		Name:      pkg.Package.Name,
+	}
+
+	var candidates []string
+	for _, vuln := range pkg.Vulnerabilities {
+		for _, fixed := range vuln.FixedVersions()[pkgKey] {
+			if installed.CompareStr(fixed) < 0 {
+				candidates = append(candidates, fixed)
+			}
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b string) int {
+		return semantic.MustParse(a, ecosystem).CompareStr(b)
+	})
+
+	for _, candidate := range candidates {
+		details := lockfile.PackageDetails{
+			Name:      pkg.Package.Name,
+			Version:   candidate,
+			Ecosystem: lockfile.Ecosystem(pkg.Package.Ecosystem),
+			CompareAs: lockfile.Ecosystem(ecosystem),
+		}
+
+		fixesAll := true
+		for _, vuln := range pkg.Vulnerabilities {
+			if vulns.IsAffected(vuln, details) {
+				fixesAll = false
+				break
+			}
+		}
+
+		if fixesAll {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// upgradePathText returns the version to upgrade the package to for display,
+// or a message explaining why there is no such version
+func upgradePathText(pkg models.PackageVulns) string {
+	ecosystem := pkg.Package.BaseEcosystem()
+	if _, err := semantic.Parse(pkg.Package.Version, ecosystem); err != nil {
+		return unknownUpgradePath
+	}
+
+	if version, ok := UpgradePath(pkg); ok {
+		return version
+	}
+
+	return noUpgradePath
+}
+
+// remediationTableBuilder lists the version each vulnerable package should be upgraded to
+func remediationTableBuilder(outputTable table.Writer, vulnResult *models.VulnerabilityResults, options TableOptions) table.Writer {
+	// Packages in container images cannot be upgraded independently of the image
+	if onlyContainerSources(vulnResult) {
+		return outputTable
+	}
+
+	basePath := sourcePathBase(options.BasePath)
+
+	for _, sourceRes := range vulnResult.Results {
+		for _, pkg := range sourceRes.Packages {
+			// Packages referenced by commit have no versions to upgrade to
+			if len(pkg.Vulnerabilities) == 0 || pkg.Package.Ecosystem == "" {
+				continue
+			}
+
+			sourcePath := sourceRes.Source.Path
+			if relPath, err := filepath.Rel(basePath, sourcePath); err == nil {
+				sourcePath = relPath
+			}
+
+			outputTable.AppendRow(table.Row{
+				pkg.Package.Ecosystem,
+				pkg.Package.Name,
+				pkg.Package.Version,
+				upgradePathText(pkg),
+				sourcePath,
+			})
+		}
+	}
+
+	if outputTable.Length() == 0 {
+		return outputTable
+	}
+
+	outputTable.AppendHeader(table.Row{"Ecosystem", "Package", "Installed Version", "Upgrade To", "Source"})
+
+	return outputTable
+}
@@ -0,0 +1,89 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func Test_upgradePathText(t *testing.T) {
+	t.Parallel()
+
+	affected := func(name string, events ...models.Event) models.Affected {
+		return models.Affected{
+			Package: models.Package{Ecosystem: "npm", Name: name},
+			Ranges:  []models.Range{{Type: models.RangeSemVer, Events: events}},
+		}
+	}
+
+	tests := []struct {
+		name string
+		pkg  models.PackageVulns
+		want string
+	}{
+		{
+			name: "single vulnerability",
+			pkg: models.PackageVulns{
+				Package: models.PackageInfo{Ecosystem: "npm", Name: "mine1", Version: "1.2.3"},
+				Vulnerabilities: []models.Vulnerability{
+					{ID: "OSV-1", Affected: []models.Affected{affected("mine1", models.Event{Introduced: "0"}, models.Event{Fixed: "1.3.0"})}},
+				},
+			},
+			want: "1.3.0",
+		},
+		{
+			name: "lowest version that fixes every vulnerability",
+			pkg: models.PackageVulns{
+				Package: models.PackageInfo{Ecosystem: "npm", Name: "mine1", Version: "1.2.3"},
+				Vulnerabilities: []models.Vulnerability{
+					{ID: "OSV-1", Affected: []models.Affected{affected("mine1", models.Event{Introduced: "0"}, models.Event{Fixed: "1.3.0"})}},
+					{ID: "OSV-2", Affected: []models.Affected{affected("mine1", models.Event{Introduced: "1.0.0"}, models.Event{Fixed: "1.5.0"})}},
+					{ID: "OSV-3", Affected: []models.Affected{affected("mine1", models.Event{Introduced: "0"}, models.Event{Fixed: "1.0.0"})}},
+				},
+			},
+			want: "1.5.0",
+		},
+		{
+			name: "fixed version that is affected by another vulnerability",
+			pkg: models.PackageVulns{
+				Package: models.PackageInfo{Ecosystem: "npm", Name: "mine1", Version: "1.2.3"},
+				Vulnerabilities: []models.Vulnerability{
+					{ID: "OSV-1", Affected: []models.Affected{affected("mine1", models.Event{Introduced: "0"}, models.Event{Fixed: "2.0.0"})}},
+					{ID: "OSV-2", Affected: []models.Affected{affected("mine1", models.Event{Introduced: "2.0.0"}, models.Event{Fixed: "2.1.0"})}},
+				},
+			},
+			want: "2.1.0",
+		},
+		{
+			name: "vulnerability without a fix",
+			pkg: models.PackageVulns{
+				Package: models.PackageInfo{Ecosystem: "npm", Name: "mine1", Version: "1.2.3"},
+				Vulnerabilities: []models.Vulnerability{
+					{ID: "OSV-1", Affected: []models.Affected{affected("mine1", models.Event{Introduced: "0"}, models.Event{Fixed: "1.3.0"})}},
+					{ID: "OSV-2", Affected: []models.Affected{affected("mine1", models.Event{Introduced: "0"})}},
+				},
+			},
+			want: noUpgradePath,
+		},
+		{
+			name: "ecosystem without version comparison support",
+			pkg: models.PackageVulns{
+				Package: models.PackageInfo{Ecosystem: "Unknown", Name: "mine1", Version: "1"},
+				Vulnerabilities: []models.Vulnerability{
+					{ID: "OSV-1", Affected: []models.Affected{affected("mine1", models.Event{Introduced: "0"}, models.Event{Fixed: "2"})}},
+				},
+			},
+			want: unknownUpgradePath,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := upgradePathText(tt.pkg)
+			if got != tt.want {
+				t.Errorf("upgradePathText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
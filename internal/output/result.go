@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
@@ -75,6 +76,20 @@ func mustGetWorkingDirectory() string {
 	return dir
 }
 
+// sourcePathBase returns the absolute path that source paths should be shown relative to,
+// which is the working directory unless a base path is given
+func sourcePathBase(basePath string) string {
+	if basePath == "" {
+		return mustGetWorkingDirectory()
+	}
+
+	if abs, err := filepath.Abs(basePath); err == nil {
+		return abs
+	}
+
+	return basePath
+}
+
 // groupFixedVersions builds the fixed versions for each ID Group, with keys formatted like so:
 // `Source:ID`
 func groupFixedVersions(flattened []models.VulnerabilityFlattened) map[string][]string {
@@ -112,6 +127,10 @@ type groupedSARIFFinding struct {
 	// AliasedIDList contains all aliased IDs, including ones that are not OSV (e.g. CVE IDs)
 	// Sorted by idSortFunc, therefore the first element will be the display ID
 	AliasedIDList []string
+	// Filtered are the groups of the packages for which the vulnerability was filtered out
+	Filtered map[pkgWithSource]models.GroupInfo `json:"-"`
+	// MaxSeverity is the highest max severity of the groups of the vulnerability, if any of them have one
+	MaxSeverity string
 }
 
 // mapIDsToGroupedSARIFFinding creates a map over all vulnerability IDs, with aliased vuln IDs
@@ -137,8 +156,12 @@ func mapIDsToGroupedSARIFFinding(vulns *models.VulnerabilityResults) map[string]
 					data = &groupedSARIFFinding{
 						PkgSource:    make(pkgSourceSet),
 						AliasedVulns: make(map[string]models.Vulnerability),
+						Filtered:     make(map[pkgWithSource]models.GroupInfo),
 					}
 				}
+				if severityScore(gi.MaxSeverity) > severityScore(data.MaxSeverity) {
+					data.MaxSeverity = gi.MaxSeverity
+				}
 				// Point all the IDs of the same group to the same data, either newly created or existing
 				for _, id := range gi.IDs {
 					results[id] = data
@@ -151,6 +174,11 @@ func mapIDsToGroupedSARIFFinding(vulns *models.VulnerabilityResults) map[string]
 				}
 				entry := results[v.ID]
 				entry.PkgSource[newPkgSource] = struct{}{}
+				for _, gi := range pkg.Groups {
+					if gi.Filtered && slices.Contains(gi.IDs, v.ID) {
+						entry.Filtered[newPkgSource] = gi
+					}
+				}
 				entry.AliasedVulns[v.ID] = v
 				entry.AliasedIDList = append(entry.AliasedIDList, v.ID)
 				entry.AliasedIDList = append(entry.AliasedIDList, v.Aliases...)
@@ -1,11 +1,14 @@
 package output
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -163,8 +166,29 @@ func stripGitHubWorkspace(path string) string {
 	return strings.TrimPrefix(path, "/github/workspace/")
 }
 
+// SARIFOptions controls optional parts of the SARIF output
+type SARIFOptions struct {
+	// BasePath is the path that artifact locations are made relative to,
+	// rather than only stripping the GitHub workspace directory
+	BasePath string
+	// Metadata describes the build of osv-scanner and how it was run, which is
+	// recorded on the driver and as the invocation of the run if set
+	Metadata *models.ScanMetadata
+}
+
+// sarifSourcePath returns the path of a source as it should be shown in the SARIF output
+func sarifSourcePath(path string, options SARIFOptions) string {
+	if options.BasePath != "" {
+		if rel, err := filepath.Rel(sourcePathBase(options.BasePath), path); err == nil {
+			return filepath.ToSlash(rel)
+		}
+	}
+
+	return stripGitHubWorkspace(path)
+}
+
 // createSARIFHelpText returns the text for SARIF rule's help field
-func createSARIFHelpText(gv *groupedSARIFFinding) string {
+func createSARIFHelpText(gv *groupedSARIFFinding, options SARIFOptions) string {
 	backtickSARIFTemplate := strings.ReplaceAll(strings.TrimSpace(SARIFTemplate), `""`, "`")
 	helpTextTemplate, err := template.New("helpText").Parse(backtickSARIFTemplate)
 	if err != nil {
@@ -199,7 +223,7 @@ func createSARIFHelpText(gv *groupedSARIFFinding) string {
 
 	affectedPackagePaths := []string{}
 	for _, pws := range pkgWithSrcKeys {
-		affectedPackagePaths = append(affectedPackagePaths, stripGitHubWorkspace(filepath.Dir(pws.Source.Path)))
+		affectedPackagePaths = append(affectedPackagePaths, sarifSourcePath(filepath.Dir(pws.Source.Path), options))
 	}
 	// Compact to remove duplicates
 	// (which should already be next to each other since it's sorted in the previous step)
@@ -221,8 +245,60 @@ func createSARIFHelpText(gv *groupedSARIFFinding) string {
 	return helpText.String()
 }
 
+// sarifFingerprintKey is the key the fingerprint of each result is stored under
+// in its partialFingerprints, which is versioned in case how it is computed changes
+const sarifFingerprintKey = "osvScannerPackageVulnerability/v1"
+
+// sarifFingerprint returns a stable fingerprint for the result of a package in a source being
+// vulnerable to a vulnerability, so that alerts can be tracked across runs regardless of the
+// order the results are in
+func sarifFingerprint(artifactPath string, pkg models.PackageInfo, vulnID string) string {
+	name := pkg.Name
+	if name == "" {
+		name = pkg.Commit
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join([]string{artifactPath, pkg.Ecosystem, name, vulnID}, "\x00")))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// sarifRuleProperties tags the rule of the vulnerability as being security related, along with its
+// "security-severity" if it has a max severity, which GitHub uses to rank the alerts as low, medium, high or critical
+func sarifRuleProperties(gv *groupedSARIFFinding) *sarif.PropertyBag {
+	properties := sarif.NewPropertyBag()
+	properties.Add("tags", []string{"security"})
+
+	if score := severityScore(gv.MaxSeverity); score >= 0 {
+		properties.AddString("security-severity", strconv.FormatFloat(score, 'f', 1, 64))
+	}
+
+	return properties
+}
+
 // PrintSARIFReport prints SARIF output to outputWriter
-func PrintSARIFReport(vulnResult *models.VulnerabilityResults, outputWriter io.Writer) error {
+// sarifSuppression describes why the vulnerabilities of the filtered group were ignored,
+// which is either in the source (with an inline comment) or externally (in a config file)
+func sarifSuppression(group models.GroupInfo) *sarif.Suppression {
+	kind := "external"
+	if group.FilterSource == models.InlineIgnoreFilterSource {
+		kind = "inSource"
+	}
+
+	suppression := sarif.NewSuppression(kind).WithStatus("accepted")
+	if group.FilterReason != "" {
+		suppression.WithJustifcation(group.FilterReason)
+	}
+	if group.FilterSource != "" {
+		properties := sarif.NewPropertyBag()
+		properties.AddString("filterSource", group.FilterSource)
+		suppression.AttachPropertyBag(properties)
+	}
+
+	return suppression
+}
+
+func PrintSARIFReport(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, options SARIFOptions) error {
 	report, err := sarif.New(sarif.Version210)
 	if err != nil {
 		return err
@@ -231,6 +307,17 @@ func PrintSARIFReport(vulnResult *models.VulnerabilityResults, outputWriter io.W
 	run := sarif.NewRunWithInformationURI("osv-scanner", "https://github.com/google/osv-scanner")
 	run.Tool.Driver.WithVersion(version.OSVVersion)
 
+	if options.Metadata != nil {
+		properties := sarif.NewPropertyBag()
+		properties.AddString("commit", options.Metadata.Commit)
+		properties.AddString("buildDate", options.Metadata.BuildDate)
+		run.Tool.Driver.AttachPropertyBag(properties)
+
+		run.AddInvocation(true).
+			WithArguments(options.Metadata.Args).
+			WithStartTimeUTC(options.Metadata.ScannedAt)
+	}
+
 	vulnIDMap := mapIDsToGroupedSARIFFinding(vulnResult)
 	// Sort the IDs to have deterministic loop of vulnIDMap
 	vulnIDs := []string{}
@@ -242,7 +329,7 @@ func PrintSARIFReport(vulnResult *models.VulnerabilityResults, outputWriter io.W
 	for _, vulnID := range vulnIDs {
 		gv := vulnIDMap[vulnID]
 
-		helpText := createSARIFHelpText(gv)
+		helpText := createSARIFHelpText(gv, options)
 
 		// Pick the "best" description from the alias group based on the source.
 		// Set short description to the first entry with a non empty summary
@@ -275,9 +362,10 @@ func PrintSARIFReport(vulnResult *models.VulnerabilityResults, outputWriter io.W
 			WithTextHelp(helpText)
 
 		rule.DeprecatedIds = gv.AliasedIDList
+		rule.AttachPropertyBag(sarifRuleProperties(gv))
 
 		for _, pws := range gv.PkgSource.StableKeys() {
-			artifactPath := stripGitHubWorkspace(pws.Source.Path)
+			artifactPath := sarifSourcePath(pws.Source.Path, options)
 			if filepath.IsAbs(artifactPath) {
 				// this only errors if the file path is not absolute,
 				// which we've already confirmed is not the case
@@ -293,7 +381,7 @@ func PrintSARIFReport(vulnResult *models.VulnerabilityResults, outputWriter io.W
 				alsoKnownAsStr = fmt.Sprintf(" (also known as '%s')", strings.Join(gv.AliasedIDList[1:], "', '"))
 			}
 
-			run.CreateResultForRule(gv.DisplayID).
+			result := run.CreateResultForRule(gv.DisplayID).
 				WithLevel("warning").
 				WithMessage(
 					sarif.NewTextMessage(
@@ -303,11 +391,18 @@ func PrintSARIFReport(vulnResult *models.VulnerabilityResults, outputWriter io.W
 							gv.DisplayID,
 							alsoKnownAsStr,
 						))).
-				AddLocation(
-					sarif.NewLocationWithPhysicalLocation(
-						sarif.NewPhysicalLocation().
-							WithArtifactLocation(sarif.NewSimpleArtifactLocation(artifactPath)),
-					))
+				WithPartialFingerPrints(map[string]interface{}{
+					sarifFingerprintKey: sarifFingerprint(artifactPath, pws.Package, gv.DisplayID),
+				})
+			result.AddLocation(
+				sarif.NewLocationWithPhysicalLocation(
+					sarif.NewPhysicalLocation().
+						WithArtifactLocation(sarif.NewSimpleArtifactLocation(artifactPath)),
+				))
+
+			if group, ok := gv.Filtered[pws]; ok {
+				result.AddSuppression(sarifSuppression(group))
+			}
 		}
 	}
 
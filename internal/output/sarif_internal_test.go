@@ -1,9 +1,12 @@
 package output
 
 import (
+	"encoding/json"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/osv-scanner/internal/testutility"
+	"github.com/google/osv-scanner/pkg/models"
 )
 
 func Test_createSARIFHelpText(t *testing.T) {
@@ -31,8 +34,95 @@ func Test_createSARIFHelpText(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := createSARIFHelpText(&tt.args)
+			got := createSARIFHelpText(&tt.args, SARIFOptions{})
 			tt.want.MatchText(t, got)
 		})
 	}
 }
+
+func Test_sarifSourcePath(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(string(filepath.Separator), "home", "user")
+
+	tests := []struct {
+		name    string
+		path    string
+		options SARIFOptions
+		want    string
+	}{
+		{
+			name:    "no base path",
+			path:    "/github/workspace/path/to/go.mod",
+			options: SARIFOptions{},
+			want:    "path/to/go.mod",
+		},
+		{
+			name:    "relative to the base path",
+			path:    filepath.Join(root, "project", "path", "to", "go.mod"),
+			options: SARIFOptions{BasePath: filepath.Join(root, "project")},
+			want:    "path/to/go.mod",
+		},
+		{
+			name:    "outside of the base path",
+			path:    filepath.Join(root, "other", "go.mod"),
+			options: SARIFOptions{BasePath: filepath.Join(root, "project")},
+			want:    "../other/go.mod",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := sarifSourcePath(tt.path, tt.options)
+			if got != tt.want {
+				t.Errorf("sarifSourcePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sarifSuppression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		group models.GroupInfo
+		want  string
+	}{
+		{
+			name: "ignored by a config file",
+			group: models.GroupInfo{
+				IDs:          []string{"GHSA-1"},
+				Filtered:     true,
+				FilterReason: "not exploitable",
+				FilterSource: "/path/to/osv-scanner.toml",
+			},
+			want: `{"kind":"external","status":"accepted","location":null,"guid":null,"justification":"not exploitable","properties":{"filterSource":"/path/to/osv-scanner.toml"}}`,
+		},
+		{
+			name: "ignored by an inline comment without a reason",
+			group: models.GroupInfo{
+				IDs:          []string{"GHSA-1"},
+				Filtered:     true,
+				FilterSource: models.InlineIgnoreFilterSource,
+			},
+			want: `{"kind":"inSource","status":"accepted","location":null,"guid":null,"justification":null,"properties":{"filterSource":"inline comment"}}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(sarifSuppression(tt.group))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("sarifSuppression() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,12 @@ package output_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/osv-scanner/internal/output"
 	"github.com/google/osv-scanner/internal/testutility"
@@ -78,7 +83,7 @@ func TestPrintSARIFReport(t *testing.T) {
 			t.Parallel()
 
 			bufOut := bytes.Buffer{}
-			err := output.PrintSARIFReport(&tt.args, &bufOut)
+			err := output.PrintSARIFReport(&tt.args, &bufOut, output.SARIFOptions{})
 			if err != nil {
 				t.Errorf("Error writing SARIF output: %s", err)
 			}
@@ -94,7 +99,7 @@ func TestPrintSARIFReport_WithVulnerabilities(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		err := output.PrintSARIFReport(args.vulnResult, outputWriter)
+		err := output.PrintSARIFReport(args.vulnResult, outputWriter, output.SARIFOptions{})
 
 		if err != nil {
 			t.Errorf("Error writing SARIF output: %s", err)
@@ -116,7 +121,7 @@ func TestPrintSARIFReport_WithLicenseViolations(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		err := output.PrintSARIFReport(args.vulnResult, outputWriter)
+		err := output.PrintSARIFReport(args.vulnResult, outputWriter, output.SARIFOptions{})
 
 		if err != nil {
 			t.Errorf("Error writing SARIF output: %s", err)
@@ -138,7 +143,7 @@ func TestPrintSARIFReport_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		err := output.PrintSARIFReport(args.vulnResult, outputWriter)
+		err := output.PrintSARIFReport(args.vulnResult, outputWriter, output.SARIFOptions{})
 
 		if err != nil {
 			t.Errorf("Error writing SARIF output: %s", err)
@@ -152,3 +157,179 @@ func TestPrintSARIFReport_WithMixedIssues(t *testing.T) {
 			}).MatchText(t, outputWriter.String())
 	})
 }
+
+func TestPrintSARIFReport_WithMetadata(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	err := output.PrintSARIFReport(&models.VulnerabilityResults{}, outputWriter, output.SARIFOptions{
+		Metadata: &models.ScanMetadata{
+			Version:   "1.2.3",
+			Commit:    "abc123",
+			BuildDate: "2024-01-02T03:04:05Z",
+			ScannedAt: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+			Args:      []string{"scan", "--format", "sarif", "-r", "."},
+		},
+	})
+
+	if err != nil {
+		t.Errorf("Error writing SARIF output: %s", err)
+	}
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
+// sarifFingerprints returns the partial fingerprints of each result in the SARIF report,
+// keyed by the rule and message of the result
+func sarifFingerprints(t *testing.T, vulnResult *models.VulnerabilityResults) map[string]map[string]string {
+	t.Helper()
+
+	outputWriter := &bytes.Buffer{}
+	if err := output.PrintSARIFReport(vulnResult, outputWriter, output.SARIFOptions{}); err != nil {
+		t.Fatalf("Error writing SARIF output: %s", err)
+	}
+
+	var report struct {
+		Runs []struct {
+			Results []struct {
+				RuleID  string `json:"ruleId"`
+				Message struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				PartialFingerprints map[string]string `json:"partialFingerprints"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+
+	if err := json.Unmarshal(outputWriter.Bytes(), &report); err != nil {
+		t.Fatalf("Error parsing SARIF output: %s", err)
+	}
+
+	fingerprints := map[string]map[string]string{}
+	for _, run := range report.Runs {
+		for _, result := range run.Results {
+			if len(result.PartialFingerprints) == 0 {
+				t.Errorf("result for %s has no partial fingerprints", result.RuleID)
+			}
+
+			fingerprints[result.RuleID+": "+result.Message.Text] = result.PartialFingerprints
+		}
+	}
+
+	return fingerprints
+}
+
+func TestPrintSARIFReport_StableFingerprints(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/test-vuln-results-a.json")
+	want := sarifFingerprints(t, &vulnResult)
+
+	if len(want) == 0 {
+		t.Fatalf("expected the fixture to have results")
+	}
+
+	// reverse the order of the sources, packages, and vulnerabilities
+	reversed := testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/test-vuln-results-a.json")
+	slices.Reverse(reversed.Results)
+	for i := range reversed.Results {
+		slices.Reverse(reversed.Results[i].Packages)
+		for j := range reversed.Results[i].Packages {
+			slices.Reverse(reversed.Results[i].Packages[j].Vulnerabilities)
+			slices.Reverse(reversed.Results[i].Packages[j].Groups)
+		}
+	}
+
+	got := sarifFingerprints(t, &reversed)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fingerprints changed with the order of the results:\n got: %v\nwant: %v", got, want)
+	}
+
+	seen := map[string]string{}
+	for result, fingerprints := range want {
+		for _, fingerprint := range fingerprints {
+			if other, ok := seen[fingerprint]; ok {
+				t.Errorf("results %q and %q have the same fingerprint", result, other)
+			}
+			seen[fingerprint] = result
+		}
+	}
+}
+
+func TestPrintSARIFReport_SecuritySeverity(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{
+							{ID: "GHSA-1"},
+							{ID: "GHSA-2"},
+						},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"GHSA-1"}, MaxSeverity: "9.8"},
+							{IDs: []string{"GHSA-2"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	outputWriter := &bytes.Buffer{}
+	if err := output.PrintSARIFReport(vulnResult, outputWriter, output.SARIFOptions{}); err != nil {
+		t.Fatalf("Error writing SARIF output: %s", err)
+	}
+
+	var report struct {
+		Runs []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID         string         `json:"id"`
+						Properties map[string]any `json:"properties"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+		} `json:"runs"`
+	}
+
+	if err := json.Unmarshal(outputWriter.Bytes(), &report); err != nil {
+		t.Fatalf("Error parsing SARIF output: %s", err)
+	}
+
+	if len(report.Runs) != 1 || len(report.Runs[0].Tool.Driver.Rules) != 2 {
+		t.Fatalf("expected one run with two rules, got %s", outputWriter.String())
+	}
+
+	for _, rule := range report.Runs[0].Tool.Driver.Rules {
+		if tags, ok := rule.Properties["tags"].([]any); !ok || !slices.Contains(tags, any("security")) {
+			t.Errorf("rule %s has tags %v, want them to include \"security\"", rule.ID, rule.Properties["tags"])
+		}
+
+		severity, hasSeverity := rule.Properties["security-severity"]
+
+		switch rule.ID {
+		case "GHSA-1":
+			str, ok := severity.(string)
+			if !ok {
+				t.Fatalf("rule %s has security-severity %#v, want a string", rule.ID, severity)
+			}
+
+			if score, err := strconv.ParseFloat(str, 64); err != nil || score != 9.8 {
+				t.Errorf("rule %s has security-severity %q, want a numeric value of 9.8", rule.ID, str)
+			}
+		case "GHSA-2":
+			if hasSeverity {
+				t.Errorf("rule %s has security-severity %#v, but has no max severity", rule.ID, severity)
+			}
+		default:
+			t.Errorf("unexpected rule %s", rule.ID)
+		}
+	}
+}
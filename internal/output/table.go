@@ -5,11 +5,14 @@ import (
 	"io"
 	"math"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/exp/maps"
 
+	"github.com/google/osv-scanner/internal/semantic"
 	"github.com/google/osv-scanner/internal/utility/results"
 	"github.com/google/osv-scanner/internal/utility/severity"
 	"github.com/google/osv-scanner/pkg/lockfile"
@@ -23,18 +26,59 @@ import (
 // Copied in from osv package to avoid referencing the osv package unnecessarily
 const OSVBaseVulnerabilityURL = "https://osv.dev/"
 
+// TableOptions controls the optional columns and sections of the table and markdown outputs.
+type TableOptions struct {
+	// ShowAliases adds a column listing the aliases (such as CVE IDs) of each vulnerability group
+	ShowAliases bool
+	// BasePath is the path that source paths are shown relative to, defaulting to the working directory
+	BasePath string
+	// ShowDependencyRelationship adds a column indicating if each package is a direct or transitive dependency
+	ShowDependencyRelationship bool
+	// ShowAffectedRanges adds a column listing the version ranges of each package that are affected by the vulnerabilities
+	ShowAffectedRanges bool
+	// ShowSummary adds a column with the summary of each vulnerability group, which is
+	// instead shown on a separate line below the IDs in the markdown output
+	ShowSummary bool
+	// ShowRelated adds a note below the IDs of each vulnerability group listing the IDs of related vulnerabilities
+	ShowRelated bool
+	// ShowCallPaths adds a note below the IDs of each called vulnerability group with the call path
+	// to the vulnerable symbol, if call analysis recorded one
+	ShowCallPaths bool
+	// MaxVulns limits the number of vulnerability rows that are rendered, with 0 meaning no limit
+	MaxVulns int
+
+	// summaryWidth is the length that summaries are truncated to, with 0 meaning they are not truncated
+	summaryWidth int
+	// markdown is true when the table is rendered as markdown
+	markdown bool
+}
+
 // PrintTableResults prints the osv scan results into a human friendly table.
-func PrintTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, terminalWidth int) {
+func PrintTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, terminalWidth int, options TableOptions) {
 	// Render the vulnerabilities.
 	outputTable := newTable(outputWriter, terminalWidth)
-	outputTable = tableBuilder(outputTable, vulnResult, terminalWidth > 0)
+	// summaries are much longer than the other columns, so leave room for them
+	options.summaryWidth = terminalWidth / 3
+	outputTable = tableBuilder(outputTable, vulnResult, terminalWidth > 0, options)
 	if outputTable.Length() != 0 {
 		outputTable.Render()
 	}
 
+	// Render the upgrades that fix the vulnerabilities, if any.
+	outputRemediationTable := newTable(outputWriter, terminalWidth)
+	outputRemediationTable = remediationTableBuilder(outputRemediationTable, vulnResult, options)
+	if outputRemediationTable.Length() != 0 {
+		outputRemediationTable.Render()
+	}
+
 	// Render the licenses if any.
+	PrintLicenseTableResults(vulnResult, outputWriter, terminalWidth, options)
+}
+
+// PrintLicenseTableResults prints only the license information of the osv scan results into a human friendly table.
+func PrintLicenseTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, terminalWidth int, options TableOptions) {
 	outputLicenseTable := newTable(outputWriter, terminalWidth)
-	outputLicenseTable = licenseTableBuilder(outputLicenseTable, vulnResult)
+	outputLicenseTable = licenseTableBuilder(outputLicenseTable, vulnResult, options)
 	if outputLicenseTable.Length() == 0 {
 		return
 	}
@@ -56,48 +100,107 @@ func newTable(outputWriter io.Writer, terminalWidth int) table.Writer {
 	return outputTable
 }
 
-func tableBuilder(outputTable table.Writer, vulnResult *models.VulnerabilityResults, addStyling bool) table.Writer {
-	outputTable.AppendHeader(table.Row{"OSV URL", "CVSS", "Ecosystem", "Package", "Version", "Source"})
-	rows := tableBuilderInner(vulnResult, addStyling, true)
+func tableBuilder(outputTable table.Writer, vulnResult *models.VulnerabilityResults, addStyling bool, options TableOptions) table.Writer {
+	header := table.Row{"OSV URL"}
+	if options.ShowAliases {
+		header = append(header, "Aliases")
+	}
+	if options.ShowSummary && !options.markdown {
+		header = append(header, "Summary")
+	}
+	header = append(header, "CVSS", "Ecosystem", "Package", "Version")
+	if options.ShowDependencyRelationship {
+		header = append(header, "Relationship")
+	}
+	if options.ShowAffectedRanges {
+		header = append(header, "Affected Ranges")
+	}
+	// Fixed versions are not useful when scanning container images, as the
+	// packages cannot be upgraded independently of the image
+	showFixedVersion := !onlyContainerSources(vulnResult)
+	if showFixedVersion {
+		header = append(header, "Fixed Version")
+	}
+	showImageLayer := hasImageLayers(vulnResult)
+	if showImageLayer {
+		header = append(header, "Layer")
+	}
+	header = append(header, "Source")
+	outputTable.AppendHeader(header)
+	rows := tableBuilderInner(vulnResult, addStyling, true, options, showFixedVersion, showImageLayer)
+	uncalledRows := tableBuilderInner(vulnResult, addStyling, false, options, showFixedVersion, showImageLayer)
+
+	// Called vulnerabilities are more important, so they are kept over uncalled ones
+	rows, uncalledRows, truncated := truncateRows(rows, uncalledRows, options.MaxVulns)
+
 	for _, elem := range rows {
 		outputTable.AppendRow(elem.row, table.RowConfig{AutoMerge: elem.shouldMerge})
 	}
 
-	uncalledRows := tableBuilderInner(vulnResult, addStyling, false)
-	if len(uncalledRows) == 0 {
-		return outputTable
-	}
+	if len(uncalledRows) != 0 {
+		outputTable.AppendSeparator()
+		outputTable.AppendRow(table.Row{"Uncalled vulnerabilities"})
+		outputTable.AppendSeparator()
 
-	outputTable.AppendSeparator()
-	outputTable.AppendRow(table.Row{"Uncalled vulnerabilities"})
-	outputTable.AppendSeparator()
+		for _, elem := range uncalledRows {
+			outputTable.AppendRow(elem.row, table.RowConfig{AutoMerge: elem.shouldMerge})
+		}
+	}
 
-	for _, elem := range uncalledRows {
-		outputTable.AppendRow(elem.row, table.RowConfig{AutoMerge: elem.shouldMerge})
+	if truncated > 0 {
+		outputTable.AppendSeparator()
+		outputTable.AppendRow(table.Row{fmt.Sprintf("... and %d more", truncated)})
 	}
 
 	return outputTable
 }
 
+// CountTableRows returns the number of vulnerability rows that the table and markdown
+// outputs would have for the results before being limited by MaxVulns
+func CountTableRows(vulnResult *models.VulnerabilityResults, options TableOptions) int {
+	rows := tableBuilderInner(vulnResult, false, true, options, false, false)
+	uncalledRows := tableBuilderInner(vulnResult, false, false, options, false, false)
+
+	return len(rows) + len(uncalledRows)
+}
+
+// truncateRows limits the total number of called and uncalled rows to maxRows,
+// keeping the called rows first, and returns the number of rows that were removed.
+//
+// A maxRows of 0 (or less) means there is no limit.
+func truncateRows(calledRows, uncalledRows []tbInnerResponse, maxRows int) ([]tbInnerResponse, []tbInnerResponse, int) {
+	total := len(calledRows) + len(uncalledRows)
+
+	if maxRows <= 0 || total <= maxRows {
+		return calledRows, uncalledRows, 0
+	}
+
+	if len(calledRows) >= maxRows {
+		return calledRows[:maxRows], nil, total - maxRows
+	}
+
+	return calledRows, uncalledRows[:maxRows-len(calledRows)], total - maxRows
+}
+
 type tbInnerResponse struct {
 	row         table.Row
 	shouldMerge bool
 }
 
-func tableBuilderInner(vulnResult *models.VulnerabilityResults, addStyling bool, calledVulns bool) []tbInnerResponse {
+func tableBuilderInner(vulnResult *models.VulnerabilityResults, addStyling bool, calledVulns bool, options TableOptions, showFixedVersion bool, showImageLayer bool) []tbInnerResponse {
 	allOutputRows := []tbInnerResponse{}
-	workingDir := mustGetWorkingDirectory()
+	basePath := sourcePathBase(options.BasePath)
 
 	for _, sourceRes := range vulnResult.Results {
 		for _, pkg := range sourceRes.Packages {
 			source := sourceRes.Source
-			sourcePath, err := filepath.Rel(workingDir, source.Path)
+			sourcePath, err := filepath.Rel(basePath, source.Path)
 			if err == nil { // Simplify the path if possible
 				source.Path = sourcePath
 			}
 
 			// Merge groups into the same row
-			for _, group := range pkg.Groups {
+			for _, group := range collapseAliasedGroups(pkg.Groups) {
 				if group.IsCalled() != calledVulns {
 					continue
 				}
@@ -115,22 +218,75 @@ func tableBuilderInner(vulnResult *models.VulnerabilityResults, addStyling bool,
 					}
 				}
 
+				if options.ShowSummary && options.markdown && group.Summary != "" {
+					links = append(links, group.Summary)
+				}
+				if options.ShowRelated && len(group.Related) > 0 {
+					links = append(links, "Related: "+strings.Join(group.Related, ", "))
+				}
+				if options.ShowCallPaths {
+					if callPath := groupCallPath(group); len(callPath) > 0 {
+						links = append(links, "Call path: "+strings.Join(callPath, " -> "))
+					}
+				}
 				outputRow = append(outputRow, strings.Join(links, "\n"))
-				outputRow = append(outputRow, group.MaxSeverity)
+				if options.ShowAliases {
+					outputRow = append(outputRow, strings.Join(groupAliases(group, pkg), "\n"))
+				}
+				if options.ShowSummary && !options.markdown {
+					outputRow = append(outputRow, truncateSummary(group.Summary, options.summaryWidth))
+				}
+				// some advisories only have a qualitative severity rather than a CVSS vector
+				if group.MaxSeverity != "" {
+					outputRow = append(outputRow, group.MaxSeverity)
+				} else {
+					outputRow = append(outputRow, databaseSpecificSeverity(group, pkg))
+				}
 
 				if pkg.Package.Ecosystem == "" && pkg.Package.Commit != "" {
 					pkgCommitStr := results.PkgToString(pkg.Package)
 					outputRow = append(outputRow, "GIT", pkgCommitStr, pkgCommitStr)
 					shouldMerge = true
+					if options.ShowDependencyRelationship {
+						outputRow = append(outputRow, "")
+					}
+					if options.ShowAffectedRanges {
+						outputRow = append(outputRow, "")
+					}
+					if showFixedVersion {
+						outputRow = append(outputRow, noFixedVersion)
+					}
+					if showImageLayer {
+						outputRow = append(outputRow, "")
+					}
 				} else {
 					name := pkg.Package.Name
 					if lockfile.Ecosystem(pkg.Package.Ecosystem).IsDevGroup(pkg.DepGroups) {
 						name += " (dev)"
 					}
+					if source.Type == "runtime" {
+						name += " (runtime)"
+					}
 					outputRow = append(outputRow, pkg.Package.Ecosystem, name, pkg.Package.Version)
+					if options.ShowDependencyRelationship {
+						outputRow = append(outputRow, dependencyRelationship(pkg))
+					}
+					if options.ShowAffectedRanges {
+						outputRow = append(outputRow, strings.Join(group.AffectedRanges, "\n"))
+					}
+					if showFixedVersion {
+						outputRow = append(outputRow, minFixedVersion(group, pkg))
+					}
+					if showImageLayer {
+						outputRow = append(outputRow, imageLayer(pkg.Package))
+					}
 				}
 
-				outputRow = append(outputRow, source.Path)
+				if source.Workspace != "" {
+					outputRow = append(outputRow, source.Path+"\n("+source.Workspace+")")
+				} else {
+					outputRow = append(outputRow, source.Path)
+				}
 				allOutputRows = append(allOutputRows, tbInnerResponse{
 					row:         outputRow,
 					shouldMerge: shouldMerge,
@@ -142,7 +298,355 @@ func tableBuilderInner(vulnResult *models.VulnerabilityResults, addStyling bool,
 	return allOutputRows
 }
 
-func MaxSeverity(group models.GroupInfo, pkg models.PackageVulns) string {
+// collapseAliasedGroups merges groups that are connected through their aliases, even if
+// only indirectly, so that the same underlying vulnerability is only shown as one row.
+//
+// The IDs of a merged group start with its canonical ID, followed by the others in order.
+func collapseAliasedGroups(groups []models.GroupInfo) []models.GroupInfo {
+	// Mapping of `groups` index to the index of the group it has been merged into
+	merged := make([]int, len(groups))
+	for i := range groups {
+		merged[i] = i
+	}
+
+	var root func(i int) int
+	root = func(i int) int {
+		if merged[i] != i {
+			merged[i] = root(merged[i])
+		}
+
+		return merged[i]
+	}
+
+	for i := range groups {
+		for j := i + 1; j < len(groups); j++ {
+			if slices.ContainsFunc(groups[i].Aliases, func(alias string) bool {
+				return slices.Contains(groups[j].Aliases, alias)
+			}) {
+				merged[root(j)] = root(i)
+			}
+		}
+	}
+
+	collapsed := make([]models.GroupInfo, 0, len(groups))
+	collapsedIndexes := map[int]int{}
+	for i, group := range groups {
+		r := root(i)
+		ci, ok := collapsedIndexes[r]
+		if !ok {
+			collapsedIndexes[r] = len(collapsed)
+			collapsed = append(collapsed, group)
+
+			continue
+		}
+		collapsed[ci] = mergeGroups(collapsed[ci], group)
+	}
+
+	return collapsed
+}
+
+// mergeGroups combines two groups that are the same underlying vulnerability into one
+func mergeGroups(a, b models.GroupInfo) models.GroupInfo {
+	ids := append(slices.Clone(a.IDs), b.IDs...)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	canonical := slices.MinFunc(ids, idSortFunc)
+	ids = append([]string{canonical}, slices.DeleteFunc(ids, func(id string) bool {
+		return id == canonical
+	})...)
+
+	aliases := append(slices.Clone(a.Aliases), b.Aliases...)
+	slices.Sort(aliases)
+
+	affectedRanges := append(slices.Clone(a.AffectedRanges), b.AffectedRanges...)
+	slices.Sort(affectedRanges)
+
+	var analysis map[string]models.AnalysisInfo
+	if len(a.ExperimentalAnalysis) > 0 || len(b.ExperimentalAnalysis) > 0 {
+		analysis = maps.Clone(a.ExperimentalAnalysis)
+		if analysis == nil {
+			analysis = map[string]models.AnalysisInfo{}
+		}
+		maps.Copy(analysis, b.ExperimentalAnalysis)
+	}
+
+	maxSeverity := a.MaxSeverity
+	if severityScore(b.MaxSeverity) > severityScore(a.MaxSeverity) {
+		maxSeverity = b.MaxSeverity
+	}
+
+	a.IDs = ids
+	a.Aliases = slices.Compact(aliases)
+	a.AffectedRanges = slices.Compact(affectedRanges)
+	if a.Summary == "" {
+		a.Summary = b.Summary
+	}
+	if len(b.Related) > 0 {
+		related := append(slices.Clone(a.Related), b.Related...)
+		related = slices.DeleteFunc(related, func(id string) bool {
+			return slices.Contains(a.Aliases, id)
+		})
+		slices.SortFunc(related, idSortFunc)
+		a.Related = slices.Compact(related)
+	}
+	a.ExperimentalAnalysis = analysis
+	a.MaxSeverity = maxSeverity
+
+	return a
+}
+
+// severityScore parses the max severity of a group, which is -1 if it does not have one
+func severityScore(maxSeverity string) float64 {
+	score, err := strconv.ParseFloat(maxSeverity, 64)
+	if err != nil {
+		return -1
+	}
+
+	return score
+}
+
+// dependencyRelationship returns how the package is depended on for display,
+// which is empty if that is not known
+func dependencyRelationship(pkg models.PackageVulns) string {
+	switch lockfile.DependencyRelationship(pkg.DependencyRelationship) {
+	case lockfile.DirectDependency:
+		return "Direct"
+	case lockfile.TransitiveDependency:
+		return "Transitive"
+	case lockfile.UnknownDependency:
+	}
+
+	return ""
+}
+
+// noFixedVersion is displayed when there is no known fixed version
+const noFixedVersion = "—"
+
+// onlyContainerSources returns true if all the results come from scanning container images
+func onlyContainerSources(vulnResult *models.VulnerabilityResults) bool {
+	if len(vulnResult.Results) == 0 {
+		return false
+	}
+
+	for _, sourceRes := range vulnResult.Results {
+		if sourceRes.Source.Type != "docker" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasImageLayers returns true if any of the packages are known to have been introduced by a container image layer
+func hasImageLayers(vulnResult *models.VulnerabilityResults) bool {
+	for _, sourceRes := range vulnResult.Results {
+		for _, pkg := range sourceRes.Packages {
+			if pkg.Package.ImageLayerDigest != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// imageLayer returns the image layer that introduced the package for display, which is
+// the short form of the layer digest followed by the command that created the layer
+func imageLayer(pkg models.PackageInfo) string {
+	if pkg.ImageLayerDigest == "" {
+		return ""
+	}
+
+	_, digest, found := strings.Cut(pkg.ImageLayerDigest, ":")
+	if !found {
+		digest = pkg.ImageLayerDigest
+	}
+	if len(digest) > 12 {
+		digest = digest[:12]
+	}
+
+	// commands of Dockerfile instructions are run by the shell, which is not useful to show
+	command := strings.TrimPrefix(pkg.ImageLayerCommand, "/bin/sh -c ")
+	command = strings.TrimPrefix(command, "#(nop) ")
+	command = strings.TrimSpace(command)
+
+	if command == "" {
+		return digest
+	}
+
+	return digest + "\n" + command
+}
+
+// minFixedVersion returns the lowest fixed version across the vulnerabilities in the group
+// that is greater than the installed version of the package.
+//
+// If the versions of the package's ecosystem cannot be compared, all the fixed versions are returned.
+func minFixedVersion(group models.GroupInfo, pkg models.PackageVulns) string {
+	pkgKey := models.Package{
+		Ecosystem: models.Ecosystem(pkg.Package.Ecosystem),
+		Name:      pkg.Package.Name,
+	}
+
+	var fixedVersions []string
+	for _, vuln := range pkg.Vulnerabilities {
+		if slices.Contains(group.IDs, vuln.ID) {
+			fixedVersions = append(fixedVersions, vuln.FixedVersions()[pkgKey]...)
+		}
+	}
+
+	if len(fixedVersions) == 0 {
+		return noFixedVersion
+	}
+
+	ecosystem := pkg.Package.BaseEcosystem()
+	installed, err := semantic.Parse(pkg.Package.Version, ecosystem)
+	if err != nil {
+		slices.Sort(fixedVersions)
+
+		return strings.Join(slices.Compact(fixedVersions), ", ")
+	}
+
+	minFixed := ""
+	for _, fixed := range fixedVersions {
+		if installed.CompareStr(fixed) >= 0 {
+			continue
+		}
+		if minFixed == "" || semantic.MustParse(fixed, ecosystem).CompareStr(minFixed) < 0 {
+			minFixed = fixed
+		}
+	}
+
+	if minFixed == "" {
+		return noFixedVersion
+	}
+
+	return minFixed
+}
+
+// groupAliases returns the aliases of the vulnerabilities in the group that
+// are not themselves one of the group's IDs, such as CVE IDs
+func groupAliases(group models.GroupInfo, pkg models.PackageVulns) []string {
+	aliases := slices.Clone(group.Aliases)
+	for _, vuln := range pkg.Vulnerabilities {
+		if slices.Contains(group.IDs, vuln.ID) {
+			aliases = append(aliases, vuln.Aliases...)
+		}
+	}
+	aliases = slices.DeleteFunc(aliases, func(alias string) bool {
+		return slices.Contains(group.IDs, alias)
+	})
+	slices.SortFunc(aliases, idSortFunc)
+
+	return slices.Compact(aliases)
+}
+
+// GroupSummary returns the summary of the first vulnerability in the group that has one
+func GroupSummary(group models.GroupInfo, pkg models.PackageVulns) string {
+	for _, id := range group.IDs {
+		for _, vuln := range pkg.Vulnerabilities {
+			if vuln.ID == id && vuln.Summary != "" {
+				return vuln.Summary
+			}
+		}
+	}
+
+	return ""
+}
+
+// GroupRelated returns the IDs of the vulnerabilities that are related to those in the
+// group, not including the IDs and aliases of the group itself
+func GroupRelated(group models.GroupInfo, pkg models.PackageVulns) []string {
+	var related []string
+	for _, vuln := range pkg.Vulnerabilities {
+		if slices.Contains(group.IDs, vuln.ID) {
+			related = append(related, vuln.Related...)
+		}
+	}
+	related = slices.DeleteFunc(related, func(id string) bool {
+		return slices.Contains(group.IDs, id) || slices.Contains(group.Aliases, id)
+	})
+	slices.SortFunc(related, idSortFunc)
+
+	return slices.Compact(related)
+}
+
+// groupCallPath returns the first call path recorded by call analysis for
+// the vulnerabilities in the group, going through their IDs in order
+func groupCallPath(group models.GroupInfo) []string {
+	for _, id := range group.IDs {
+		for _, evidence := range group.ExperimentalAnalysis[id].Evidence {
+			if len(evidence.CallPath) > 0 {
+				return evidence.CallPath
+			}
+		}
+	}
+
+	return nil
+}
+
+// truncateSummary shortens the summary to at most width characters, ending
+// with an ellipsis if it was shortened, unless width is not positive
+func truncateSummary(summary string, width int) string {
+	runes := []rune(summary)
+	if width <= 0 || len(runes) <= width {
+		return summary
+	}
+
+	if width == 1 {
+		return "…"
+	}
+
+	return strings.TrimSpace(string(runes[:width-1])) + "…"
+}
+
+// AffectedRanges returns the version ranges of the package that are affected by the vulnerabilities in the group
+func AffectedRanges(group models.GroupInfo, pkg models.PackageVulns) []string {
+	pkgKey := models.Package{
+		Ecosystem: models.Ecosystem(pkg.Package.Ecosystem),
+		Name:      pkg.Package.Name,
+	}
+
+	var ranges []string
+	for _, vuln := range pkg.Vulnerabilities {
+		if slices.Contains(group.IDs, vuln.ID) {
+			ranges = append(ranges, vuln.AffectedRanges()[pkgKey]...)
+		}
+	}
+	slices.Sort(ranges)
+
+	return slices.Compact(ranges)
+}
+
+// qualitativeSeverityRanks orders the qualitative severities that are given by databases
+var qualitativeSeverityRanks = map[string]int{
+	"LOW":      1,
+	"MODERATE": 2,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
+// databaseSpecificSeverity returns the highest qualitative severity given in the database_specific
+// field of the vulnerabilities in the group, for when none of them have a CVSS vector
+func databaseSpecificSeverity(group models.GroupInfo, pkg models.PackageVulns) string {
+	maxSeverity := ""
+	for _, vuln := range pkg.Vulnerabilities {
+		if !slices.Contains(group.IDs, vuln.ID) {
+			continue
+		}
+
+		severity := vuln.DatabaseSpecificSeverity()
+		if maxSeverity == "" || qualitativeSeverityRanks[severity] > qualitativeSeverityRanks[maxSeverity] {
+			maxSeverity = severity
+		}
+	}
+
+	return maxSeverity
+}
+
+// MaxSeverity returns the highest score of the vulnerabilities in the group, using only the
+// CVSS vectors of the preferred type for each vulnerability that has any, if one is given
+func MaxSeverity(group models.GroupInfo, pkg models.PackageVulns, preferred models.SeverityType) string {
 	var maxSeverity float64 = -1
 	for _, vulnID := range group.IDs {
 		var severities []models.Severity
@@ -151,7 +655,7 @@ func MaxSeverity(group models.GroupInfo, pkg models.PackageVulns) string {
 				severities = vuln.Severity
 			}
 		}
-		score, _, _ := severity.CalculateOverallScore(severities)
+		score, _, _ := severity.CalculatePreferredScore(severities, preferred)
 		maxSeverity = math.Max(maxSeverity, score)
 	}
 
@@ -162,30 +666,48 @@ func MaxSeverity(group models.GroupInfo, pkg models.PackageVulns) string {
 	return fmt.Sprintf("%.1f", maxSeverity)
 }
 
-func licenseTableBuilder(outputTable table.Writer, vulnResult *models.VulnerabilityResults) table.Writer {
+func licenseTableBuilder(outputTable table.Writer, vulnResult *models.VulnerabilityResults, options TableOptions) table.Writer {
 	licenseConfig := vulnResult.ExperimentalAnalysisConfig.Licenses
 	if licenseConfig.Summary {
 		return licenseSummaryTableBuilder(outputTable, vulnResult)
-	} else if len(licenseConfig.Allowlist) > 0 {
-		return licenseViolationsTableBuilder(outputTable, vulnResult)
+	} else if licenseConfig.HasViolationPolicy() {
+		return licenseViolationsTableBuilder(outputTable, vulnResult, options)
 	} else {
 		return outputTable
 	}
 }
 
 func licenseSummaryTableBuilder(outputTable table.Writer, vulnResult *models.VulnerabilityResults) table.Writer {
+	counts := licenseCounts(vulnResult, func(pkg models.PackageVulns) []models.License { return pkg.Licenses })
+	if len(counts) == 0 {
+		// No packages found.
+		return outputTable
+	}
+	outputTable.AppendHeader(table.Row{"License", "No. of package versions"})
+	for _, count := range counts {
+		outputTable.AppendRow(table.Row{count.License, count.Count})
+	}
+
+	return outputTable
+}
+
+// licenseCount is the number of package versions that have a license
+type licenseCount struct {
+	License models.License `json:"license"`
+	Count   int            `json:"count"`
+}
+
+// licenseCounts counts the number of package versions with each of the licenses returned by
+// licensesOf, in descending count order with the UNKNOWN license last.
+func licenseCounts(vulnResult *models.VulnerabilityResults, licensesOf func(models.PackageVulns) []models.License) []licenseCount {
 	counts := make(map[models.License]int)
 	for _, pkgSource := range vulnResult.Results {
 		for _, pkg := range pkgSource.Packages {
-			for _, l := range pkg.Licenses {
+			for _, l := range licensesOf(pkg) {
 				counts[l] += 1
 			}
 		}
 	}
-	if len(counts) == 0 {
-		// No packages found.
-		return outputTable
-	}
 	licenses := maps.Keys(counts)
 	// Sort the license count in descending count order with the UNKNOWN
 	// license last.
@@ -202,17 +724,18 @@ func licenseSummaryTableBuilder(outputTable table.Writer, vulnResult *models.Vul
 
 		return counts[licenses[i]] > counts[licenses[j]]
 	})
-	outputTable.AppendHeader(table.Row{"License", "No. of package versions"})
+
+	result := make([]licenseCount, 0, len(licenses))
 	for _, license := range licenses {
-		outputTable.AppendRow(table.Row{license, counts[license]})
+		result = append(result, licenseCount{License: license, Count: counts[license]})
 	}
 
-	return outputTable
+	return result
 }
 
-func licenseViolationsTableBuilder(outputTable table.Writer, vulnResult *models.VulnerabilityResults) table.Writer {
+func licenseViolationsTableBuilder(outputTable table.Writer, vulnResult *models.VulnerabilityResults, options TableOptions) table.Writer {
 	outputTable.AppendHeader(table.Row{"License Violation", "Ecosystem", "Package", "Version", "Source"})
-	workingDir := mustGetWorkingDirectory()
+	basePath := sourcePathBase(options.BasePath)
 	for _, pkgSource := range vulnResult.Results {
 		for _, pkg := range pkgSource.Packages {
 			if len(pkg.LicenseViolations) == 0 {
@@ -223,7 +746,7 @@ func licenseViolationsTableBuilder(outputTable table.Writer, vulnResult *models.
 				violations[i] = string(l)
 			}
 			path := pkgSource.Source.Path
-			if simplifiedPath, err := filepath.Rel(workingDir, pkgSource.Source.Path); err == nil {
+			if simplifiedPath, err := filepath.Rel(basePath, pkgSource.Source.Path); err == nil {
 				path = simplifiedPath
 			}
 			outputTable.AppendRow(table.Row{
@@ -0,0 +1,419 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func Test_groupAliases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		group models.GroupInfo
+		pkg   models.PackageVulns
+		want  []string
+	}{
+		{
+			name:  "no aliases",
+			group: models.GroupInfo{IDs: []string{"GHSA-123"}, Aliases: []string{"GHSA-123"}},
+			pkg: models.PackageVulns{
+				Vulnerabilities: []models.Vulnerability{{ID: "GHSA-123"}},
+			},
+			want: []string{},
+		},
+		{
+			name:  "aliases from the group and vulnerabilities",
+			group: models.GroupInfo{IDs: []string{"GHSA-123", "GO-2022-0274"}, Aliases: []string{"CVE-2022-1", "GHSA-123", "GO-2022-0274"}},
+			pkg: models.PackageVulns{
+				Vulnerabilities: []models.Vulnerability{
+					{ID: "GO-2022-0274", Aliases: []string{"CVE-2022-1", "GHSA-123"}},
+					{ID: "GHSA-123", Aliases: []string{"CVE-2022-1", "CVE-2022-2"}},
+					{ID: "GHSA-456", Aliases: []string{"CVE-2022-3"}},
+				},
+			},
+			want: []string{"CVE-2022-1", "CVE-2022-2"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := groupAliases(tt.group, tt.pkg)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("groupAliases() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_collapseAliasedGroups(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		groups []models.GroupInfo
+		want   []models.GroupInfo
+	}{
+		{
+			name: "unrelated groups are kept separate",
+			groups: []models.GroupInfo{
+				{IDs: []string{"GHSA-123"}, Aliases: []string{"CVE-2022-1", "GHSA-123"}, MaxSeverity: "5.0"},
+				{IDs: []string{"GHSA-456"}, Aliases: []string{"CVE-2022-2", "GHSA-456"}, MaxSeverity: "7.0"},
+			},
+			want: []models.GroupInfo{
+				{IDs: []string{"GHSA-123"}, Aliases: []string{"CVE-2022-1", "GHSA-123"}, MaxSeverity: "5.0"},
+				{IDs: []string{"GHSA-456"}, Aliases: []string{"CVE-2022-2", "GHSA-456"}, MaxSeverity: "7.0"},
+			},
+		},
+		{
+			name: "groups connected through aliases are merged",
+			groups: []models.GroupInfo{
+				{IDs: []string{"GHSA-123"}, Aliases: []string{"CVE-2022-1", "GHSA-123"}, MaxSeverity: "5.0"},
+				{IDs: []string{"GHSA-456"}, Aliases: []string{"CVE-2022-2", "GHSA-456"}, MaxSeverity: ""},
+				{IDs: []string{"PYSEC-2022-1"}, Aliases: []string{"CVE-2022-1", "CVE-2022-2", "PYSEC-2022-1"}, MaxSeverity: "7.0"},
+			},
+			want: []models.GroupInfo{
+				{
+					IDs:         []string{"PYSEC-2022-1", "GHSA-123", "GHSA-456"},
+					Aliases:     []string{"CVE-2022-1", "CVE-2022-2", "GHSA-123", "GHSA-456", "PYSEC-2022-1"},
+					MaxSeverity: "7.0",
+				},
+			},
+		},
+		{
+			name: "groups are merged even when connected after other groups",
+			groups: []models.GroupInfo{
+				{IDs: []string{"GHSA-1"}, Aliases: []string{"CVE-1", "GHSA-1"}},
+				{IDs: []string{"GHSA-2"}, Aliases: []string{"CVE-2", "GHSA-2"}},
+				{IDs: []string{"GHSA-3"}, Aliases: []string{"CVE-1", "GHSA-3"}},
+				{IDs: []string{"GHSA-4"}, Aliases: []string{"CVE-1", "CVE-2", "GHSA-4"}},
+			},
+			want: []models.GroupInfo{
+				{
+					IDs:     []string{"GHSA-1", "GHSA-2", "GHSA-3", "GHSA-4"},
+					Aliases: []string{"CVE-1", "CVE-2", "GHSA-1", "GHSA-2", "GHSA-3", "GHSA-4"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := collapseAliasedGroups(tt.groups)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("collapseAliasedGroups() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_truncateSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		summary string
+		width   int
+		want    string
+	}{
+		{
+			name:    "no limit",
+			summary: "Denial of service in the regex crate",
+			width:   0,
+			want:    "Denial of service in the regex crate",
+		},
+		{
+			name:    "shorter than the limit",
+			summary: "Denial of service",
+			width:   20,
+			want:    "Denial of service",
+		},
+		{
+			name:    "longer than the limit",
+			summary: "Denial of service in the regex crate",
+			width:   18,
+			want:    "Denial of service…",
+		},
+		{
+			name:    "multi-byte characters",
+			summary: "Déni de service",
+			width:   5,
+			want:    "Déni…",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := truncateSummary(tt.summary, tt.width); got != tt.want {
+				t.Errorf("truncateSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupRelated(t *testing.T) {
+	t.Parallel()
+
+	pkg := models.PackageVulns{
+		Vulnerabilities: []models.Vulnerability{
+			{ID: "GHSA-1", Aliases: []string{"CVE-1"}, Related: []string{"CVE-1", "GHSA-3", "OSV-2"}},
+			{ID: "CVE-1", Aliases: []string{"GHSA-1"}, Related: []string{"GHSA-3", "GHSA-1"}},
+			{ID: "GHSA-4", Related: []string{"GHSA-5"}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		group models.GroupInfo
+		want  []string
+	}{
+		{
+			name:  "no related vulnerabilities",
+			group: models.GroupInfo{IDs: []string{"GHSA-6"}},
+			want:  nil,
+		},
+		{
+			name:  "related vulnerabilities are deduplicated and exclude the group itself",
+			group: models.GroupInfo{IDs: []string{"CVE-1", "GHSA-1"}, Aliases: []string{"CVE-1", "GHSA-1"}},
+			want:  []string{"OSV-2", "GHSA-3"},
+		},
+		{
+			name:  "only the vulnerabilities in the group are considered",
+			group: models.GroupInfo{IDs: []string{"GHSA-4"}},
+			want:  []string{"GHSA-5"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := GroupRelated(tt.group, pkg)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GroupRelated() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_minFixedVersion(t *testing.T) {
+	t.Parallel()
+
+	affected := func(ecosystem, name string, fixed ...string) models.Affected {
+		events := []models.Event{{Introduced: "0"}}
+		for _, f := range fixed {
+			events = append(events, models.Event{Fixed: f})
+		}
+
+		return models.Affected{
+			Package: models.Package{Ecosystem: models.Ecosystem(ecosystem), Name: name},
+			Ranges:  []models.Range{{Type: models.RangeEcosystem, Events: events}},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		group models.GroupInfo
+		pkg   models.PackageVulns
+		want  string
+	}{
+		{
+			name:  "no fixed versions",
+			group: models.GroupInfo{IDs: []string{"OSV-1"}},
+			pkg: models.PackageVulns{
+				Package:         models.PackageInfo{Ecosystem: "npm", Name: "mine1", Version: "1.2.3"},
+				Vulnerabilities: []models.Vulnerability{{ID: "OSV-1"}},
+			},
+			want: noFixedVersion,
+		},
+		{
+			name:  "lowest fixed version greater than the installed version",
+			group: models.GroupInfo{IDs: []string{"OSV-1", "OSV-2"}},
+			pkg: models.PackageVulns{
+				Package: models.PackageInfo{Ecosystem: "npm", Name: "mine1", Version: "1.2.3"},
+				Vulnerabilities: []models.Vulnerability{
+					{ID: "OSV-1", Affected: []models.Affected{affected("npm", "mine1", "1.0.0", "1.10.0")}},
+					{ID: "OSV-2", Affected: []models.Affected{affected("npm", "mine1", "1.9.0")}},
+					{ID: "OSV-3", Affected: []models.Affected{affected("npm", "mine1", "1.3.0")}},
+				},
+			},
+			want: "1.9.0",
+		},
+		{
+			name:  "only older fixed versions",
+			group: models.GroupInfo{IDs: []string{"OSV-1"}},
+			pkg: models.PackageVulns{
+				Package: models.PackageInfo{Ecosystem: "npm", Name: "mine1", Version: "1.2.3"},
+				Vulnerabilities: []models.Vulnerability{
+					{ID: "OSV-1", Affected: []models.Affected{affected("npm", "mine1", "1.0.0")}},
+				},
+			},
+			want: noFixedVersion,
+		},
+		{
+			name:  "ecosystem with a release suffix",
+			group: models.GroupInfo{IDs: []string{"DSA-1"}},
+			pkg: models.PackageVulns{
+				Package: models.PackageInfo{Ecosystem: "Debian:11", Name: "curl", Version: "7.74.0-1.3"},
+				Vulnerabilities: []models.Vulnerability{
+					{ID: "DSA-1", Affected: []models.Affected{affected("Debian:11", "curl", "7.74.0-1.3+deb11u1")}},
+				},
+			},
+			want: "7.74.0-1.3+deb11u1",
+		},
+		{
+			name:  "ecosystem without version comparison support",
+			group: models.GroupInfo{IDs: []string{"OSV-1"}},
+			pkg: models.PackageVulns{
+				Package: models.PackageInfo{Ecosystem: "Unknown", Name: "mine1", Version: "1"},
+				Vulnerabilities: []models.Vulnerability{
+					{ID: "OSV-1", Affected: []models.Affected{affected("Unknown", "mine1", "3", "2", "3")}},
+				},
+			},
+			want: "2, 3",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := minFixedVersion(tt.group, tt.pkg)
+			if got != tt.want {
+				t.Errorf("minFixedVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dependencyRelationship(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		pkg  models.PackageVulns
+		want string
+	}{
+		{
+			name: "unknown",
+			pkg:  models.PackageVulns{},
+			want: "",
+		},
+		{
+			name: "direct",
+			pkg:  models.PackageVulns{DependencyRelationship: "direct"},
+			want: "Direct",
+		},
+		{
+			name: "transitive",
+			pkg:  models.PackageVulns{DependencyRelationship: "transitive"},
+			want: "Transitive",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := dependencyRelationship(tt.pkg)
+			if got != tt.want {
+				t.Errorf("dependencyRelationship() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_imageLayer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		pkg  models.PackageInfo
+		want string
+	}{
+		{
+			name: "not from an image",
+			pkg:  models.PackageInfo{},
+			want: "",
+		},
+		{
+			name: "without a command",
+			pkg: models.PackageInfo{
+				ImageLayerDigest: "sha256:4693057ce2364720d39e57e85a5b8e0bd9ac3573716237736d6470ec5b7b7230",
+			},
+			want: "4693057ce236",
+		},
+		{
+			name: "with a shell command",
+			pkg: models.PackageInfo{
+				ImageLayerDigest:  "sha256:4693057ce2364720d39e57e85a5b8e0bd9ac3573716237736d6470ec5b7b7230",
+				ImageLayerCommand: "/bin/sh -c apk add --no-cache curl",
+			},
+			want: "4693057ce236\napk add --no-cache curl",
+		},
+		{
+			name: "with a nop command",
+			pkg: models.PackageInfo{
+				ImageLayerDigest:  "sha256:4693057ce2364720d39e57e85a5b8e0bd9ac3573716237736d6470ec5b7b7230",
+				ImageLayerCommand: "/bin/sh -c #(nop) ADD file:1b6e3b4b3d3f4a5e in / ",
+			},
+			want: "4693057ce236\nADD file:1b6e3b4b3d3f4a5e in /",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := imageLayer(tt.pkg)
+			if got != tt.want {
+				t.Errorf("imageLayer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_truncateRows(t *testing.T) {
+	t.Parallel()
+
+	rows := func(n int) []tbInnerResponse {
+		r := make([]tbInnerResponse, 0, n)
+		for i := 0; i < n; i++ {
+			r = append(r, tbInnerResponse{row: table.Row{i}})
+		}
+
+		return r
+	}
+
+	tests := []struct {
+		name          string
+		called        int
+		uncalled      int
+		maxRows       int
+		wantCalled    int
+		wantUncalled  int
+		wantTruncated int
+	}{
+		{name: "no limit", called: 3, uncalled: 2, maxRows: 0, wantCalled: 3, wantUncalled: 2, wantTruncated: 0},
+		{name: "within the limit", called: 3, uncalled: 2, maxRows: 5, wantCalled: 3, wantUncalled: 2, wantTruncated: 0},
+		{name: "only uncalled rows are removed", called: 3, uncalled: 2, maxRows: 4, wantCalled: 3, wantUncalled: 1, wantTruncated: 1},
+		{name: "all uncalled rows are removed", called: 3, uncalled: 2, maxRows: 3, wantCalled: 3, wantUncalled: 0, wantTruncated: 2},
+		{name: "called rows are removed", called: 3, uncalled: 2, maxRows: 1, wantCalled: 1, wantUncalled: 0, wantTruncated: 4},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotCalled, gotUncalled, gotTruncated := truncateRows(rows(tt.called), rows(tt.uncalled), tt.maxRows)
+			if len(gotCalled) != tt.wantCalled || len(gotUncalled) != tt.wantUncalled || gotTruncated != tt.wantTruncated {
+				t.Errorf(
+					"truncateRows() = (%d, %d, %d), want (%d, %d, %d)",
+					len(gotCalled), len(gotUncalled), gotTruncated,
+					tt.wantCalled, tt.wantUncalled, tt.wantTruncated,
+				)
+			}
+		})
+	}
+}
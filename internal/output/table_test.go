@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/osv-scanner/internal/output"
 	"github.com/google/osv-scanner/internal/testutility"
+	"github.com/google/osv-scanner/pkg/models"
 	"github.com/jedib0t/go-pretty/v6/text"
 )
 
@@ -16,7 +17,7 @@ func TestPrintTableResults_StandardTerminalWidth_WithVulnerabilities(t *testing.
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 80)
+		output.PrintTableResults(args.vulnResult, outputWriter, 80, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -29,7 +30,7 @@ func TestPrintTableResults_StandardTerminalWidth_WithLicenseViolations(t *testin
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 80)
+		output.PrintTableResults(args.vulnResult, outputWriter, 80, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -42,7 +43,7 @@ func TestPrintTableResults_StandardTerminalWidth_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 80)
+		output.PrintTableResults(args.vulnResult, outputWriter, 80, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -55,7 +56,7 @@ func TestPrintTableResults_LongTerminalWidth_WithVulnerabilities(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 800)
+		output.PrintTableResults(args.vulnResult, outputWriter, 800, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -68,7 +69,7 @@ func TestPrintTableResults_LongTerminalWidth_WithLicenseViolations(t *testing.T)
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 800)
+		output.PrintTableResults(args.vulnResult, outputWriter, 800, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -81,7 +82,7 @@ func TestPrintTableResults_LongTerminalWidth_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 800)
+		output.PrintTableResults(args.vulnResult, outputWriter, 800, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -94,7 +95,7 @@ func TestPrintTableResults_NoTerminalWidth_WithVulnerabilities(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, -1)
+		output.PrintTableResults(args.vulnResult, outputWriter, -1, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
@@ -107,7 +108,7 @@ func TestPrintTableResults_NoTerminalWidth_WithLicenseViolations(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, -1)
+		output.PrintTableResults(args.vulnResult, outputWriter, -1, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
@@ -120,8 +121,147 @@ func TestPrintTableResults_NoTerminalWidth_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, -1)
+		output.PrintTableResults(args.vulnResult, outputWriter, -1, output.TableOptions{})
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
 }
+
+func TestPrintTableResults_ShowAliases_WithVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithVulnerabilities(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		output.PrintTableResults(args.vulnResult, outputWriter, 800, output.TableOptions{ShowAliases: true})
+
+		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+	})
+}
+
+func TestPrintTableResults_ShowDependencyRelationship_WithVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithVulnerabilities(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		output.PrintTableResults(args.vulnResult, outputWriter, 800, output.TableOptions{ShowDependencyRelationship: true})
+
+		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+	})
+}
+
+func TestPrintTableResults_MaxVulns_WithVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithVulnerabilities(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		output.PrintTableResults(args.vulnResult, outputWriter, 800, output.TableOptions{MaxVulns: 1})
+
+		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+	})
+}
+
+func TestPrintTableResults_DatabaseSpecificSeverity(t *testing.T) {
+	t.Parallel()
+
+	// GitHub advisories for some ecosystems only have a qualitative severity
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/composer.lock", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "mine/package", Version: "1.0.0", Ecosystem: "Packagist"},
+						Vulnerabilities: []models.Vulnerability{
+							{ID: "GHSA-1", DatabaseSpecific: map[string]interface{}{"severity": "MODERATE"}},
+							{ID: "GHSA-2", DatabaseSpecific: map[string]interface{}{"severity": "HIGH"}},
+							{ID: "GHSA-3", DatabaseSpecific: map[string]interface{}{"severity": "LOW"}},
+						},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"GHSA-1", "GHSA-2"}},
+							{IDs: []string{"GHSA-3"}, MaxSeverity: "3.1"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(vulnResult, outputWriter, 0, output.TableOptions{BasePath: "/path/to"})
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
+func TestPrintTableResults_ShowCallPaths(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/go.mod", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "github.com/mine/package", Version: "1.0.0", Ecosystem: "Go"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GO-1"}, {ID: "GO-2"}},
+						Groups: []models.GroupInfo{
+							{
+								IDs: []string{"GO-1"},
+								ExperimentalAnalysis: map[string]models.AnalysisInfo{
+									"GO-1": {
+										Called: true,
+										Evidence: []models.AnalysisEvidence{{
+											Symbol:   "github.com/mine/package.Parse",
+											Caller:   "main.main",
+											CallPath: []string{"main.main", "example.com/app.load", "github.com/mine/package.Parse"},
+										}},
+									},
+								},
+							},
+							{
+								IDs: []string{"GO-2"},
+								ExperimentalAnalysis: map[string]models.AnalysisInfo{
+									"GO-2": {Called: false},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(vulnResult, outputWriter, 0, output.TableOptions{BasePath: "/path/to", ShowCallPaths: true})
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
+func TestPrintTableResults_Runtimes(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/.nvmrc", Type: "runtime"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "node", Version: "18", Ecosystem: "Bitnami"},
+						Vulnerabilities: []models.Vulnerability{{ID: "BIT-node-2024-27983"}},
+						Groups:          []models.GroupInfo{{IDs: []string{"BIT-node-2024-27983"}, MaxSeverity: "8.2"}},
+					},
+				},
+			},
+		},
+	}
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(vulnResult, outputWriter, 0, output.TableOptions{BasePath: "/path/to"})
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
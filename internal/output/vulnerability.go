@@ -0,0 +1,118 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scanner/internal/utility/severity"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// PrintVulnerabilityTable prints the details of a single vulnerability as a human friendly table
+func PrintVulnerabilityTable(vuln models.Vulnerability, outputWriter io.Writer, terminalWidth int) {
+	outputTable := newTable(outputWriter, terminalWidth)
+	outputTable = vulnerabilityTableBuilder(outputTable, vuln)
+	outputTable.Render()
+}
+
+// PrintVulnerabilityJSON prints a single vulnerability in the OSV format
+func PrintVulnerabilityJSON(vuln models.Vulnerability, outputWriter io.Writer) error {
+	encoder := json.NewEncoder(outputWriter)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(vuln)
+}
+
+func vulnerabilityTableBuilder(outputTable table.Writer, vuln models.Vulnerability) table.Writer {
+	outputTable.AppendRow(table.Row{"ID", vuln.ID})
+	outputTable.AppendRow(table.Row{"OSV URL", OSVBaseVulnerabilityURL + vuln.ID})
+
+	if vuln.Summary != "" {
+		outputTable.AppendRow(table.Row{"Summary", vuln.Summary})
+	}
+
+	if len(vuln.Aliases) > 0 {
+		aliases := slices.Clone(vuln.Aliases)
+		slices.SortFunc(aliases, idSortFunc)
+		outputTable.AppendRow(table.Row{"Aliases", strings.Join(aliases, "\n")})
+	}
+
+	if len(vuln.Related) > 0 {
+		related := slices.Clone(vuln.Related)
+		slices.SortFunc(related, idSortFunc)
+		outputTable.AppendRow(table.Row{"Related", strings.Join(related, "\n")})
+	}
+
+	if len(vuln.Severity) > 0 {
+		outputTable.AppendRow(table.Row{"Severity", strings.Join(describeSeverities(vuln.Severity), "\n")})
+	}
+
+	if affected := describeAffected(vuln); len(affected) > 0 {
+		outputTable.AppendRow(table.Row{"Affected", strings.Join(affected, "\n")})
+	}
+
+	if !vuln.Published.IsZero() {
+		outputTable.AppendRow(table.Row{"Published", vuln.Published.UTC().Format(time.RFC3339)})
+	}
+	outputTable.AppendRow(table.Row{"Modified", vuln.Modified.UTC().Format(time.RFC3339)})
+	if !vuln.Withdrawn.IsZero() {
+		outputTable.AppendRow(table.Row{"Withdrawn", vuln.Withdrawn.UTC().Format(time.RFC3339)})
+	}
+
+	if len(vuln.References) > 0 {
+		references := make([]string, 0, len(vuln.References))
+		for _, ref := range vuln.References {
+			references = append(references, fmt.Sprintf("%s: %s", ref.Type, ref.URL))
+		}
+		outputTable.AppendRow(table.Row{"References", strings.Join(references, "\n")})
+	}
+
+	return outputTable
+}
+
+// describeSeverities describes the score and rating of each of the severities along with its vector
+func describeSeverities(severities []models.Severity) []string {
+	described := make([]string, 0, len(severities))
+	for _, sev := range severities {
+		score, rating, err := severity.CalculateScore(sev)
+		if err != nil || score < 0 {
+			described = append(described, fmt.Sprintf("%s: %s", sev.Type, sev.Score))
+			continue
+		}
+
+		described = append(described, fmt.Sprintf("%.1f (%s) %s: %s", score, rating, sev.Type, sev.Score))
+	}
+
+	return described
+}
+
+// describeAffected describes the ranges of versions that are affected of each package,
+// which is just the package if the ranges cannot be described, such as for git ranges
+func describeAffected(vuln models.Vulnerability) []string {
+	ranges := vuln.AffectedRanges()
+
+	var described []string
+	for _, a := range vuln.Affected {
+		if a.Package.Name == "" {
+			continue
+		}
+
+		pkgKey := a.Package
+		pkgKey.Purl = ""
+		line := fmt.Sprintf("%s/%s", a.Package.Ecosystem, a.Package.Name)
+		if pkgRanges := ranges[pkgKey]; len(pkgRanges) > 0 {
+			line += ": " + strings.Join(pkgRanges, "; ")
+		}
+
+		if !slices.Contains(described, line) {
+			described = append(described, line)
+		}
+	}
+
+	return described
+}
@@ -0,0 +1,59 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/internal/testutility"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func testVulnerability() models.Vulnerability {
+	return models.Vulnerability{
+		ID:        "GHSA-29mw-wpgm-hmr9",
+		Modified:  time.Date(2024, 2, 20, 5, 31, 0, 0, time.UTC),
+		Published: time.Date(2022, 1, 6, 20, 30, 46, 0, time.UTC),
+		Aliases:   []string{"CVE-2020-28500"},
+		Summary:   "Regular Expression Denial of Service (ReDoS) in lodash",
+		Affected: []models.Affected{
+			{
+				Package: models.Package{Ecosystem: models.EcosystemNPM, Name: "lodash"},
+				Ranges: []models.Range{
+					{
+						Type:   models.RangeSemVer,
+						Events: []models.Event{{Introduced: "4.0.0"}, {Fixed: "4.17.21"}},
+					},
+				},
+			},
+		},
+		Severity: []models.Severity{
+			{Type: models.SeverityCVSSV3, Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:L"},
+		},
+		References: []models.Reference{
+			{Type: models.ReferenceAdvisory, URL: "https://nvd.nist.gov/vuln/detail/CVE-2020-28500"},
+			{Type: models.ReferencePackage, URL: "https://github.com/lodash/lodash"},
+		},
+	}
+}
+
+func TestPrintVulnerabilityTable(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintVulnerabilityTable(testVulnerability(), outputWriter, 0)
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
+func TestPrintVulnerabilityJSON(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	if err := output.PrintVulnerabilityJSON(testVulnerability(), outputWriter); err != nil {
+		t.Fatalf("PrintVulnerabilityJSON() error = %v", err)
+	}
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
@@ -0,0 +1,169 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/google/osv-scanner/internal/version"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// WebhookOptions controls the summary of the results that is posted to a webhook
+type WebhookOptions struct {
+	// BasePath is the path that source paths are made relative to, rather than the working directory
+	BasePath string
+	// MaxFindings is the number of the most severe findings to include, with 0 meaning the default
+	MaxFindings int
+}
+
+// defaultWebhookMaxFindings is how many findings are included in a webhook summary by default,
+// which keeps it short enough to be read in a chat message
+const defaultWebhookMaxFindings = 10
+
+// WebhookSummary is what is posted to a webhook after a scan, which has a text field so that it
+// can be shown as-is by chat webhooks (such as those of Slack and Teams), along with the counts
+// and the most severe findings for generic webhooks
+type WebhookSummary struct {
+	Text                  string           `json:"text"`
+	Version               string           `json:"version"`
+	VulnerabilityCount    int              `json:"vulnerability_count"`
+	AffectedPackageCount  int              `json:"affected_package_count"`
+	LicenseViolationCount int              `json:"license_violation_count"`
+	SeverityCounts        map[string]int   `json:"severity_counts"`
+	TopFindings           []WebhookFinding `json:"top_findings"`
+}
+
+// WebhookFinding is a group of aliased vulnerabilities affecting a package
+type WebhookFinding struct {
+	IDs         []string `json:"ids"`
+	Package     string   `json:"package"`
+	Version     string   `json:"version"`
+	Ecosystem   string   `json:"ecosystem"`
+	Source      string   `json:"source"`
+	MaxSeverity string   `json:"max_severity,omitempty"`
+	Rating      string   `json:"rating"`
+}
+
+// HasFindings returns true if any vulnerabilities or license violations were found
+func (s WebhookSummary) HasFindings() bool {
+	return s.VulnerabilityCount > 0 || s.LicenseViolationCount > 0
+}
+
+// severityRating returns the CVSS v3 qualitative rating of the max severity of a group,
+// which is "unknown" if it does not have one
+func severityRating(maxSeverity string) string {
+	score := severityScore(maxSeverity)
+
+	switch {
+	case score < 0:
+		return "unknown"
+	case score >= 9:
+		return "critical"
+	case score >= 7:
+		return "high"
+	case score >= 4:
+		return "medium"
+	case score > 0:
+		return "low"
+	default:
+		return "none"
+	}
+}
+
+// NewWebhookSummary summarizes the results for posting to a webhook, not including anything
+// that was filtered out, with the findings ordered from the most to least severe
+func NewWebhookSummary(vulnResult *models.VulnerabilityResults, options WebhookOptions) WebhookSummary {
+	vulnCount, counts := countFindings(vulnResult)
+	summary := WebhookSummary{
+		Version:               version.OSVVersion,
+		VulnerabilityCount:    vulnCount,
+		AffectedPackageCount:  counts.AffectedPackageCount,
+		LicenseViolationCount: counts.LicenseViolationCount,
+		SeverityCounts:        map[string]int{},
+		TopFindings:           []WebhookFinding{},
+	}
+
+	basePath := sourcePathBase(options.BasePath)
+	unfiltered := vulnResult.WithoutFiltered()
+	var findings []WebhookFinding
+	for _, source := range unfiltered.Results {
+		sourcePath := source.Source.Path
+		if rel, err := filepath.Rel(basePath, sourcePath); err == nil {
+			sourcePath = rel
+		}
+
+		for _, pkg := range source.Packages {
+			for _, group := range pkg.Groups {
+				rating := severityRating(group.MaxSeverity)
+				summary.SeverityCounts[rating]++
+				findings = append(findings, WebhookFinding{
+					IDs:         group.IDs,
+					Package:     pkg.Package.Name,
+					Version:     pkg.Package.Version,
+					Ecosystem:   pkg.Package.Ecosystem,
+					Source:      sourcePath,
+					MaxSeverity: group.MaxSeverity,
+					Rating:      rating,
+				})
+			}
+		}
+	}
+
+	slices.SortStableFunc(findings, func(a, b WebhookFinding) int {
+		return cmp.Compare(severityScore(b.MaxSeverity), severityScore(a.MaxSeverity))
+	})
+
+	maxFindings := options.MaxFindings
+	if maxFindings <= 0 {
+		maxFindings = defaultWebhookMaxFindings
+	}
+	if len(findings) > maxFindings {
+		findings = findings[:maxFindings]
+	}
+	summary.TopFindings = append(summary.TopFindings, findings...)
+	summary.Text = webhookText(summary)
+
+	return summary
+}
+
+// webhookText describes the summary in a sentence, followed by a line for each of the top findings
+func webhookText(summary WebhookSummary) string {
+	if !summary.HasFindings() {
+		return "OSV-Scanner found no issues"
+	}
+
+	text := fmt.Sprintf(
+		"OSV-Scanner found %d %s affecting %d %s",
+		summary.VulnerabilityCount,
+		Form(summary.VulnerabilityCount, "vulnerability", "vulnerabilities"),
+		summary.AffectedPackageCount,
+		Form(summary.AffectedPackageCount, "package", "packages"),
+	)
+
+	if summary.LicenseViolationCount > 0 {
+		text += fmt.Sprintf(
+			", and %d %s with license violations",
+			summary.LicenseViolationCount,
+			Form(summary.LicenseViolationCount, "package", "packages"),
+		)
+	}
+
+	for _, finding := range summary.TopFindings {
+		text += fmt.Sprintf(
+			"\n- %s (%s) in %s@%s (%s)",
+			finding.IDs[0],
+			finding.Rating,
+			finding.Package,
+			finding.Version,
+			finding.Source,
+		)
+	}
+
+	if remaining := summary.VulnerabilityCount - len(summary.TopFindings); remaining > 0 {
+		text += fmt.Sprintf("\n... and %d more", remaining)
+	}
+
+	return text
+}
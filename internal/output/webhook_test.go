@@ -0,0 +1,125 @@
+package output_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/internal/version"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestNewWebhookSummary(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"},
+			Packages: []models.PackageVulns{
+				{
+					Package:         models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+					Vulnerabilities: []models.Vulnerability{{ID: "OSV-1"}, {ID: "OSV-2"}, {ID: "OSV-3"}},
+					Groups: []models.GroupInfo{
+						{IDs: []string{"OSV-1"}, MaxSeverity: "5.3"},
+						{IDs: []string{"OSV-2"}, MaxSeverity: "9.8"},
+						{IDs: []string{"OSV-3"}, MaxSeverity: "7.5", Filtered: true},
+					},
+				},
+				{
+					Package:         models.PackageInfo{Name: "left-pad", Version: "1.0.0", Ecosystem: "npm"},
+					Vulnerabilities: []models.Vulnerability{{ID: "OSV-4"}},
+					Groups:          []models.GroupInfo{{IDs: []string{"OSV-4"}}},
+				},
+			},
+		}},
+	}
+
+	finding := func(id string, pkg string, version string, maxSeverity string, rating string) output.WebhookFinding {
+		return output.WebhookFinding{
+			IDs:         []string{id},
+			Package:     pkg,
+			Version:     version,
+			Ecosystem:   "npm",
+			Source:      "package-lock.json",
+			MaxSeverity: maxSeverity,
+			Rating:      rating,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		maxFindings int
+		want        output.WebhookSummary
+	}{
+		{
+			name:        "all findings",
+			maxFindings: 0,
+			want: output.WebhookSummary{
+				Text: "OSV-Scanner found 3 vulnerabilities affecting 2 packages\n" +
+					"- OSV-2 (critical) in lodash@4.17.20 (package-lock.json)\n" +
+					"- OSV-1 (medium) in lodash@4.17.20 (package-lock.json)\n" +
+					"- OSV-4 (unknown) in left-pad@1.0.0 (package-lock.json)",
+				Version:              version.OSVVersion,
+				VulnerabilityCount:   3,
+				AffectedPackageCount: 2,
+				SeverityCounts:       map[string]int{"critical": 1, "medium": 1, "unknown": 1},
+				TopFindings: []output.WebhookFinding{
+					finding("OSV-2", "lodash", "4.17.20", "9.8", "critical"),
+					finding("OSV-1", "lodash", "4.17.20", "5.3", "medium"),
+					finding("OSV-4", "left-pad", "1.0.0", "", "unknown"),
+				},
+			},
+		},
+		{
+			name:        "only the most severe findings",
+			maxFindings: 1,
+			want: output.WebhookSummary{
+				Text: "OSV-Scanner found 3 vulnerabilities affecting 2 packages\n" +
+					"- OSV-2 (critical) in lodash@4.17.20 (package-lock.json)\n" +
+					"... and 2 more",
+				Version:              version.OSVVersion,
+				VulnerabilityCount:   3,
+				AffectedPackageCount: 2,
+				SeverityCounts:       map[string]int{"critical": 1, "medium": 1, "unknown": 1},
+				TopFindings: []output.WebhookFinding{
+					finding("OSV-2", "lodash", "4.17.20", "9.8", "critical"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Reinitialize for t.Parallel()
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := output.NewWebhookSummary(vulnResult, output.WebhookOptions{BasePath: "/path/to", MaxFindings: tt.maxFindings})
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewWebhookSummary() (-want +got):\n%s", diff)
+			}
+
+			if !got.HasFindings() {
+				t.Errorf("Expected the summary to have findings")
+			}
+		})
+	}
+}
+
+func TestNewWebhookSummary_NoFindings(t *testing.T) {
+	t.Parallel()
+
+	got := output.NewWebhookSummary(&models.VulnerabilityResults{}, output.WebhookOptions{})
+
+	if got.HasFindings() {
+		t.Errorf("Expected the summary to not have findings")
+	}
+
+	if got.Text != "OSV-Scanner found no issues" {
+		t.Errorf("Expected the text to say nothing was found, but got %q", got.Text)
+	}
+
+	if got.TopFindings == nil || got.SeverityCounts == nil {
+		t.Errorf("Expected the findings and counts to be empty rather than nil, so they are not null in the json")
+	}
+}
@@ -0,0 +1,110 @@
+package packagist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/osv-scanner/internal/semantic"
+	"github.com/google/osv-scanner/pkg/osv"
+)
+
+// DefaultEndpoint is the Packagist metadata API, as documented at https://packagist.org/apidoc
+const DefaultEndpoint = "https://repo.packagist.org/p2"
+
+// Client looks up the versions of packages that have been published on Packagist,
+// and is not safe for concurrent use
+type Client struct {
+	Endpoint   string
+	HTTPClient *http.Client
+
+	// versions caches the versions of each package that has been looked up
+	versions map[string][]string
+}
+
+func NewClient() *Client {
+	return &Client{
+		Endpoint:   DefaultEndpoint,
+		HTTPClient: http.DefaultClient,
+		versions:   make(map[string][]string),
+	}
+}
+
+type packageMetadata struct {
+	Packages map[string][]struct {
+		Version string `json:"version"`
+	} `json:"packages"`
+}
+
+// Versions returns the tagged versions of the package with the given name, such as "monolog/monolog"
+func (c *Client) Versions(name string) ([]string, error) {
+	if versions, ok := c.versions[name]; ok {
+		return versions, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.Endpoint+"/"+name+".json", nil)
+	if err != nil {
+		return nil, err
+	}
+	if osv.RequestUserAgent != "" {
+		req.Header.Set("User-Agent", osv.RequestUserAgent)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to look up %s on Packagist: %s", name, resp.Status)
+	}
+
+	var metadata packageMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to look up %s on Packagist: %w", name, err)
+	}
+
+	versions := make([]string, 0, len(metadata.Packages[name]))
+	for _, v := range metadata.Packages[name] {
+		// entries of the minified metadata only include what has changed from the previous one
+		if v.Version != "" {
+			versions = append(versions, v.Version)
+		}
+	}
+	c.versions[name] = versions
+
+	return versions, nil
+}
+
+// Resolve returns the latest stable version of the package that satisfies the constraint,
+// or an empty string if none of its versions do
+func (c *Client) Resolve(name string, constraint string) (string, error) {
+	parsed, err := ParseConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+
+	versions, err := c.Versions(name)
+	if err != nil {
+		return "", err
+	}
+
+	return latestMatching(parsed, versions), nil
+}
+
+// latestMatching returns the latest stable version that satisfies the constraint
+func latestMatching(constraint Constraint, versions []string) string {
+	latest := ""
+	for _, v := range versions {
+		if !isStable(v) || !constraint.Matches(v) {
+			continue
+		}
+
+		if latest == "" || semantic.MustParse(normalize(v), "Packagist").CompareStr(normalize(latest)) > 0 {
+			latest = v
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,76 @@
+package packagist_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/osv-scanner/internal/packagist"
+)
+
+func newTestClient(t *testing.T) *packagist.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/monolog/monolog.json" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"minified": "composer/2.0",
+			"packages": {
+				"monolog/monolog": [
+					{ "name": "monolog/monolog", "version": "3.0.0-RC1" },
+					{ "version": "2.9.2" },
+					{ "version": "2.9.1" },
+					{ "version": "2.10.0" },
+					{ "version": "1.27.1" }
+				]
+			}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := packagist.NewClient()
+	client.Endpoint = server.URL
+	client.HTTPClient = server.Client()
+
+	return client
+}
+
+func TestClient_Resolve(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{constraint: "^2.9", want: "2.10.0"},
+		{constraint: "~2.9.1", want: "2.9.2"},
+		{constraint: "^1.0 || ^2.0", want: "2.10.0"},
+		{constraint: "^3.0", want: ""},
+		{constraint: "<2", want: "1.27.1"},
+	}
+	for _, tt := range tests {
+		got, err := client.Resolve("monolog/monolog", tt.constraint)
+		if err != nil {
+			t.Errorf("Resolve(%q) unexpected error: %v", tt.constraint, err)
+		}
+		if got != tt.want {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.constraint, got, tt.want)
+		}
+	}
+}
+
+func TestClient_Resolve_NotFound(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+
+	if _, err := client.Resolve("my-company/private", "^1.0"); err == nil {
+		t.Errorf("Resolve() expected an error for a package that is not on Packagist")
+	}
+}
@@ -0,0 +1,256 @@
+// Package packagist resolves the version constraints of Composer packages
+// to the concrete versions published on Packagist.
+package packagist
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+	"github.com/google/osv-scanner/internal/semantic"
+)
+
+var ErrUnsupportedConstraint = errors.New("unsupported constraint")
+
+// bound is a single comparison that a version must satisfy, such as ">= 1.2.0.0"
+type bound struct {
+	op      string
+	version string
+}
+
+func (b bound) matches(version string) bool {
+	cmp := semantic.MustParse(version, "Packagist").CompareStr(b.version)
+
+	switch b.op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case "!=":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+// Constraint is a Composer version constraint, which is satisfied by a version
+// that satisfies all the bounds of any one of its alternatives
+type Constraint struct {
+	alternatives [][]bound
+}
+
+// Matches returns true if the given version satisfies the constraint
+func (c Constraint) Matches(version string) bool {
+	version = normalize(version)
+
+	for _, bounds := range c.alternatives {
+		matches := true
+		for _, b := range bounds {
+			if !b.matches(version) {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalize removes any "v" prefix from the version and pads it to four components if
+// it is only made up of numbers, in the same way as Composer, so that "1.2" and "1.2.0"
+// are considered to be the same version
+func normalize(version string) string {
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V")
+
+	if !isStable(version) {
+		return version
+	}
+
+	parts := strings.Split(version, ".")
+	for len(parts) < 4 {
+		parts = append(parts, "0")
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// isStable returns true if the version is only made up of numbers, as opposed
+// to being a pre-release (such as "1.0.0-beta1") or a development branch
+func isStable(version string) bool {
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V")
+
+	return cachedregexp.MustCompile(`^\d+(\.\d+)*$`).MatchString(version)
+}
+
+// numericParts returns the leading numeric components of the version
+func numericParts(version string) ([]int, error) {
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V")
+
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("%w: %q is not a version", ErrUnsupportedConstraint, version)
+	}
+
+	return parts, nil
+}
+
+// nextVersion returns the version after the given parts have been incremented at the last one,
+// such as "1.3" for [1, 2], which is the exclusive upper bound of versions starting with them
+func nextVersion(parts []int) string {
+	next := make([]string, len(parts))
+	for i, part := range parts {
+		next[i] = strconv.Itoa(part)
+	}
+	next[len(parts)-1] = strconv.Itoa(parts[len(parts)-1] + 1)
+
+	return normalize(strings.Join(next, "."))
+}
+
+// ParseConstraint parses a Composer version constraint such as "^1.2 || ~2.0.3", as described in
+// https://getcomposer.org/doc/articles/versions.md#writing-version-constraints
+//
+// Stability flags (such as "@dev") are ignored, and constraints on branches (such as "dev-main")
+// are not supported, as they do not refer to a version that is published on Packagist.
+func ParseConstraint(constraint string) (Constraint, error) {
+	constraint = cachedregexp.MustCompile(`@[a-zA-Z]+`).ReplaceAllString(constraint, "")
+
+	var c Constraint
+	for _, alternative := range cachedregexp.MustCompile(`\s*\|\|?\s*`).Split(strings.TrimSpace(constraint), -1) {
+		bounds, err := parseAlternative(alternative)
+		if err != nil {
+			return Constraint{}, err
+		}
+		c.alternatives = append(c.alternatives, bounds)
+	}
+
+	return c, nil
+}
+
+func parseAlternative(alternative string) ([]bound, error) {
+	if match := cachedregexp.MustCompile(`^(\S+)\s+-\s+(\S+)$`).FindStringSubmatch(alternative); match != nil {
+		return parseHyphenRange(match[1], match[2])
+	}
+
+	// allow a space between an operator and its version, such as ">= 1.0"
+	alternative = cachedregexp.MustCompile(`([<>=!~^])\s+`).ReplaceAllString(alternative, "$1")
+
+	var bounds []bound
+	for _, single := range cachedregexp.MustCompile(`\s*,\s*|\s+`).Split(alternative, -1) {
+		b, err := parseSingle(single)
+		if err != nil {
+			return nil, err
+		}
+		bounds = append(bounds, b...)
+	}
+
+	return bounds, nil
+}
+
+func parseHyphenRange(from string, to string) ([]bound, error) {
+	toParts, err := numericParts(to)
+	if err != nil {
+		return nil, err
+	}
+
+	// a partial upper bound such as "2.0" includes all the versions starting with it
+	upper := bound{"<=", normalize(to)}
+	if len(toParts) < 3 {
+		upper = bound{"<", nextVersion(toParts)}
+	}
+
+	return []bound{{">=", normalize(from)}, upper}, nil
+}
+
+func parseSingle(single string) ([]bound, error) {
+	switch {
+	case single == "*" || single == "x" || single == "":
+		return nil, nil
+	case strings.HasPrefix(single, "dev-") || strings.HasSuffix(single, "-dev"):
+		return nil, fmt.Errorf("%w: %q is a branch", ErrUnsupportedConstraint, single)
+	case strings.HasPrefix(single, "^"):
+		return parseCaret(single[1:])
+	case strings.HasPrefix(single, "~"):
+		return parseTilde(single[1:])
+	}
+
+	for _, op := range []string{">=", "<=", "<>", "!=", "==", ">", "<", "="} {
+		if version, ok := strings.CutPrefix(single, op); ok {
+			if op == "<>" {
+				op = "!="
+			}
+			if _, err := numericParts(version); err != nil {
+				return nil, err
+			}
+
+			return []bound{{op, normalize(version)}}, nil
+		}
+	}
+
+	if strings.HasSuffix(single, ".*") || strings.HasSuffix(single, ".x") {
+		prefix := single[:len(single)-2]
+		parts, err := numericParts(prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		return []bound{{">=", normalize(prefix)}, {"<", nextVersion(parts)}}, nil
+	}
+
+	if _, err := numericParts(single); err != nil {
+		return nil, err
+	}
+
+	return []bound{{"=", normalize(single)}}, nil
+}
+
+// parseCaret parses "^1.2.3" as ">=1.2.3 <2.0.0", with the upper bound being
+// the next version that changes the first non-zero component
+func parseCaret(version string) ([]bound, error) {
+	parts, err := numericParts(version)
+	if err != nil {
+		return nil, err
+	}
+
+	significant := len(parts) - 1
+	for i, part := range parts {
+		if part != 0 {
+			significant = i
+			break
+		}
+	}
+
+	return []bound{{">=", normalize(version)}, {"<", nextVersion(parts[:significant+1])}}, nil
+}
+
+// parseTilde parses "~1.2.3" as ">=1.2.3 <1.3.0", with the upper bound being
+// the next version that changes the second last component that is given
+func parseTilde(version string) ([]bound, error) {
+	parts, err := numericParts(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parts) > 1 {
+		parts = parts[:len(parts)-1]
+	}
+
+	return []bound{{">=", normalize(version)}, {"<", nextVersion(parts)}}, nil
+}
@@ -0,0 +1,70 @@
+package packagist_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/osv-scanner/internal/packagist"
+)
+
+func TestConstraint_Matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		matches    []string
+		notMatches []string
+	}{
+		{constraint: "1.2.3", matches: []string{"1.2.3", "v1.2.3"}, notMatches: []string{"1.2.4", "1.2"}},
+		{constraint: "1.2", matches: []string{"1.2", "1.2.0"}, notMatches: []string{"1.2.1"}},
+		{constraint: ">=1.0", matches: []string{"1.0.0", "5.0"}, notMatches: []string{"0.9.9"}},
+		{constraint: ">= 1.0 <1.5", matches: []string{"1.4.9"}, notMatches: []string{"1.5", "0.1"}},
+		{constraint: ">=1.0, <1.5", matches: []string{"1.4.9"}, notMatches: []string{"1.5.0"}},
+		{constraint: "!=1.2.3", matches: []string{"1.2.4"}, notMatches: []string{"1.2.3"}},
+		{constraint: "^1.2.3", matches: []string{"1.2.3", "1.9.0"}, notMatches: []string{"1.2.2", "2.0.0"}},
+		{constraint: "^0.3", matches: []string{"0.3.0", "0.3.9"}, notMatches: []string{"0.4.0", "0.2.9"}},
+		{constraint: "^0.0.3", matches: []string{"0.0.3"}, notMatches: []string{"0.0.4"}},
+		{constraint: "~1.2", matches: []string{"1.2.0", "1.9.9"}, notMatches: []string{"2.0.0", "1.1.0"}},
+		{constraint: "~1.2.3", matches: []string{"1.2.3", "1.2.9"}, notMatches: []string{"1.3.0"}},
+		{constraint: "1.2.*", matches: []string{"1.2.0", "1.2.99"}, notMatches: []string{"1.3.0", "1.1.9"}},
+		{constraint: "1.x", matches: []string{"1.0.0", "1.99"}, notMatches: []string{"2.0.0"}},
+		{constraint: "*", matches: []string{"0.0.1", "99.0"}},
+		{constraint: "1.0 - 2.0", matches: []string{"1.0.0", "2.0.9"}, notMatches: []string{"2.1.0", "0.9"}},
+		{constraint: "1.0.0 - 2.1.0", matches: []string{"2.1.0"}, notMatches: []string{"2.1.1"}},
+		{constraint: "^1.0 || ^2.0", matches: []string{"1.5.0", "2.5.0"}, notMatches: []string{"3.0.0"}},
+		{constraint: "^1.0 | ^2.0", matches: []string{"2.5.0"}, notMatches: []string{"0.5.0"}},
+		{constraint: "^2.0@dev", matches: []string{"2.1.0"}, notMatches: []string{"3.0.0"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.constraint, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := packagist.ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) unexpected error: %v", tt.constraint, err)
+			}
+
+			for _, v := range tt.matches {
+				if !c.Matches(v) {
+					t.Errorf("ParseConstraint(%q).Matches(%q) = false, want true", tt.constraint, v)
+				}
+			}
+			for _, v := range tt.notMatches {
+				if c.Matches(v) {
+					t.Errorf("ParseConstraint(%q).Matches(%q) = true, want false", tt.constraint, v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseConstraint_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	for _, constraint := range []string{"dev-main", "1.0.x-dev", "^latest", ">=abc"} {
+		if _, err := packagist.ParseConstraint(constraint); !errors.Is(err, packagist.ErrUnsupportedConstraint) {
+			t.Errorf("ParseConstraint(%q) error = %v, want %v", constraint, err, packagist.ErrUnsupportedConstraint)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package sbom
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scanner/internal/cachedregexp"
 )
 
 type CycloneDX struct{}
@@ -20,11 +22,11 @@ type cyclonedxType struct {
 var (
 	cycloneDXTypes = []cyclonedxType{
 		{
-			name:    "json",
+			name:    "JSON",
 			bomType: cyclonedx.BOMFileFormatJSON,
 		},
 		{
-			name:    "xml",
+			name:    "XML",
 			bomType: cyclonedx.BOMFileFormatXML,
 		},
 	}
@@ -58,6 +60,11 @@ func (c *CycloneDX) MatchesRecognizedFileNames(path string) bool {
 	return false
 }
 
+func (c *CycloneDX) MatchesContent(header []byte) bool {
+	return cachedregexp.MustCompile(`"bomFormat"\s*:\s*"CycloneDX"`).Match(header) ||
+		bytes.Contains(header, []byte("http://cyclonedx.org/schema/bom"))
+}
+
 func (c *CycloneDX) enumerateComponents(components []cyclonedx.Component, callback func(Identifier) error) error {
 	for _, component := range components {
 		if component.PackageURL != "" {
@@ -88,7 +95,7 @@ func (c *CycloneDX) enumeratePackages(bom *cyclonedx.BOM, callback func(Identifi
 	return c.enumerateComponents(*bom.Components, callback)
 }
 
-func (c *CycloneDX) GetPackages(r io.ReadSeeker, callback func(Identifier) error) error {
+func (c *CycloneDX) GetPackages(r io.ReadSeeker, callback func(Identifier) error) (string, error) {
 	//nolint:prealloc // Not sure how many there will be in advance.
 	var errs []error
 	var bom cyclonedx.BOM
@@ -96,13 +103,13 @@ func (c *CycloneDX) GetPackages(r io.ReadSeeker, callback func(Identifier) error
 	for _, formatType := range cycloneDXTypes {
 		_, err := r.Seek(0, io.SeekStart)
 		if err != nil {
-			return fmt.Errorf("failed to seek to start of file: %w", err)
+			return "", fmt.Errorf("failed to seek to start of file: %w", err)
 		}
 		decoder := cyclonedx.NewBOMDecoder(r, formatType.bomType)
 		err = decoder.Decode(&bom)
 		if err == nil {
 			if bom.BOMFormat == "CycloneDX" || strings.HasPrefix(bom.XMLNS, "http://cyclonedx.org/schema/bom") {
-				return c.enumeratePackages(&bom, callback)
+				return formatType.name, c.enumeratePackages(&bom, callback)
 			} else {
 				err = errors.New("invalid BOMFormat")
 			}
@@ -111,7 +118,7 @@ func (c *CycloneDX) GetPackages(r io.ReadSeeker, callback func(Identifier) error
 		errs = append(errs, fmt.Errorf("failed trying %s: %w", formatType.name, err))
 	}
 
-	return InvalidFormatError{
+	return "", InvalidFormatError{
 		Msg:  "failed to parse CycloneDX",
 		Errs: errs,
 	}
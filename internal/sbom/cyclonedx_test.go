@@ -25,7 +25,7 @@ func runCycloneGetPackages(t *testing.T, bomFile string, want []sbom.Identifier)
 	}
 
 	cdx := &sbom.CycloneDX{}
-	err = cdx.GetPackages(f, callback)
+	_, err = cdx.GetPackages(f, callback)
 	if err != nil {
 		t.Errorf("GetPackages returned an error: %v", err)
 	}
@@ -3,6 +3,8 @@ package sbom
 import (
 	"fmt"
 	"io"
+	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -16,7 +18,31 @@ type Reader interface {
 	Name() string
 	// MatchesRecognizedFileNames checks if the file path is a standard recognized file name
 	MatchesRecognizedFileNames(path string) bool
-	GetPackages(r io.ReadSeeker, callback func(Identifier) error) error
+	// MatchesContent checks if the start of a file looks like an SBOM of this provider,
+	// which is used to identify files whose names are ambiguous
+	MatchesContent(header []byte) bool
+	// GetPackages calls the callback with each package in the SBOM, returning
+	// the name of the format (such as "JSON") that it was successfully parsed as
+	GetPackages(r io.ReadSeeker, callback func(Identifier) error) (string, error)
+}
+
+// headerSize is the number of bytes at the start of a file that are read to identify its content
+const headerSize = 16 * 1024
+
+// ambiguousExtensions are the extensions of files that could be an SBOM of any provider
+var ambiguousExtensions = []string{"", ".json", ".xml", ".rdf", ".tv", ".txt", ".sbom"}
+
+// IsAmbiguousFileName checks if the file path looks like it could be an SBOM, without its name indicating
+// which format it is in (such as "sbom.json"), meaning its content needs to be probed to identify it
+func IsAmbiguousFileName(path string) bool {
+	name := strings.ToLower(filepath.Base(path))
+
+	return strings.Contains(name, "bom") && slices.Contains(ambiguousExtensions, filepath.Ext(name))
+}
+
+// ReadHeader reads the start of the file for probing its content with Reader.MatchesContent
+func ReadHeader(r io.Reader) ([]byte, error) {
+	return io.ReadAll(io.LimitReader(r, headerSize))
 }
 
 var (
@@ -0,0 +1,70 @@
+package sbom_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/internal/sbom"
+)
+
+func TestIsAmbiguousFileName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "sbom.json", want: true},
+		{path: "path/to/my-app.SBOM.xml", want: true},
+		{path: "bom.txt", want: true},
+		{path: "project.sbom", want: true},
+		{path: "sbom", want: true},
+		{path: "package.json", want: false},
+		{path: "sbom.go", want: false},
+		{path: "pom.xml", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := sbom.IsAmbiguousFileName(tt.path); got != tt.want {
+			t.Errorf("IsAmbiguousFileName(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesContent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		bomFile       string
+		wantSPDX      bool
+		wantCycloneDX bool
+	}{
+		{bomFile: "spdx.json", wantSPDX: true},
+		{bomFile: "spdx.tv", wantSPDX: true},
+		{bomFile: "cyclonedx.json", wantCycloneDX: true},
+		{bomFile: "cyclonedx-empty.json", wantCycloneDX: true},
+		{bomFile: "not-an-sbom.json"},
+	}
+
+	for _, tt := range tests {
+		f, err := os.Open(filepath.Join("fixtures", tt.bomFile))
+		if err != nil {
+			t.Fatalf("Failed to read fixture file: %v", err)
+		}
+		defer f.Close()
+
+		header, err := sbom.ReadHeader(f)
+		if err != nil {
+			t.Fatalf("Failed to read header of fixture file: %v", err)
+		}
+
+		if got := (&sbom.SPDX{}).MatchesContent(header); got != tt.wantSPDX {
+			t.Errorf("SPDX.MatchesContent(%s) = %v, want %v", tt.bomFile, got, tt.wantSPDX)
+		}
+
+		if got := (&sbom.CycloneDX{}).MatchesContent(header); got != tt.wantCycloneDX {
+			t.Errorf("CycloneDX.MatchesContent(%s) = %v, want %v", tt.bomFile, got, tt.wantCycloneDX)
+		}
+	}
+}
@@ -2,11 +2,13 @@
 package sbom
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"path/filepath"
 	"strings"
 
+	"github.com/google/osv-scanner/internal/cachedregexp"
 	spdx_json "github.com/spdx/tools-golang/json"
 	"github.com/spdx/tools-golang/rdf"
 	"github.com/spdx/tools-golang/spdx/v2/v2_3"
@@ -24,15 +26,15 @@ type loader struct {
 var (
 	spdxLoaders = []loader{
 		{
-			name:   "json",
+			name:   "JSON",
 			loader: spdx_json.Read,
 		},
 		{
-			name:   "rdf",
+			name:   "RDF",
 			loader: rdf.Read,
 		},
 		{
-			name:   "tv",
+			name:   "tag-value",
 			loader: tagvalue.Read,
 		},
 	}
@@ -48,6 +50,12 @@ func (s *SPDX) MatchesRecognizedFileNames(path string) bool {
 	return strings.Contains(strings.ToLower(filepath.Base(path)), ".spdx")
 }
 
+func (s *SPDX) MatchesContent(header []byte) bool {
+	return cachedregexp.MustCompile(`"spdxVersion"\s*:`).Match(header) ||
+		cachedregexp.MustCompile(`(?m)^SPDXVersion:`).Match(header) ||
+		bytes.Contains(header, []byte("spdx.org/rdf/terms"))
+}
+
 func (s *SPDX) enumeratePackages(doc *v2_3.Document, callback func(Identifier) error) error {
 	for _, p := range doc.Packages {
 		for _, r := range p.PackageExternalReferences {
@@ -65,22 +73,22 @@ func (s *SPDX) enumeratePackages(doc *v2_3.Document, callback func(Identifier) e
 	return nil
 }
 
-func (s *SPDX) GetPackages(r io.ReadSeeker, callback func(Identifier) error) error {
+func (s *SPDX) GetPackages(r io.ReadSeeker, callback func(Identifier) error) (string, error) {
 	//nolint:prealloc // Not sure how many there will be in advance.
 	var errs []error
 	for _, loader := range spdxLoaders {
 		_, err := r.Seek(0, io.SeekStart)
 		if err != nil {
-			return fmt.Errorf("failed to seek to start of file: %w", err)
+			return "", fmt.Errorf("failed to seek to start of file: %w", err)
 		}
 		doc, err := loader.loader(r)
 		if err == nil {
-			return s.enumeratePackages(doc, callback)
+			return loader.name, s.enumeratePackages(doc, callback)
 		}
 		errs = append(errs, fmt.Errorf("failed trying %s: %w", loader.name, err))
 	}
 
-	return InvalidFormatError{
+	return "", InvalidFormatError{
 		Msg:  "failed to parse SPDX",
 		Errs: errs,
 	}
@@ -0,0 +1,55 @@
+package sbom_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/internal/sbom"
+)
+
+func TestSPDXGetPackages(t *testing.T) {
+	t.Parallel()
+
+	want := []sbom.Identifier{
+		{PURL: "pkg:maven/org.hdrhistogram/HdrHistogram@2.1.12"},
+		{PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.16.0"},
+	}
+
+	tests := []struct {
+		bomFile    string
+		wantFormat string
+	}{
+		{bomFile: "spdx.json", wantFormat: "JSON"},
+		{bomFile: "spdx.tv", wantFormat: "tag-value"},
+	}
+
+	for _, tt := range tests {
+		f, err := os.Open(filepath.Join("fixtures", tt.bomFile))
+		if err != nil {
+			t.Fatalf("Failed to read fixture file: %v", err)
+		}
+		defer f.Close()
+
+		got := []sbom.Identifier{}
+		callback := func(id sbom.Identifier) error {
+			got = append(got, id)
+			return nil
+		}
+
+		spdx := &sbom.SPDX{}
+		format, err := spdx.GetPackages(f, callback)
+		if err != nil {
+			t.Errorf("GetPackages(%s) returned an error: %v", tt.bomFile, err)
+		}
+
+		if format != tt.wantFormat {
+			t.Errorf("GetPackages(%s) parsed the SBOM as %s, want %s", tt.bomFile, format, tt.wantFormat)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("GetPackages(%s) returned an unexpected result (-want, +got):\n%s", tt.bomFile, diff)
+		}
+	}
+}
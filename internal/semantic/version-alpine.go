@@ -80,6 +80,10 @@ func weightAlpineSuffixString(suffixStr string) int {
 //
 // Finally, an optional package build component *-r{number}* can follow.
 //
+// apk itself does not have epochs, but versions from other sources (such as SBOMs)
+// are sometimes prefixed with one as *{epoch:}*, so an optional epoch is also supported
+// which is compared before anything else, with versions without an epoch having an epoch of 0.
+//
 // Also see https://github.com/alpinelinux/apk-tools/blob/master/doc/apk-package.5.scd#package-info-metadata
 type AlpineVersion struct {
 	// the original string that was parsed
@@ -88,6 +92,8 @@ type AlpineVersion struct {
 	invalid bool
 	// the remainder of the string after parsing has been completed
 	remainder string
+	// optional epoch, prefixed to the version with a ":"
+	epoch *big.Int
 	// slice of number components which can be compared in a semver-like manner
 	components alpineNumberComponents
 	// optional single lower-case letter
@@ -190,6 +196,9 @@ func (v AlpineVersion) Compare(w AlpineVersion) int {
 	}
 
 	// note: commit hashes are ignored as we can't properly compare them
+	if diff := v.epoch.Cmp(w.epoch); diff != 0 {
+		return diff
+	}
 	if diff := v.compareComponents(w); diff != 0 {
 		return diff
 	}
@@ -213,13 +222,31 @@ func (v AlpineVersion) CompareStr(str string) int {
 	return v.Compare(parseAlpineVersion(str))
 }
 
+// parseAlpineEpoch parses the given string into AlpineVersion.epoch and then
+// returns the remainder of the string for continued parsing.
+//
+// The epoch is optional, and is a number followed by a ":".
+//
+// This parser must be applied *before* any other parser.
+func parseAlpineEpoch(v *AlpineVersion, str string) string {
+	matches := cachedregexp.MustCompile(`^(\d+):`).FindStringSubmatch(str)
+
+	if matches == nil {
+		return str
+	}
+
+	v.epoch = convertToBigIntOrPanic(matches[1])
+
+	return strings.TrimPrefix(str, matches[0])
+}
+
 // parseAlpineNumberComponents parses the given string into AlpineVersion.components
 // and then returns the remainder of the string for continued parsing.
 //
 // Each number component is a sequence of digits (0-9), separated with a ".",
 // and with no limit on the value or amount of number components.
 //
-// This parser must be applied *before* any other parser.
+// This parser must be applied *after* parseAlpineEpoch.
 func parseAlpineNumberComponents(v *AlpineVersion, str string) string {
 	sub := cachedregexp.MustCompile(`^((\d+)\.?)*`).FindString(str)
 
@@ -323,8 +350,9 @@ func parseAlpineBuildComponent(v *AlpineVersion, str string) string {
 }
 
 func parseAlpineVersion(str string) AlpineVersion {
-	v := AlpineVersion{original: str, buildComponent: new(big.Int)}
+	v := AlpineVersion{original: str, epoch: new(big.Int), buildComponent: new(big.Int)}
 
+	str = parseAlpineEpoch(&v, str)
 	str = parseAlpineNumberComponents(&v, str)
 	str = parseAlpineLetter(&v, str)
 	str = parseAlpineSuffixes(&v, str)
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/osv-scanner/internal/sourceanalysis/govulncheck"
 	"github.com/google/osv-scanner/internal/url"
@@ -17,7 +18,7 @@ import (
 	"golang.org/x/vuln/scan"
 )
 
-func goAnalysis(r reporter.Reporter, pkgs []models.PackageVulns, source models.SourceInfo) {
+func goAnalysis(r reporter.Reporter, pkgs []models.PackageVulns, source models.SourceInfo, showCallPaths bool) {
 	cmd := exec.Command("go", "version")
 	_, err := cmd.Output()
 	if err != nil {
@@ -54,17 +55,22 @@ func goAnalysis(r reporter.Reporter, pkgs []models.PackageVulns, source models.S
 
 		return
 	}
-	matchAnalysisWithPackageVulns(pkgs, res, vulnsByID)
+	matchAnalysisWithPackageVulns(pkgs, res, vulnsByID, showCallPaths)
 }
 
-func matchAnalysisWithPackageVulns(pkgs []models.PackageVulns, idToFindings map[string][]*govulncheck.Finding, vulnsByID map[string]models.Vulnerability) {
+func matchAnalysisWithPackageVulns(pkgs []models.PackageVulns, idToFindings map[string][]*govulncheck.Finding, vulnsByID map[string]models.Vulnerability, showCallPaths bool) {
 	idToModuleToCalled := map[string]map[string]bool{}
+	idToModuleToEvidence := map[string]map[string][]models.AnalysisEvidence{}
 	for id, findings := range idToFindings {
 		idToModuleToCalled[id] = map[string]bool{}
+		idToModuleToEvidence[id] = map[string][]models.AnalysisEvidence{}
 		for _, f := range findings {
 			modulePath := f.Trace[0].Module
 			called := f.Trace[0].Function != ""
 			idToModuleToCalled[f.OSV][modulePath] = called
+			if called {
+				idToModuleToEvidence[f.OSV][modulePath] = append(idToModuleToEvidence[f.OSV][modulePath], evidenceFromTrace(f.Trace, showCallPaths))
+			}
 		}
 	}
 
@@ -89,14 +95,61 @@ func matchAnalysisWithPackageVulns(pkgs []models.PackageVulns, idToFindings map[
 					continue
 				}
 
-				(*analysis)[vulnID] = models.AnalysisInfo{
-					Called: moduleToCalled[pv.Package.Name],
+				called := moduleToCalled[pv.Package.Name]
+				info := models.AnalysisInfo{
+					Called: called,
+				}
+				if called {
+					info.Evidence = idToModuleToEvidence[vulnID][pv.Package.Name]
 				}
+				(*analysis)[vulnID] = info
 			}
 		}
 	}
 }
 
+// frameSymbol returns the fully qualified name of the function in the frame,
+// such as "github.com/gogo/protobuf/proto.RegisterEnum" or "net/http.Client.Do"
+func frameSymbol(frame *govulncheck.Frame) string {
+	symbol := frame.Function
+	if frame.Receiver != "" {
+		symbol = strings.TrimPrefix(frame.Receiver, "*") + "." + symbol
+	}
+	if frame.Package != "" {
+		symbol = frame.Package + "." + symbol
+	}
+
+	return symbol
+}
+
+// evidenceFromTrace describes the call to the vulnerable symbol at the start of a govulncheck trace,
+// which ends with the function in the scanned code that makes the call (possibly indirectly)
+//
+// govulncheck only reports one representative trace for each finding, which is
+// used as the call path when withCallPath is true
+func evidenceFromTrace(trace []*govulncheck.Frame, withCallPath bool) models.AnalysisEvidence {
+	evidence := models.AnalysisEvidence{
+		Symbol: frameSymbol(trace[0]),
+	}
+
+	if len(trace) > 1 {
+		caller := trace[len(trace)-1]
+		evidence.Caller = frameSymbol(caller)
+		if caller.Position != nil && caller.Position.Line > 0 {
+			evidence.File = caller.Position.Filename
+			evidence.Line = caller.Position.Line
+		}
+
+		if withCallPath {
+			for i := len(trace) - 1; i >= 0; i-- {
+				evidence.CallPath = append(evidence.CallPath, frameSymbol(trace[i]))
+			}
+		}
+	}
+
+	return evidence
+}
+
 func vulnHasImportsField(vuln models.Vulnerability, pkg *models.PackageInfo) bool {
 	for _, affected := range vuln.Affected {
 		if pkg != nil {
@@ -15,7 +15,7 @@ func Test_matchAnalysisWithPackageVulns(t *testing.T) {
 	gvcResByVulnID := testutility.LoadJSONFixture[map[string][]*govulncheck.Finding](t, "fixtures-go/govulncheckinput.json")
 	vulnsByID := testutility.LoadJSONFixture[map[string]models.Vulnerability](t, "fixtures-go/vulnbyid.json")
 
-	matchAnalysisWithPackageVulns(pkgs, gvcResByVulnID, vulnsByID)
+	matchAnalysisWithPackageVulns(pkgs, gvcResByVulnID, vulnsByID, false)
 
 	testutility.NewSnapshot().MatchJSON(t, pkgs)
 }
@@ -28,7 +28,19 @@ func Test_matchEmptyAnalysisWithPackageVulns(t *testing.T) {
 	gvcResByVulnID := map[string][]*govulncheck.Finding{}
 	vulnsByID := testutility.LoadJSONFixture[map[string]models.Vulnerability](t, "fixtures-go/vulnbyid-no-call-data.json")
 
-	matchAnalysisWithPackageVulns(pkgs, gvcResByVulnID, vulnsByID)
+	matchAnalysisWithPackageVulns(pkgs, gvcResByVulnID, vulnsByID, false)
+
+	testutility.NewSnapshot().MatchJSON(t, pkgs)
+}
+
+func Test_matchAnalysisWithPackageVulns_ShowCallPaths(t *testing.T) {
+	t.Parallel()
+
+	pkgs := testutility.LoadJSONFixture[[]models.PackageVulns](t, "fixtures-go/input.json")
+	gvcResByVulnID := testutility.LoadJSONFixture[map[string][]*govulncheck.Finding](t, "fixtures-go/govulncheckinput.json")
+	vulnsByID := testutility.LoadJSONFixture[map[string]models.Vulnerability](t, "fixtures-go/vulnbyid.json")
+
+	matchAnalysisWithPackageVulns(pkgs, gvcResByVulnID, vulnsByID, true)
 
 	testutility.NewSnapshot().MatchJSON(t, pkgs)
 }
@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/google/osv-scanner/internal/cachedregexp"
@@ -43,6 +44,7 @@ func rustAnalysis(r reporter.Reporter, pkgs []models.PackageVulns, source models
 	// - There is function level vuln info, and it **is** called    (true)
 	// - There is **no** functional level vuln info, so we don't know whether it is called (doesn't exist)
 	isCalledVulnMap := map[string]bool{}
+	calledFuncsVulnMap := map[string][]string{}
 
 	for _, path := range binaryPaths {
 		var readAt io.ReaderAt
@@ -97,6 +99,9 @@ func rustAnalysis(r reporter.Reporter, pkgs []models.PackageVulns, source models
 							_, called := calls[funcName]
 							// Once one advisory marks this vuln as called, always mark as called
 							isCalledVulnMap[v.ID] = isCalledVulnMap[v.ID] || called
+							if called && !slices.Contains(calledFuncsVulnMap[v.ID], funcName) {
+								calledFuncsVulnMap[v.ID] = append(calledFuncsVulnMap[v.ID], funcName)
+							}
 						}
 					}
 				}
@@ -113,9 +118,15 @@ func rustAnalysis(r reporter.Reporter, pkgs []models.PackageVulns, source models
 
 					called, hasFuncInfo := isCalledVulnMap[vulnID]
 					if hasFuncInfo {
-						(*analysis)[vulnID] = models.AnalysisInfo{
+						info := models.AnalysisInfo{
 							Called: called,
 						}
+						// The debug symbols only identify the vulnerable functions
+						// included in the binary, and not where they are called from
+						for _, funcName := range calledFuncsVulnMap[vulnID] {
+							info.Evidence = append(info.Evidence, models.AnalysisEvidence{Symbol: funcName})
+						}
+						(*analysis)[vulnID] = info
 					}
 				}
 			}
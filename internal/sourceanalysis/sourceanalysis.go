@@ -24,11 +24,12 @@ func vulnsFromAllPkgs(pkgs []models.PackageVulns) ([]models.Vulnerability, map[s
 	return vulns, flatVulns
 }
 
-// Run runs the language specific analyzers on the code given packages and source info
-func Run(r reporter.Reporter, source models.SourceInfo, pkgs []models.PackageVulns, callAnalysis map[string]bool) {
+// Run runs the language specific analyzers on the code given packages and source info,
+// recording the call path to each called vulnerable symbol if showCallPaths is true
+func Run(r reporter.Reporter, source models.SourceInfo, pkgs []models.PackageVulns, callAnalysis map[string]bool, showCallPaths bool) {
 	// GoVulnCheck
 	if source.Type == "lockfile" && filepath.Base(source.Path) == "go.mod" && callAnalysis["go"] {
-		goAnalysis(r, pkgs, source)
+		goAnalysis(r, pkgs, source, showCallPaths)
 	}
 
 	if source.Type == "lockfile" && filepath.Base(source.Path) == "Cargo.lock" && callAnalysis["rust"] {
@@ -71,3 +71,24 @@ func CalculateOverallScore(severities []models.Severity) (float64, string, error
 
 	return maxScore, maxRating, nil
 }
+
+// CalculatePreferredScore calculates the score of the severities of the preferred type,
+// falling back to the overall score if there are none of that type or no type is preferred
+func CalculatePreferredScore(severities []models.Severity, preferred models.SeverityType) (float64, string, error) {
+	if preferred == "" {
+		return CalculateOverallScore(severities)
+	}
+
+	var preferredSeverities []models.Severity
+	for _, severity := range severities {
+		if severity.Type == preferred {
+			preferredSeverities = append(preferredSeverities, severity)
+		}
+	}
+
+	if len(preferredSeverities) == 0 {
+		return CalculateOverallScore(severities)
+	}
+
+	return CalculateOverallScore(preferredSeverities)
+}
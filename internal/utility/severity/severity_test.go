@@ -91,3 +91,63 @@ func TestSeverity_CalculateScore(t *testing.T) {
 		})
 	}
 }
+
+func TestSeverity_CalculatePreferredScore(t *testing.T) {
+	t.Parallel()
+
+	v2 := models.Severity{
+		Type:  models.SeverityCVSSV2,
+		Score: "AV:L/AC:M/Au:N/C:N/I:P/A:C/E:H/RL:U/RC:C/CDP:LM/TD:M/CR:L/IR:M/AR:H",
+	}
+	v3 := models.Severity{
+		Type:  models.SeverityCVSSV3,
+		Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H/E:U/RL:T/RC:U/CR:L/IR:L/AR:H/MAV:P/MAC:H/MPR:H/MUI:R/MS:C/MC:H/MI:H/MA:H",
+	}
+
+	tests := []struct {
+		name       string
+		severities []models.Severity
+		preferred  models.SeverityType
+		want       float64
+	}{
+		{
+			name:       "No preference uses the highest score",
+			severities: []models.Severity{v2, v3},
+			preferred:  "",
+			want:       10.0,
+		},
+		{
+			name:       "Preferred version is used when present",
+			severities: []models.Severity{v2, v3},
+			preferred:  models.SeverityCVSSV2,
+			want:       5.4,
+		},
+		{
+			name:       "Falls back to the highest score when preferred version is absent",
+			severities: []models.Severity{v2, v3},
+			preferred:  models.SeverityCVSSV4,
+			want:       10.0,
+		},
+		{
+			name:       "No severities",
+			severities: []models.Severity{},
+			preferred:  models.SeverityCVSSV3,
+			want:       -1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, _, err := severity.CalculatePreferredScore(tt.severities, tt.preferred)
+			if err != nil {
+				t.Errorf("CalculatePreferredScore() error: %v", err)
+			}
+			if math.Round(10*got) != math.Round(10*tt.want) {
+				t.Errorf("CalculatePreferredScore() = %.1f, want %.1f", got, tt.want)
+			}
+		})
+	}
+}
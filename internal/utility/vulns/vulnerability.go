@@ -108,9 +108,25 @@ func isAliasOf(v models.Vulnerability, vulnerability models.Vulnerability) bool
 	return false
 }
 
+// matchesEcosystem returns true if the ecosystem of an affected package matches the ecosystem
+// of a package, where a package without a release suffix (such as "Debian") matches every
+// release of its ecosystem (such as "Debian:11") as its release is not known
+func matchesEcosystem(affected models.Ecosystem, ecosystem lockfile.Ecosystem) bool {
+	if string(affected) == string(ecosystem) {
+		return true
+	}
+
+	base := models.PackageInfo{Ecosystem: string(ecosystem)}.BaseEcosystem()
+	if string(base) != string(ecosystem) {
+		return false
+	}
+
+	return models.PackageInfo{Ecosystem: string(affected)}.BaseEcosystem() == base
+}
+
 func AffectsEcosystem(v models.Vulnerability, ecosystem lockfile.Ecosystem) bool {
 	for _, affected := range v.Affected {
-		if string(affected.Package.Ecosystem) == string(ecosystem) {
+		if matchesEcosystem(affected.Package.Ecosystem, ecosystem) {
 			return true
 		}
 	}
@@ -120,7 +136,7 @@ func AffectsEcosystem(v models.Vulnerability, ecosystem lockfile.Ecosystem) bool
 
 func IsAffected(v models.Vulnerability, pkg lockfile.PackageDetails) bool {
 	for _, affected := range v.Affected {
-		if string(affected.Package.Ecosystem) == string(pkg.Ecosystem) &&
+		if matchesEcosystem(affected.Package.Ecosystem, pkg.Ecosystem) &&
 			affected.Package.Name == pkg.Name {
 			if len(affected.Ranges) == 0 && len(affected.Versions) == 0 {
 				_, _ = fmt.Fprintf(
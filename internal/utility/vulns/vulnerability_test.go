@@ -84,6 +84,48 @@ func TestOSV_AffectsEcosystem(t *testing.T) {
 			Ecosystem: "npm",
 			Expected:  true,
 		},
+		{
+			Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: "Debian:11"}},
+			},
+			Ecosystem: "Debian",
+			Expected:  true,
+		},
+		{
+			Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: "Debian:11"}},
+			},
+			Ecosystem: "Debian:12",
+			Expected:  false,
+		},
+		{
+			Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: "Alpine"}},
+			},
+			Ecosystem: "Alpine:v3.16",
+			Expected:  false,
+		},
+		{
+			Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: "Ubuntu:22.04:LTS"}},
+			},
+			Ecosystem: "Ubuntu",
+			Expected:  true,
+		},
+		{
+			Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: "Ubuntu:22.04:LTS"}},
+			},
+			Ecosystem: "Ubuntu:22.04:LTS",
+			Expected:  true,
+		},
+		{
+			Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: "Debian:11"}},
+			},
+			Ecosystem: "Ubuntu",
+			Expected:  false,
+		},
 	}
 
 	for i, test := range tests {
@@ -704,3 +746,91 @@ func TestOSV_IsAffected_OnlyVersions(t *testing.T) {
 	// an empty version should always be treated as affected
 	expectIsAffected(t, vuln, "", true)
 }
+
+func TestOSV_IsAffected_AffectsWithEcosystem_Alpine(t *testing.T) {
+	t.Parallel()
+
+	vuln := buildOSVWithAffected(
+		models.Affected{
+			Package: models.Package{Ecosystem: "Alpine:v3.18", Name: "openssl"},
+			Ranges: []models.Range{
+				buildEcosystemAffectsRange(
+					models.Event{Introduced: "0"},
+					models.Event{Fixed: "3.1.4-r10"},
+				),
+			},
+		},
+	)
+
+	expect := func(version string, expectAffected bool) {
+		t.Helper()
+
+		pkg := lockfile.PackageDetails{
+			Name:      "openssl",
+			Version:   version,
+			Ecosystem: "Alpine:v3.18",
+			CompareAs: lockfile.AlpineEcosystem,
+		}
+
+		if vulns.IsAffected(vuln, pkg) != expectAffected {
+			if expectAffected {
+				t.Errorf("Expected OSV to affect package version %s but it did not", version)
+			} else {
+				t.Errorf("Expected OSV not to affect package version %s but it did", version)
+			}
+		}
+	}
+
+	// builds are compared as numbers rather than strings
+	for _, v := range []string{"3.1.4-r1", "3.1.4-r2", "3.1.4-r9", "3.1.4_rc1-r20", "3.1.3-r99"} {
+		expect(v, true)
+	}
+
+	for _, v := range []string{"3.1.4-r10", "3.1.4-r11", "3.1.4-r100", "3.1.4_p1-r0", "3.1.5-r0", "1:3.1.4-r1"} {
+		expect(v, false)
+	}
+}
+
+func TestOSV_IsAffected_AffectsWithEcosystem_Debian(t *testing.T) {
+	t.Parallel()
+
+	vuln := buildOSVWithAffected(
+		models.Affected{
+			Package: models.Package{Ecosystem: "Debian:9", Name: "libxml2"},
+			Ranges: []models.Range{
+				buildEcosystemAffectsRange(
+					models.Event{Introduced: "0"},
+					models.Event{Fixed: "2.9.4+dfsg1-2.2+deb9u7"},
+				),
+			},
+		},
+	)
+
+	expect := func(version string, expectAffected bool) {
+		t.Helper()
+
+		pkg := lockfile.PackageDetails{
+			Name:      "libxml2",
+			Version:   version,
+			Ecosystem: "Debian:9",
+			CompareAs: lockfile.DebianEcosystem,
+		}
+
+		if vulns.IsAffected(vuln, pkg) != expectAffected {
+			if expectAffected {
+				t.Errorf("Expected OSV to affect package version %s but it did not", version)
+			} else {
+				t.Errorf("Expected OSV not to affect package version %s but it did", version)
+			}
+		}
+	}
+
+	for _, v := range []string{"2.9.4+dfsg1-2.2", "2.9.4+dfsg1-2.2+deb9u6", "2.9.4+dfsg1-2.2~bpo8+1", "2.9.4+dfsg1-2.2+deb9u7~rc1"} {
+		expect(v, true)
+	}
+
+	// security updates are compared as numbers rather than strings
+	for _, v := range []string{"2.9.4+dfsg1-2.2+deb9u7", "2.9.4+dfsg1-2.2+deb9u10", "2.9.4+dfsg1-2.3", "1:2.9.4+dfsg1-2.2"} {
+		expect(v, false)
+	}
+}
@@ -2,3 +2,9 @@ package version
 
 // OSVVersion is the current release version, you should update this variable when doing a release
 var OSVVersion = "1.7.4"
+
+// Commit is the commit that osv-scanner was built from, which is set when doing a release
+var Commit = "n/a"
+
+// BuildDate is the date of the commit that osv-scanner was built from, which is set when doing a release
+var BuildDate = "n/a"
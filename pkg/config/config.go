@@ -2,12 +2,14 @@ package config
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"slices"
 	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/google/osv-scanner/internal/cachedregexp"
 	"github.com/google/osv-scanner/pkg/reporter"
 )
 
@@ -23,15 +25,22 @@ type ConfigManager struct {
 }
 
 type Config struct {
-	IgnoredVulns      []IgnoreEntry `toml:"IgnoredVulns"`
-	LoadPath          string        `toml:"LoadPath"`
-	GoVersionOverride string        `toml:"GoVersionOverride"`
+	IgnoredVulns      []IgnoreEntry `toml:"IgnoredVulns,omitempty"`
+	LoadPath          string        `toml:"LoadPath,omitempty"`
+	GoVersionOverride string        `toml:"GoVersionOverride,omitempty"`
+	// ExcludePaths are globs of paths to skip when scanning the directory containing the config
+	ExcludePaths []string `toml:"ExcludePaths,omitempty"`
 }
 
 type IgnoreEntry struct {
 	ID          string    `toml:"id"`
-	IgnoreUntil time.Time `toml:"ignoreUntil"`
-	Reason      string    `toml:"reason"`
+	IgnoreUntil time.Time `toml:"ignoreUntil,omitempty"`
+	Reason      string    `toml:"reason,omitempty"`
+}
+
+// Write writes the config as TOML, in the same format it is loaded from
+func (c Config) Write(w io.Writer) error {
+	return toml.NewEncoder(w).Encode(c)
 }
 
 func (c *Config) ShouldIgnore(vulnID string) (bool, IgnoreEntry) {
@@ -94,6 +103,49 @@ func (c *ConfigManager) Get(r reporter.Reporter, targetPath string) Config {
 	return config
 }
 
+// ValidateFile checks that the config file at configPath can be loaded and that each of
+// its entries is valid, returning an error describing each problem that was found
+func ValidateFile(configPath string) []error {
+	var config Config
+	md, err := toml.DecodeFile(configPath, &config)
+	if err != nil {
+		return []error{fmt.Errorf("failed to parse config file: %w", err)}
+	}
+
+	var errs []error
+	for _, key := range md.Undecoded() {
+		errs = append(errs, fmt.Errorf("unknown key %q", key.String()))
+	}
+
+	return append(errs, config.Validate(time.Now())...)
+}
+
+// Validate checks that each entry of the config is valid as of now, returning an error describing
+// each problem that was found, such as malformed vulnerability IDs or ignores that have expired
+func (c Config) Validate(now time.Time) []error {
+	var errs []error
+	idRe := cachedregexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(-[A-Za-z0-9._:]+)+$`)
+	seen := map[string]bool{}
+
+	for i, entry := range c.IgnoredVulns {
+		switch {
+		case entry.ID == "":
+			errs = append(errs, fmt.Errorf("IgnoredVulns[%d]: id is missing", i))
+		case !idRe.MatchString(entry.ID):
+			errs = append(errs, fmt.Errorf("IgnoredVulns[%d]: %q is not a well-formed vulnerability id", i, entry.ID))
+		case seen[entry.ID]:
+			errs = append(errs, fmt.Errorf("IgnoredVulns[%d]: %q is ignored more than once", i, entry.ID))
+		}
+		seen[entry.ID] = true
+
+		if !entry.IgnoreUntil.IsZero() && !entry.IgnoreUntil.After(now) {
+			errs = append(errs, fmt.Errorf("IgnoredVulns[%d]: ignore of %q expired on %s", i, entry.ID, entry.IgnoreUntil.Format(time.DateOnly)))
+		}
+	}
+
+	return errs
+}
+
 // Finds the containing folder of `target`, then appends osvScannerConfigName
 func normalizeConfigLoadPath(target string) (string, error) {
 	stat, err := os.Stat(target)
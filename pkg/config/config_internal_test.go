@@ -1,11 +1,14 @@
 package config
 
 import (
+	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -77,6 +80,136 @@ func TestTryLoadConfig(t *testing.T) {
 	}
 }
 
+func TestConfig_Write(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		IgnoredVulns: []IgnoreEntry{
+			{
+				ID: "GO-2022-0968",
+			},
+			{
+				ID:          "GO-2022-1059",
+				IgnoreUntil: time.Date(2022, 11, 9, 0, 0, 0, 0, time.UTC),
+				Reason:      "not exploitable",
+			},
+		},
+		LoadPath:     "/path/to/osv-scanner.toml",
+		ExcludePaths: []string{"vendor/**"},
+	}
+
+	var b strings.Builder
+	if err := config.Write(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `LoadPath = "/path/to/osv-scanner.toml"
+ExcludePaths = ["vendor/**"]
+
+[[IgnoredVulns]]
+  id = "GO-2022-0968"
+
+[[IgnoredVulns]]
+  id = "GO-2022-1059"
+  ignoreUntil = 2022-11-09T00:00:00Z
+  reason = "not exploitable"
+`
+
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("Write() mismatch (-want +got):\n%s", diff)
+	}
+
+	// the written config should load back to the same config
+	var loaded Config
+	if _, err := toml.Decode(b.String(), &loaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(config, loaded); diff != "" {
+		t.Errorf("loaded config mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		config Config
+		want   []string
+	}{
+		{
+			name: "valid config",
+			config: Config{
+				IgnoredVulns: []IgnoreEntry{
+					{ID: "GHSA-c3h9-896r-86jm"},
+					{ID: "CVE-2022-1234", IgnoreUntil: now.AddDate(0, 1, 0)},
+					{ID: "ALSA-2021:1234"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid entries",
+			config: Config{
+				IgnoredVulns: []IgnoreEntry{
+					{ID: ""},
+					{ID: "GHSA c3h9"},
+					{ID: "GO-2022-0968"},
+					{ID: "GO-2022-0968"},
+					{ID: "GO-2022-1059", IgnoreUntil: now.AddDate(0, -1, 0)},
+				},
+			},
+			want: []string{
+				"IgnoredVulns[0]: id is missing",
+				`IgnoredVulns[1]: "GHSA c3h9" is not a well-formed vulnerability id`,
+				`IgnoredVulns[3]: "GO-2022-0968" is ignored more than once`,
+				`IgnoredVulns[4]: ignore of "GO-2022-1059" expired on 2023-12-01`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, err := range tt.config.Validate(now) {
+				got = append(got, err.Error())
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "osv-scanner.toml")
+	contents := "[[IgnoredVulns]]\nid = \"GO-2022-0968\"\nignoreUtnil = 2022-11-09\n"
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("could not write config: %v", err)
+	}
+
+	var got []string
+	for _, err := range ValidateFile(path) {
+		got = append(got, err.Error())
+	}
+
+	want := []string{`unknown key "IgnoredVulns.ignoreUtnil"`}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ValidateFile() mismatch (-want +got):\n%s", diff)
+	}
+
+	if errs := ValidateFile(filepath.Join(t.TempDir(), "missing.toml")); len(errs) != 1 {
+		t.Errorf("ValidateFile() of a missing file = %v, want one error", errs)
+	}
+}
+
 func TestConfig_ShouldIgnore(t *testing.T) {
 	t.Parallel()
 
@@ -191,3 +324,51 @@ func TestConfig_ShouldIgnore(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplate(t *testing.T) {
+	t.Parallel()
+
+	var config Config
+	if _, err := toml.Decode(Template, &config); err != nil {
+		t.Fatalf("Template does not parse: %v", err)
+	}
+
+	if diff := cmp.Diff(Config{}, config); diff != "" {
+		t.Errorf("Template should not configure anything until its examples are uncommented (-want +got):\n%s", diff)
+	}
+
+	// uncomment each of the examples, leaving the descriptions as comments
+	lines := strings.Split(Template, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "# ") && line != "#" {
+			lines[i] = strings.TrimPrefix(line, "#")
+		}
+	}
+
+	var uncommented Config
+	md, err := toml.Decode(strings.Join(lines, "\n"), &uncommented)
+	if err != nil {
+		t.Fatalf("Template examples do not parse: %v", err)
+	}
+
+	for _, key := range md.Undecoded() {
+		t.Errorf("Template has unknown key %q", key.String())
+	}
+
+	// every option that can be configured should have an example
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		name, _, _ := strings.Cut(configType.Field(i).Tag.Get("toml"), ",")
+		if name == "LoadPath" {
+			continue
+		}
+
+		if !md.IsDefined(name) {
+			t.Errorf("Template does not have an example of %q", name)
+		}
+	}
+
+	if errs := uncommented.Validate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); len(errs) > 0 {
+		t.Errorf("Template examples are not valid: %v", errs)
+	}
+}
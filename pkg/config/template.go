@@ -0,0 +1,28 @@
+package config
+
+// Template is a commented osv-scanner.toml that documents each of the options that can be configured,
+// for getting started with configuring a project.
+//
+// Each of the examples is commented out with a "#" that is not followed by a space,
+// so that the template can be checked to parse once they are uncommented.
+const Template = `# osv-scanner.toml
+#
+# Configures how osv-scanner scans the directory containing this file, unless another
+# config is passed with the --config flag.
+# See https://google.github.io/osv-scanner/configuration/ for more details.
+
+# The version of Go to check the standard library ("stdlib") against, instead of the
+# version from go.mod.
+#GoVersionOverride = "1.22.4"
+
+# Globs of paths to skip when scanning the directory containing this file, which are
+# used in addition to any passed with the --exclude flag.
+#ExcludePaths = ["**/testdata/**", "examples/*"]
+
+# Vulnerabilities to ignore, which also ignores the vulnerabilities that are aliases of them.
+# Each entry can optionally have a date after which it is no longer ignored, and a reason.
+#[[IgnoredVulns]]
+#id = "GHSA-xxxx-xxxx-xxxx"
+#ignoreUntil = 2030-01-01
+#reason = "Only used to parse trusted input"
+`
@@ -131,7 +131,10 @@ func FromApkInstalled(pathToInstalled string) (Lockfile, error) {
 
 	sort.Slice(packages, func(i, j int) bool {
 		if packages[i].Name == packages[j].Name {
-			return packages[i].Version < packages[j].Version
+			// apk versions cannot be compared as strings, as "1.2.3-r10" is after "1.2.3-r9"
+			cmp, _ := CompareVersions(AlpineEcosystem, packages[i].Version, packages[j].Version)
+
+			return cmp < 0
 		}
 
 		return packages[i].Name < packages[j].Name
@@ -2,6 +2,7 @@ package lockfile_test
 
 import (
 	"io/fs"
+	"reflect"
 	"testing"
 
 	"github.com/google/osv-scanner/pkg/lockfile"
@@ -133,3 +134,49 @@ func TestParseApkInstalled_Multiple(t *testing.T) {
 		},
 	})
 }
+
+func TestFromApkInstalled_Rebuilds(t *testing.T) {
+	t.Parallel()
+
+	lf, err := lockfile.FromApkInstalled("fixtures/apk/rebuilds_installed")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// builds should be sorted as numbers rather than strings
+	var versions []string
+	for _, pkg := range lf.Packages {
+		versions = append(versions, pkg.Name+"@"+pkg.Version)
+	}
+
+	want := []string{"libcrypto3@3.1.4-r9", "libcrypto3@3.1.4-r10", "zlib@1:1.3.1-r0"}
+
+	if !reflect.DeepEqual(versions, want) {
+		t.Errorf("Expected packages to be sorted as %v but got %v", want, versions)
+	}
+
+	expectPackages(t, lf.Packages, []lockfile.PackageDetails{
+		{
+			Name:      "libcrypto3",
+			Version:   "3.1.4-r10",
+			Commit:    "a0d7b8cb1ae0ec5d09ab2c4dfc79e8c62ee65a5c",
+			Ecosystem: lockfile.AlpineEcosystem,
+			CompareAs: lockfile.AlpineEcosystem,
+		},
+		{
+			Name:      "libcrypto3",
+			Version:   "3.1.4-r9",
+			Commit:    "5e4ab38ff1f2e3d6a0a6e0a7db5b7c4e7c8f0d1a",
+			Ecosystem: lockfile.AlpineEcosystem,
+			CompareAs: lockfile.AlpineEcosystem,
+		},
+		{
+			Name:      "zlib",
+			Version:   "1:1.3.1-r0",
+			Commit:    "4f4f1c0b9dc5b6c0a5e6d1a4c8f2b0e7d3a9c6b2",
+			Ecosystem: lockfile.AlpineEcosystem,
+			CompareAs: lockfile.AlpineEcosystem,
+		},
+	})
+}
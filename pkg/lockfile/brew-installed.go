@@ -0,0 +1,159 @@
+package lockfile
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+)
+
+const BrewEcosystem Ecosystem = "Homebrew"
+
+// brewInstallReceipt is the part of the INSTALL_RECEIPT.json that Homebrew writes
+// alongside each installed version of a formula that is needed to describe it
+type brewInstallReceipt struct {
+	InstalledOnRequest bool `json:"installed_on_request"`
+	Source             struct {
+		Versions struct {
+			Stable string `json:"stable"`
+		} `json:"versions"`
+	} `json:"source"`
+}
+
+// brewVersion removes the revision (such as the "_1" in "3.2.0_1") that Homebrew adds
+// to the version of a formula when it is rebuilt without a new upstream version
+func brewVersion(version string) string {
+	re := cachedregexp.MustCompile(`_\d+$`)
+
+	return re.ReplaceAllString(version, "")
+}
+
+// BrewListExtractor extracts the formulae from the output of `brew list --versions`,
+// which has a line per formula listing each of its installed versions
+type BrewListExtractor struct{}
+
+func (e BrewListExtractor) ShouldExtract(path string) bool {
+	// The output can be written to any file, so don't return a default should extract
+	return false
+}
+
+func (e BrewListExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	var packages []PackageDetails
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) < 2 {
+			continue
+		}
+
+		for _, version := range fields[1:] {
+			packages = append(packages, PackageDetails{
+				Name:      fields[0],
+				Version:   brewVersion(version),
+				Ecosystem: BrewEcosystem,
+				CompareAs: BrewEcosystem,
+			})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = BrewListExtractor{}
+
+// parseBrewCellar reads the install receipt of each version of each formula installed
+// in the given Cellar directory, which are laid out as <cellar>/<formula>/<version>
+func parseBrewCellar(pathToCellar string) ([]PackageDetails, error) {
+	receipts, err := filepath.Glob(filepath.Join(pathToCellar, "*", "*", "INSTALL_RECEIPT.json"))
+
+	if err != nil {
+		return []PackageDetails{}, err
+	}
+
+	packages := make([]PackageDetails, 0, len(receipts))
+
+	for _, pathToReceipt := range receipts {
+		b, err := os.ReadFile(pathToReceipt)
+
+		if err != nil {
+			return packages, err
+		}
+
+		var receipt brewInstallReceipt
+
+		if err := json.Unmarshal(b, &receipt); err != nil {
+			return packages, fmt.Errorf("could not parse %s: %w", pathToReceipt, err)
+		}
+
+		versionDir := filepath.Dir(pathToReceipt)
+		version := receipt.Source.Versions.Stable
+
+		// formulae installed from HEAD do not have a stable version
+		if version == "" {
+			version = brewVersion(filepath.Base(versionDir))
+		}
+
+		packages = append(packages, PackageDetails{
+			Name:         filepath.Base(filepath.Dir(versionDir)),
+			Version:      version,
+			Ecosystem:    BrewEcosystem,
+			CompareAs:    BrewEcosystem,
+			Relationship: relationship(receipt.InstalledOnRequest),
+		})
+	}
+
+	return packages, nil
+}
+
+// ParseBrewInstalled parses either the output of `brew list --versions`,
+// or if given a directory, the install receipts of the formulae in a Homebrew Cellar
+func ParseBrewInstalled(pathToInstalled string) ([]PackageDetails, error) {
+	info, err := os.Stat(pathToInstalled)
+
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", pathToInstalled, err)
+	}
+
+	if !info.IsDir() {
+		return extractFromFile(pathToInstalled, BrewListExtractor{})
+	}
+
+	packages, err := parseBrewCellar(pathToInstalled)
+
+	if err != nil {
+		return packages, fmt.Errorf("could not extract from %s: %w", pathToInstalled, err)
+	}
+
+	return packages, nil
+}
+
+// FromBrewInstalled attempts to parse the given path as a "brew" lockfile, which is
+// either the output of `brew list --versions` or a Homebrew Cellar directory
+func FromBrewInstalled(pathToInstalled string) (Lockfile, error) {
+	packages, err := ParseBrewInstalled(pathToInstalled)
+
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name == packages[j].Name {
+			return packages[i].Version < packages[j].Version
+		}
+
+		return packages[i].Name < packages[j].Name
+	})
+
+	return Lockfile{
+		FilePath: pathToInstalled,
+		ParsedAs: "brew",
+		Packages: packages,
+	}, err
+}
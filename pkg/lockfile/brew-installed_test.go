@@ -0,0 +1,141 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestParseBrewInstalled_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseBrewInstalled("fixtures/brew/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseBrewInstalled_EmptyList(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseBrewInstalled("fixtures/brew/empty.txt")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseBrewInstalled_List(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseBrewInstalled("fixtures/brew/list.txt")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "ca-certificates",
+			Version:   "2023-08-22",
+			Ecosystem: lockfile.BrewEcosystem,
+			CompareAs: lockfile.BrewEcosystem,
+		},
+		{
+			Name:      "jq",
+			Version:   "1.7.1",
+			Ecosystem: lockfile.BrewEcosystem,
+			CompareAs: lockfile.BrewEcosystem,
+		},
+		{
+			Name:      "openssl@3",
+			Version:   "3.2.0",
+			Ecosystem: lockfile.BrewEcosystem,
+			CompareAs: lockfile.BrewEcosystem,
+		},
+		{
+			Name:      "python@3.12",
+			Version:   "3.12.1",
+			Ecosystem: lockfile.BrewEcosystem,
+			CompareAs: lockfile.BrewEcosystem,
+		},
+		{
+			Name:      "python@3.12",
+			Version:   "3.12.0",
+			Ecosystem: lockfile.BrewEcosystem,
+			CompareAs: lockfile.BrewEcosystem,
+		},
+	})
+}
+
+func TestParseBrewInstalled_EmptyCellar(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseBrewInstalled("fixtures/brew/empty-cellar")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseBrewInstalled_InvalidCellar(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseBrewInstalled("fixtures/brew/invalid-cellar")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseBrewInstalled_Cellar(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseBrewInstalled("fixtures/brew/cellar")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:         "ca-certificates",
+			Version:      "2023-08-22",
+			Ecosystem:    lockfile.BrewEcosystem,
+			CompareAs:    lockfile.BrewEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "jq",
+			Version:      "HEAD-b9c6327",
+			Ecosystem:    lockfile.BrewEcosystem,
+			CompareAs:    lockfile.BrewEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "openssl@3",
+			Version:      "3.2.0",
+			Ecosystem:    lockfile.BrewEcosystem,
+			CompareAs:    lockfile.BrewEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "python@3.12",
+			Version:      "3.12.0",
+			Ecosystem:    lockfile.BrewEcosystem,
+			CompareAs:    lockfile.BrewEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "python@3.12",
+			Version:      "3.12.1",
+			Ecosystem:    lockfile.BrewEcosystem,
+			CompareAs:    lockfile.BrewEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+	})
+}
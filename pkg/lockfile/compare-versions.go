@@ -0,0 +1,29 @@
+package lockfile
+
+import (
+	"strings"
+
+	"github.com/google/osv-scanner/internal/semantic"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// ErrUnsupportedEcosystem is returned when comparing versions of an ecosystem
+// whose versioning scheme is not supported
+var ErrUnsupportedEcosystem = semantic.ErrUnsupportedEcosystem
+
+// CompareVersions compares two versions using the versioning scheme of the given ecosystem,
+// such as semver for npm and crates.io, PEP 440 for PyPI, and the Debian and Alpine rules for their packages.
+//
+// The result will be 0 if a == b, -1 if a < b, or +1 if a > b. An error wrapping
+// ErrUnsupportedEcosystem is returned if the versions of the ecosystem cannot be compared.
+func CompareVersions(ecosystem Ecosystem, a, b string) (int, error) {
+	// Ecosystems can have a release suffix, such as "Debian:11"
+	base, _, _ := strings.Cut(string(ecosystem), ":")
+
+	v, err := semantic.Parse(a, models.Ecosystem(base))
+	if err != nil {
+		return 0, err
+	}
+
+	return v.CompareStr(b), nil
+}
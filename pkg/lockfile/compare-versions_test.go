@@ -0,0 +1,84 @@
+package lockfile_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ecosystem lockfile.Ecosystem
+		a         string
+		b         string
+		want      int
+	}{
+		// semver
+		{lockfile.NpmEcosystem, "1.2.3", "1.2.3", 0},
+		{lockfile.NpmEcosystem, "1.2.3", "1.10.0", -1},
+		{lockfile.NpmEcosystem, "2.0.0", "1.99.99", 1},
+		{lockfile.NpmEcosystem, "1.0.0-alpha", "1.0.0", -1},
+		{lockfile.NpmEcosystem, "1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{lockfile.NpmEcosystem, "1.0.0+build.1", "1.0.0+build.2", 0},
+		{lockfile.CargoEcosystem, "0.9.10", "0.10.0", -1},
+		{lockfile.CargoEcosystem, "1.0.0-rc.1", "1.0.0-beta.2", 1},
+		{lockfile.GoEcosystem, "v1.2.3", "1.2.3", 0},
+		{lockfile.PubEcosystem, "3.0.0", "3.0.0-dev", 1},
+		// PEP 440
+		{lockfile.PipEcosystem, "1.0", "1.0.0", 0},
+		{lockfile.PipEcosystem, "1.0a1", "1.0", -1},
+		{lockfile.PipEcosystem, "1.0.post1", "1.0", 1},
+		{lockfile.PipEcosystem, "1.0.dev1", "1.0a1", -1},
+		{lockfile.PipEcosystem, "1!0.1", "2.0", 1},
+		{lockfile.PipEcosystem, "1.0rc1", "1.0b2", 1},
+		// Debian
+		{lockfile.DebianEcosystem, "1.2.3-1", "1.2.3-1", 0},
+		{lockfile.DebianEcosystem, "1.2.3-1", "1.2.3-2", -1},
+		{lockfile.DebianEcosystem, "1:1.0", "2.0", 1},
+		{lockfile.DebianEcosystem, "1.0~rc1", "1.0", -1},
+		{lockfile.DebianEcosystem, "7.74.0-1.3+deb11u1", "7.74.0-1.3", 1},
+		{"Debian:11", "2.31-13+deb11u5", "2.31-13+deb11u6", -1},
+		// Alpine
+		{lockfile.AlpineEcosystem, "1.2.3-r0", "1.2.3-r0", 0},
+		{lockfile.AlpineEcosystem, "1.2.3-r0", "1.2.3-r1", -1},
+		{lockfile.AlpineEcosystem, "1.2.3_rc1-r0", "1.2.3-r0", -1},
+		{lockfile.AlpineEcosystem, "1.2.10-r0", "1.2.9-r5", 1},
+		{"Alpine:v3.16", "3.0.8-r0", "3.0.10-r0", -1},
+		// others
+		{lockfile.MavenEcosystem, "1.0-SNAPSHOT", "1.0", -1},
+		{lockfile.MavenEcosystem, "1.0.RELEASE", "1.0", 0},
+		{lockfile.NuGetEcosystem, "1.0.0-beta", "1.0.0", -1},
+		{lockfile.BundlerEcosystem, "1.0.0.pre", "1.0.0", -1},
+		{lockfile.ComposerEcosystem, "1.0.0-RC1", "1.0.0-beta", 1},
+		{lockfile.MixEcosystem, "1.1.0", "1.0.9", 1},
+		{lockfile.CRANEcosystem, "1.0-1", "1.0.2", -1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.ecosystem)+"/"+tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := lockfile.CompareVersions(tt.ecosystem, tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("CompareVersions(%s, %s, %s) = %d, want %d", tt.ecosystem, tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareVersions_UnsupportedEcosystem(t *testing.T) {
+	t.Parallel()
+
+	_, err := lockfile.CompareVersions("Unknown", "1.0.0", "2.0.0")
+
+	if !errors.Is(err, lockfile.ErrUnsupportedEcosystem) {
+		t.Errorf("Expected to get %v, but got %v", lockfile.ErrUnsupportedEcosystem, err)
+	}
+}
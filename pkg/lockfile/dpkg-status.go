@@ -173,7 +173,10 @@ func FromDpkgStatus(pathToStatus string) (Lockfile, error) {
 
 	sort.Slice(packages, func(i, j int) bool {
 		if packages[i].Name == packages[j].Name {
-			return packages[i].Version < packages[j].Version
+			// dpkg versions cannot be compared as strings, as "1.0~rc1" is before "1.0"
+			cmp, _ := CompareVersions(DebianEcosystem, packages[i].Version, packages[j].Version)
+
+			return cmp < 0
 		}
 
 		return packages[i].Name < packages[j].Name
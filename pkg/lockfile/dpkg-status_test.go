@@ -2,6 +2,7 @@ package lockfile_test
 
 import (
 	"io/fs"
+	"reflect"
 	"testing"
 
 	"github.com/google/osv-scanner/pkg/lockfile"
@@ -158,3 +159,50 @@ func TestParseDpkgStatus_Source_Ver_Override(t *testing.T) {
 		},
 	})
 }
+
+func TestFromDpkgStatus_SecurityUpdates(t *testing.T) {
+	t.Parallel()
+
+	lf, err := lockfile.FromDpkgStatus("fixtures/dpkg/security_updates_status")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// versions should be sorted per the Debian rules rather than as strings
+	var versions []string
+	for _, pkg := range lf.Packages {
+		versions = append(versions, pkg.Name+"@"+pkg.Version)
+	}
+
+	want := []string{
+		"libxml2@2.9.4+dfsg1-2.2~bpo8+1",
+		"libxml2@2.9.4+dfsg1-2.2+deb9u6",
+		"libxml2@2.9.4+dfsg1-2.2+deb9u10",
+	}
+
+	if !reflect.DeepEqual(versions, want) {
+		t.Errorf("Expected packages to be sorted as %v but got %v", want, versions)
+	}
+
+	expectPackages(t, lf.Packages, []lockfile.PackageDetails{
+		{
+			Name:      "libxml2",
+			Version:   "2.9.4+dfsg1-2.2+deb9u10",
+			Ecosystem: lockfile.DebianEcosystem,
+			CompareAs: lockfile.DebianEcosystem,
+		},
+		{
+			Name:      "libxml2",
+			Version:   "2.9.4+dfsg1-2.2+deb9u6",
+			Ecosystem: lockfile.DebianEcosystem,
+			CompareAs: lockfile.DebianEcosystem,
+		},
+		{
+			Name:      "libxml2",
+			Version:   "2.9.4+dfsg1-2.2~bpo8+1",
+			Ecosystem: lockfile.DebianEcosystem,
+			CompareAs: lockfile.DebianEcosystem,
+		},
+	})
+}
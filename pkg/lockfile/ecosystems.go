@@ -16,6 +16,8 @@ func KnownEcosystems() []Ecosystem {
 		PubEcosystem,
 		ConanEcosystem,
 		CRANEcosystem,
+		CocoaPodsEcosystem,
+		TerraformEcosystem,
 		// Disabled temporarily,
 		// see https://github.com/google/osv-scanner/pull/128 discussion for additional context
 		// AlpineEcosystem,
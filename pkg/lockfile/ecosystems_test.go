@@ -37,8 +37,10 @@ func TestKnownEcosystems(t *testing.T) {
 	// - npm, yarn, and pnpm,
 	// - pip, poetry, pdm and pipenv,
 	// - maven, gradle, and gradle/verification-metadata
+	// - packages.lock.json and packages.config
+	// - composer.lock and composer.json
 	// all use the same ecosystem so "ignore" those parsers in the count
-	expectedCount -= 7
+	expectedCount -= 9
 
 	ecosystems := lockfile.KnownEcosystems()
 
@@ -33,8 +33,10 @@ func TestFindExtractor(t *testing.T) {
 	t.Parallel()
 
 	lockfiles := map[string]string{
+		".terraform.lock.hcl":              "terraform-lock",
 		"buildscript-gradle.lockfile":      "gradle.lockfile",
 		"Cargo.lock":                       "Cargo.lock",
+		"composer.json":                    "composer.json",
 		"composer.lock":                    "composer.lock",
 		"Gemfile.lock":                     "Gemfile.lock",
 		"go.mod":                           "go.mod",
@@ -44,6 +46,7 @@ func TestFindExtractor(t *testing.T) {
 		"pdm.lock":                         "pdm.lock",
 		"Pipfile.lock":                     "Pipfile.lock",
 		"package-lock.json":                "package-lock.json",
+		"packages.config":                  "packages.config",
 		"packages.lock.json":               "packages.lock.json",
 		"pnpm-lock.yaml":                   "pnpm-lock.yaml",
 		"poetry.lock":                      "poetry.lock",
@@ -85,8 +88,10 @@ func TestExtractDeps_FindsExpectedExtractor(t *testing.T) {
 	t.Parallel()
 
 	lockfiles := []string{
+		".terraform.lock.hcl",
 		"buildscript-gradle.lockfile",
 		"Cargo.lock",
+		"composer.json",
 		"composer.lock",
 		"conan.lock",
 		"Gemfile.lock",
@@ -97,8 +102,10 @@ func TestExtractDeps_FindsExpectedExtractor(t *testing.T) {
 		"pdm.lock",
 		"Pipfile.lock",
 		"package-lock.json",
+		"packages.config",
 		"packages.lock.json",
 		"pnpm-lock.yaml",
+		"Podfile.lock",
 		"poetry.lock",
 		"pom.xml",
 		"pubspec.lock",
@@ -3,8 +3,11 @@ package lockfile
 import (
 	"errors"
 	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
 var ErrOpenNotSupported = errors.New("this file does not support opening files")
@@ -69,6 +72,42 @@ func OpenLocalDepFile(path string) (NestedDepFile, error) {
 var _ DepFile = LocalFile{}
 var _ NestedDepFile = LocalFile{}
 
+// An FSFile represents a file that exists within an fs.FS, such as the contents
+// of a repository that have been fetched into memory.
+type FSFile struct {
+	io.ReadCloser
+
+	fsys fs.FS
+	path string
+}
+
+// Open opens the file at path within the same fs.FS, with absolute paths
+// being relative to the root of the fs.FS
+func (f FSFile) Open(p string) (NestedDepFile, error) {
+	if path.IsAbs(p) {
+		return OpenFSDepFile(f.fsys, strings.TrimPrefix(p, "/"))
+	}
+
+	return OpenFSDepFile(f.fsys, path.Join(path.Dir(f.path), p))
+}
+
+func (f FSFile) Path() string { return f.path }
+
+// OpenFSDepFile opens the file at path within fsys, which must be a valid
+// fs.FS path (i.e. slash separated and unrooted)
+func OpenFSDepFile(fsys fs.FS, path string) (NestedDepFile, error) {
+	r, err := fsys.Open(path)
+
+	if err != nil {
+		return FSFile{}, err
+	}
+
+	return FSFile{r, fsys, path}, nil
+}
+
+var _ DepFile = FSFile{}
+var _ NestedDepFile = FSFile{}
+
 func extractFromFile(pathToLockfile string, extractor Extractor) ([]PackageDetails, error) {
 	f, err := OpenLocalDepFile(pathToLockfile)
 
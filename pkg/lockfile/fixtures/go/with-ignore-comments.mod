@@ -0,0 +1,8 @@
+module my-library
+
+require (
+	// osv-scanner:ignore=GO-2022-0001 only used in tests
+	github.com/BurntSushi/toml v1.0.0
+	gopkg.in/yaml.v2 v2.4.0 // osv-scanner:ignore=GHSA-xxxx-xxxx-xxxx,GO-2022-0002
+	golang.org/x/mod v0.4.2 // indirect
+)
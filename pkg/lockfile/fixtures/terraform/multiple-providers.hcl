@@ -0,0 +1,24 @@
+# This file is maintained automatically by "tofu init".
+# Manual edits may be lost in future updates.
+
+provider "registry.opentofu.org/hashicorp/random" {
+  version     = "3.6.0"
+  constraints = ">= 3.0.0, < 4.0.0"
+  hashes = [
+    "h1:R5Ucn26riKIEijcsiOMBR3uOAjuOMfI1x7XvH4P6B1w=",
+    "zh:486a1c921eab5c51a480f2eb0ad85173f207c9b7bb215f1d9f9ee1f4d0b7b1a0",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/google" {
+  version = "4.84.0"
+  hashes  = ["h1:Hw6U1eL8GzwXQ1bsAHkF3QvLmu2gqX9PsDLzzs4BKuA="]
+}
+
+provider "registry.terraform.io/integrations/github" {
+  version     = "5.42.0"
+  constraints = "5.42.0"
+  hashes = [
+    "h1:vHT5RXAXK/fbc6O/TgWTtv0/OZGyAhQv14rzaJ0pnOc=",
+  ]
+}
@@ -0,0 +1,94 @@
+package lockfile
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strings"
+
+	"golang.org/x/exp/maps"
+)
+
+// goBinaryModule returns the module that was actually built into the binary,
+// which is the replacement of the module if it was replaced
+func goBinaryModule(mod *debug.Module) *debug.Module {
+	if mod.Replace != nil {
+		return mod.Replace
+	}
+
+	return mod
+}
+
+// goBinaryPackages returns the packages that were built into a Go binary
+func goBinaryPackages(info *buildinfo.BuildInfo) []PackageDetails {
+	packages := map[string]PackageDetails{}
+
+	modules := append([]*debug.Module{&info.Main}, info.Deps...)
+
+	for _, mod := range modules {
+		mod = goBinaryModule(mod)
+
+		// modules that were built from a local directory (such as the main module
+		// when not installed with "go install", or modules replaced with a local path)
+		// do not have a version that can be checked
+		if mod.Path == "" || mod.Version == "" || mod.Version == "(devel)" {
+			continue
+		}
+
+		packages[mod.Path+"@"+mod.Version] = PackageDetails{
+			Name:      mod.Path,
+			Version:   strings.TrimPrefix(mod.Version, "v"),
+			Ecosystem: GoEcosystem,
+			CompareAs: GoEcosystem,
+		}
+	}
+
+	// e.g. "go1.22.1" or "go1.22.1 X:loopvar" if built with experiments
+	if goVersion, _, _ := strings.Cut(info.GoVersion, " "); goVersion != "" {
+		packages["stdlib"] = PackageDetails{
+			Name:      "stdlib",
+			Version:   strings.TrimPrefix(goVersion, "go"),
+			Ecosystem: GoEcosystem,
+			CompareAs: GoEcosystem,
+		}
+	}
+
+	return maps.Values(packages)
+}
+
+type GoBinaryExtractor struct{}
+
+// ShouldExtract always returns false, as Go binaries can be named anything,
+// and so must be explicitly extracted as "go-binary"
+func (e GoBinaryExtractor) ShouldExtract(_ string) bool {
+	return false
+}
+
+func (e GoBinaryExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	b, err := io.ReadAll(f)
+
+	var info *buildinfo.BuildInfo
+
+	if err == nil {
+		info, err = buildinfo.Read(bytes.NewReader(b))
+	}
+
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+
+	return goBinaryPackages(info), nil
+}
+
+var _ Extractor = GoBinaryExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("go-binary", GoBinaryExtractor{})
+}
+
+func ParseGoBinary(pathToBinary string) ([]PackageDetails, error) {
+	return extractFromFile(pathToBinary, GoBinaryExtractor{})
+}
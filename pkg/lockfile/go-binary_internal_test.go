@@ -0,0 +1,77 @@
+package lockfile
+
+import (
+	"debug/buildinfo"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_goBinaryPackages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		info *buildinfo.BuildInfo
+		want []PackageDetails
+	}{
+		{
+			name: "installed with go install",
+			info: &buildinfo.BuildInfo{
+				GoVersion: "go1.22.1",
+				Main:      debug.Module{Path: "example.com/mine", Version: "v1.2.3"},
+				Deps: []*debug.Module{
+					{Path: "golang.org/x/mod", Version: "v0.18.0"},
+				},
+			},
+			want: []PackageDetails{
+				{Name: "example.com/mine", Version: "1.2.3", Ecosystem: GoEcosystem, CompareAs: GoEcosystem},
+				{Name: "golang.org/x/mod", Version: "0.18.0", Ecosystem: GoEcosystem, CompareAs: GoEcosystem},
+				{Name: "stdlib", Version: "1.22.1", Ecosystem: GoEcosystem, CompareAs: GoEcosystem},
+			},
+		},
+		{
+			name: "with replacements",
+			info: &buildinfo.BuildInfo{
+				GoVersion: "go1.22.12 X:loopvar",
+				Main:      debug.Module{Path: "example.com/mine", Version: "(devel)"},
+				Deps: []*debug.Module{
+					{Path: "golang.org/x/mod", Version: "v0.18.0"},
+					{
+						Path:    "golang.org/x/exp",
+						Version: "v0.0.0-20230522175609-2e198f4a06a1",
+						Replace: &debug.Module{Path: "golang.org/x/exp", Version: "v0.0.0-20240604190554-fc45aab8b7f8"},
+					},
+					{
+						Path:    "example.com/local",
+						Version: "v1.0.0",
+						Replace: &debug.Module{Path: "../local"},
+					},
+				},
+			},
+			want: []PackageDetails{
+				{Name: "golang.org/x/exp", Version: "0.0.0-20240604190554-fc45aab8b7f8", Ecosystem: GoEcosystem, CompareAs: GoEcosystem},
+				{Name: "golang.org/x/mod", Version: "0.18.0", Ecosystem: GoEcosystem, CompareAs: GoEcosystem},
+				{Name: "stdlib", Version: "1.22.12", Ecosystem: GoEcosystem, CompareAs: GoEcosystem},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := goBinaryPackages(tt.info)
+			slices.SortFunc(got, func(a, b PackageDetails) int {
+				return strings.Compare(a.Name, b.Name)
+			})
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("goBinaryPackages() returned an unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestGoBinaryExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	paths := []string{"", "osv-scanner", "path/to/my/go.mod", "path/to/my/binary.exe"}
+
+	for _, path := range paths {
+		if (lockfile.GoBinaryExtractor{}).ShouldExtract(path) {
+			t.Errorf("Expected ShouldExtract(%s) to be false, as binaries must be explicitly extracted", path)
+		}
+	}
+}
+
+func TestParseGoBinary_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoBinary("fixtures/go/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseGoBinary_NotABinary(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoBinary("fixtures/go/one-package.mod")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+// the test binary is itself a Go binary, so it is used rather than a checked in one
+func TestParseGoBinary_TestBinary(t *testing.T) {
+	t.Parallel()
+
+	path, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	packages, err := lockfile.ParseGoBinary(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	version := strings.TrimPrefix(runtime.Version(), "go")
+	hasStdlib := slices.ContainsFunc(packages, func(pkg lockfile.PackageDetails) bool {
+		return pkg.Name == "stdlib" && pkg.Version == version && pkg.Ecosystem == lockfile.GoEcosystem
+	})
+
+	if !hasStdlib {
+		t.Errorf("Expected stdlib@%s to be found in the test binary, but got %v", version, packages)
+	}
+}
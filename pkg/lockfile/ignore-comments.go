@@ -0,0 +1,40 @@
+package lockfile
+
+import (
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+)
+
+// IgnoreComment is a vulnerability that has been marked as ignored by a comment
+// next to a package in a lockfile, such as:
+//
+//	# osv-scanner:ignore=GHSA-xxxx-xxxx-xxxx reason for ignoring
+type IgnoreComment struct {
+	ID     string
+	Reason string
+}
+
+// parseIgnoreComments returns the vulnerabilities ignored by a comment in the given line,
+// which may list multiple ids separated by commas
+func parseIgnoreComments(line string) []IgnoreComment {
+	re := cachedregexp.MustCompile(`(?:^|\s)(?:#|//)\s*osv-scanner:ignore=(\S+)(.*)$`)
+	matches := re.FindStringSubmatch(line)
+
+	if matches == nil {
+		return nil
+	}
+
+	var ignores []IgnoreComment
+	reason := strings.TrimSpace(matches[2])
+
+	for _, id := range strings.Split(matches[1], ",") {
+		if id == "" {
+			continue
+		}
+
+		ignores = append(ignores, IgnoreComment{ID: id, Reason: reason})
+	}
+
+	return ignores
+}
@@ -0,0 +1,141 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+	"golang.org/x/exp/maps"
+)
+
+// MavenDependencyTreeExtractor extracts the packages from the output of `mvn dependency:tree`,
+// in either the default text format or the DOT format (`-DoutputType=dot`)
+type MavenDependencyTreeExtractor struct{}
+
+func (e MavenDependencyTreeExtractor) ShouldExtract(path string) bool {
+	// The output can be written to any file, so don't return a default should extract
+	return false
+}
+
+// parseMavenTreeCoordinate parses a "group:artifact:type[:classifier]:version:scope" coordinate
+// of a dependency, returning false if str is not such a coordinate (such as that of the project itself)
+func parseMavenTreeCoordinate(str string) (PackageDetails, bool) {
+	parts := strings.Split(str, ":")
+
+	if len(parts) != 5 && len(parts) != 6 {
+		return PackageDetails{}, false
+	}
+
+	pkg := PackageDetails{
+		Name:      parts[0] + ":" + parts[1],
+		Version:   parts[len(parts)-2],
+		Ecosystem: MavenEcosystem,
+		CompareAs: MavenEcosystem,
+	}
+
+	// Only record non-default scopes, as is done for pom.xml files
+	if scope := parts[len(parts)-1]; scope != "compile" {
+		pkg.DepGroups = append(pkg.DepGroups, scope)
+	}
+
+	return pkg, true
+}
+
+// parseMavenTreeLine returns the dependency on a line of the text format, along with its
+// depth in the tree (where the dependencies of the project itself have a depth of 1)
+func parseMavenTreeLine(line string) (PackageDetails, int, bool) {
+	re := cachedregexp.MustCompile(`^((?:[| ]  )*)[+\\]- (\S+)`)
+
+	matched := re.FindStringSubmatch(line)
+
+	if matched == nil {
+		return PackageDetails{}, 0, false
+	}
+
+	// Dependencies that were omitted (such as for being a duplicate or conflicting with
+	// another version) are wrapped in parentheses, and are not part of the resolved tree
+	if strings.HasPrefix(matched[2], "(") {
+		return PackageDetails{}, 0, false
+	}
+
+	pkg, ok := parseMavenTreeCoordinate(matched[2])
+
+	return pkg, len(matched[1])/3 + 1, ok
+}
+
+func (e MavenDependencyTreeExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	digraphRe := cachedregexp.MustCompile(`^digraph "([^"]+)"`)
+	edgeRe := cachedregexp.MustCompile(`^"([^"]+)" -> "([^"]+)"`)
+
+	packages := map[string]PackageDetails{}
+	addPackage := func(pkg PackageDetails, isDirect bool) {
+		key := pkg.Name + "@" + pkg.Version
+
+		// a package is direct if the project itself depends on it anywhere in the tree
+		if existing, ok := packages[key]; ok && existing.Relationship == DirectDependency {
+			return
+		}
+
+		pkg.Relationship = relationship(isDirect)
+		packages[key] = pkg
+	}
+
+	var root string
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "[INFO] ")
+		trimmed := strings.TrimSpace(line)
+
+		if matched := digraphRe.FindStringSubmatch(trimmed); matched != nil {
+			root = matched[1]
+
+			continue
+		}
+
+		if matched := edgeRe.FindStringSubmatch(trimmed); matched != nil {
+			if pkg, ok := parseMavenTreeCoordinate(matched[2]); ok {
+				addPackage(pkg, matched[1] == root)
+			}
+
+			continue
+		}
+
+		if pkg, depth, ok := parseMavenTreeLine(line); ok {
+			addPackage(pkg, depth == 1)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	return maps.Values(packages), nil
+}
+
+var _ Extractor = MavenDependencyTreeExtractor{}
+
+func ParseMavenDependencyTree(pathToTree string) ([]PackageDetails, error) {
+	return extractFromFile(pathToTree, MavenDependencyTreeExtractor{})
+}
+
+// FromMavenDependencyTree attempts to parse the given file as the output of `mvn dependency:tree`
+func FromMavenDependencyTree(pathToTree string) (Lockfile, error) {
+	packages, err := ParseMavenDependencyTree(pathToTree)
+
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name == packages[j].Name {
+			return packages[i].Version < packages[j].Version
+		}
+
+		return packages[i].Name < packages[j].Name
+	})
+
+	return Lockfile{
+		FilePath: pathToTree,
+		ParsedAs: "maven-dependency-tree",
+		Packages: packages,
+	}, err
+}
@@ -0,0 +1,223 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestMavenDependencyTreeExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	e := lockfile.MavenDependencyTreeExtractor{}
+
+	for _, path := range []string{"", "tree.txt", "path/to/my/dependency-tree.txt", "path/to/my/tree.dot"} {
+		if e.ShouldExtract(path) {
+			t.Errorf("ShouldExtract(%q) = true, want false", path)
+		}
+	}
+}
+
+func TestParseMavenDependencyTree_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseMavenDependencyTree("fixtures/maven-dependency-tree/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseMavenDependencyTree_Empty(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseMavenDependencyTree("fixtures/maven-dependency-tree/empty.txt")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseMavenDependencyTree_NoDependencies(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseMavenDependencyTree("fixtures/maven-dependency-tree/no-dependencies.txt")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseMavenDependencyTree_Text(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseMavenDependencyTree("fixtures/maven-dependency-tree/tree.txt")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:         "org.springframework:spring-core",
+			Version:      "5.3.9",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "org.springframework:spring-jcl",
+			Version:      "5.3.9",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "org.apache.logging.log4j:log4j-core",
+			Version:      "2.14.1",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			DepGroups:    []string{"runtime"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "org.apache.logging.log4j:log4j-api",
+			Version:      "2.14.1",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			DepGroups:    []string{"runtime"},
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "io.netty:netty-transport-native-epoll",
+			Version:      "4.1.65.Final",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "io.netty:netty-common",
+			Version:      "4.1.65.Final",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "javax.servlet:javax.servlet-api",
+			Version:      "4.0.1",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			DepGroups:    []string{"provided"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "junit:junit",
+			Version:      "4.13.2",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			DepGroups:    []string{"test"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "org.hamcrest:hamcrest-core",
+			Version:      "1.3",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			DepGroups:    []string{"test"},
+			Relationship: lockfile.TransitiveDependency,
+		},
+	})
+}
+
+func TestParseMavenDependencyTree_OutputFile(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseMavenDependencyTree("fixtures/maven-dependency-tree/tree-output-file.txt")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:         "org.springframework:spring-core",
+			Version:      "5.3.9",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			// a direct dependency of the project, as well as of spring-core
+			Name:         "org.springframework:spring-jcl",
+			Version:      "5.3.9",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+	})
+}
+
+func TestParseMavenDependencyTree_Dot(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseMavenDependencyTree("fixtures/maven-dependency-tree/tree.dot")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:         "org.springframework:spring-core",
+			Version:      "5.3.9",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "org.springframework:spring-jcl",
+			Version:      "5.3.9",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "junit:junit",
+			Version:      "4.13.2",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			DepGroups:    []string{"test"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "org.hamcrest:hamcrest-core",
+			Version:      "1.3",
+			Ecosystem:    lockfile.MavenEcosystem,
+			CompareAs:    lockfile.MavenEcosystem,
+			DepGroups:    []string{"test"},
+			Relationship: lockfile.TransitiveDependency,
+		},
+	})
+}
+
+func TestFromMavenDependencyTree(t *testing.T) {
+	t.Parallel()
+
+	lockf, err := lockfile.FromMavenDependencyTree("fixtures/maven-dependency-tree/tree-output-file.txt")
+
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if lockf.ParsedAs != "maven-dependency-tree" {
+		t.Errorf("Expected ParsedAs to be maven-dependency-tree, but got %s", lockf.ParsedAs)
+	}
+
+	if len(lockf.Packages) != 2 || lockf.Packages[0].Name != "org.springframework:spring-core" {
+		t.Errorf("Expected packages to be sorted by name, but got %v", lockf.Packages)
+	}
+}
@@ -0,0 +1,213 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type nodeModulesWalker struct {
+	// the real paths of the packages that have been walked, to avoid walking
+	// symlinked packages more than once
+	seen     map[string]struct{}
+	packages map[string]PackageDetails
+}
+
+// isInstalledPackage returns true if the real path of a package is within a
+// node_modules directory, rather than being a link to a local package (e.g. a workspace)
+func isInstalledPackage(realPath string) bool {
+	return slices.Contains(strings.Split(filepath.ToSlash(realPath), "/"), "node_modules")
+}
+
+// walkNodeModules walks the packages installed in the given node_modules directory,
+// including those within scoped directories and nested node_modules directories
+func (w *nodeModulesWalker) walkNodeModules(dir string) error {
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(dir, name)
+
+		switch {
+		// pnpm keeps the actual packages in a virtual store, with each package
+		// having its dependencies as siblings within its own node_modules
+		case name == ".pnpm":
+			stored, err := os.ReadDir(path)
+
+			if err != nil {
+				return err
+			}
+
+			for _, s := range stored {
+				if err := w.walkNodeModules(filepath.Join(path, s.Name(), "node_modules")); err != nil {
+					return err
+				}
+			}
+		// other dot directories (and files) such as .bin are not packages
+		case strings.HasPrefix(name, "."):
+			continue
+		case strings.HasPrefix(name, "@"):
+			scoped, err := os.ReadDir(path)
+
+			if err != nil {
+				return err
+			}
+
+			for _, s := range scoped {
+				if err := w.walkPackage(filepath.Join(path, s.Name()), name+"/"+s.Name()); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := w.walkPackage(path, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkPackage reads the package.json of the package installed at the given path,
+// and then walks any packages installed within its own node_modules directory
+func (w *nodeModulesWalker) walkPackage(path string, name string) error {
+	realPath, err := filepath.EvalSymlinks(path)
+
+	if err != nil {
+		// broken symlinks cannot be packages
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if _, ok := w.seen[realPath]; ok {
+		return nil
+	}
+
+	w.seen[realPath] = struct{}{}
+
+	info, err := os.Stat(realPath)
+
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return nil
+	}
+
+	if isInstalledPackage(realPath) {
+		pkg, err := readNodePackageManifest(filepath.Join(realPath, "package.json"), name)
+
+		if err != nil {
+			return err
+		}
+
+		if pkg.Version != "" {
+			w.packages[pkg.Name+"@"+pkg.Version] = pkg
+		}
+	}
+
+	return w.walkNodeModules(filepath.Join(realPath, "node_modules"))
+}
+
+// readNodePackageManifest reads the name and version of an installed package from its package.json,
+// using the given name if the package.json does not have one
+func readNodePackageManifest(pathToManifest string, name string) (PackageDetails, error) {
+	b, err := os.ReadFile(pathToManifest)
+
+	if err != nil {
+		// directories without a package.json are not packages
+		if os.IsNotExist(err) {
+			return PackageDetails{}, nil
+		}
+
+		return PackageDetails{}, err
+	}
+
+	var manifest struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return PackageDetails{}, fmt.Errorf("could not parse %s: %w", pathToManifest, err)
+	}
+
+	if manifest.Name != "" {
+		name = manifest.Name
+	}
+
+	return PackageDetails{
+		Name:      name,
+		Version:   manifest.Version,
+		Ecosystem: NpmEcosystem,
+		CompareAs: NpmEcosystem,
+	}, nil
+}
+
+// ParseNodeModules walks the packages installed in a node_modules directory (or the node_modules
+// directory of the given project) by reading the package.json of each of them, including those
+// in nested node_modules directories
+func ParseNodeModules(pathToNodeModules string) ([]PackageDetails, error) {
+	if filepath.Base(pathToNodeModules) != "node_modules" {
+		pathToNodeModules = filepath.Join(pathToNodeModules, "node_modules")
+	}
+
+	w := nodeModulesWalker{
+		seen:     map[string]struct{}{},
+		packages: map[string]PackageDetails{},
+	}
+
+	_, err := os.Stat(pathToNodeModules)
+
+	if err == nil {
+		err = w.walkNodeModules(pathToNodeModules)
+	}
+
+	packages := make([]PackageDetails, 0, len(w.packages))
+
+	for _, pkg := range w.packages {
+		packages = append(packages, pkg)
+	}
+
+	if err != nil {
+		return packages, fmt.Errorf("could not extract from %s: %w", pathToNodeModules, err)
+	}
+
+	return packages, nil
+}
+
+// FromNodeModules attempts to parse the given directory as a "node-modules" lockfile,
+// by reading the package.json of each package installed within it.
+func FromNodeModules(pathToNodeModules string) (Lockfile, error) {
+	packages, err := ParseNodeModules(pathToNodeModules)
+
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name == packages[j].Name {
+			return packages[i].Version < packages[j].Version
+		}
+
+		return packages[i].Name < packages[j].Name
+	})
+
+	return Lockfile{
+		FilePath: pathToNodeModules,
+		ParsedAs: "node-modules",
+		Packages: packages,
+	}, err
+}
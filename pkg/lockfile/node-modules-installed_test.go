@@ -0,0 +1,120 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestParseNodeModules_DirectoryDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNodeModules("fixtures/node-modules/does-not-exist/node_modules")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseNodeModules_NoNodeModules(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNodeModules("fixtures/node-modules/empty")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseNodeModules_Project(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNodeModules("fixtures/node-modules/project")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "@babel/code-frame",
+			Version:   "7.16.7",
+			Ecosystem: lockfile.NpmEcosystem,
+			CompareAs: lockfile.NpmEcosystem,
+		},
+		{
+			Name:      "lodash",
+			Version:   "4.17.21",
+			Ecosystem: lockfile.NpmEcosystem,
+			CompareAs: lockfile.NpmEcosystem,
+		},
+		{
+			Name:      "once",
+			Version:   "1.4.0",
+			Ecosystem: lockfile.NpmEcosystem,
+			CompareAs: lockfile.NpmEcosystem,
+		},
+		{
+			Name:      "unnamed",
+			Version:   "2.0.0",
+			Ecosystem: lockfile.NpmEcosystem,
+			CompareAs: lockfile.NpmEcosystem,
+		},
+		{
+			Name:      "wrappy",
+			Version:   "1.0.2",
+			Ecosystem: lockfile.NpmEcosystem,
+			CompareAs: lockfile.NpmEcosystem,
+		},
+		{
+			Name:      "wrappy",
+			Version:   "1.0.1",
+			Ecosystem: lockfile.NpmEcosystem,
+			CompareAs: lockfile.NpmEcosystem,
+		},
+	})
+}
+
+func TestParseNodeModules_NodeModulesDirectory(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNodeModules("fixtures/node-modules/project/node_modules")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	if len(packages) != 6 {
+		t.Errorf("Expected to get 6 packages, but got %d", len(packages))
+	}
+}
+
+func TestParseNodeModules_Pnpm(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNodeModules("fixtures/node-modules/pnpm-project")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "is-number",
+			Version:   "6.0.0",
+			Ecosystem: lockfile.NpmEcosystem,
+			CompareAs: lockfile.NpmEcosystem,
+		},
+		{
+			Name:      "is-odd",
+			Version:   "3.0.1",
+			Ecosystem: lockfile.NpmEcosystem,
+			CompareAs: lockfile.NpmEcosystem,
+		},
+		{
+			Name:      "left-pad",
+			Version:   "1.3.0",
+			Ecosystem: lockfile.NpmEcosystem,
+			CompareAs: lockfile.NpmEcosystem,
+		},
+	})
+}
@@ -38,10 +38,11 @@ func TestNodeModulesExtractor_Extract_npm_v2_OnePackage(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "wrappy",
-			Version:   "1.0.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -57,11 +58,12 @@ func TestNodeModulesExtractor_Extract_npm_v2_OnePackageDev(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "wrappy",
-			Version:   "1.0.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			DepGroups: []string{"dev"},
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -77,16 +79,18 @@ func TestNodeModulesExtractor_Extract_npm_v2_TwoPackages(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "wrappy",
-			Version:   "1.0.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "supports-color",
-			Version:   "5.5.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "supports-color",
+			Version:      "5.5.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -102,16 +106,18 @@ func TestNodeModulesExtractor_Extract_npm_v2_ScopedPackages(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "wrappy",
-			Version:   "1.0.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@babel/code-frame",
-			Version:   "7.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "@babel/code-frame",
+			Version:      "7.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -127,34 +133,42 @@ func TestNodeModulesExtractor_Extract_npm_v2_NestedDependencies(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "postcss",
-			Version:   "6.0.23",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "postcss",
+			Version:      "6.0.23",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+			Dependencies: []string{"supports-color@5.5.0"},
 		},
 		{
-			Name:      "postcss",
-			Version:   "7.0.16",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "postcss",
+			Version:      "7.0.16",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+			Dependencies: []string{"supports-color@6.1.0"},
 		},
 		{
-			Name:      "postcss-calc",
-			Version:   "7.0.1",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "postcss-calc",
+			Version:      "7.0.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+			Dependencies: []string{"postcss@7.0.16"},
 		},
 		{
-			Name:      "supports-color",
-			Version:   "6.1.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "supports-color",
+			Version:      "6.1.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "supports-color",
-			Version:   "5.5.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "supports-color",
+			Version:      "5.5.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -170,16 +184,18 @@ func TestNodeModulesExtractor_Extract_npm_v2_NestedDependenciesDup(t *testing.T)
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "supports-color",
-			Version:   "6.1.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "supports-color",
+			Version:      "6.1.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "supports-color",
-			Version:   "2.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "supports-color",
+			Version:      "2.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -195,112 +211,126 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "@segment/analytics.js-integration-facebook-pixel",
-			Version:   "2.4.1",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "3b1bb80b302c2e552685dc8a029797ec832ea7c9",
-		},
-		{
-			Name:      "ansi-styles",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
-		},
-		{
-			Name:      "babel-preset-php",
-			Version:   "1.1.1",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "c5a7ba5e0ad98b8db1cb8ce105403dd4b768cced",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-1",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "af885e2e890b9ef0875edd2b117305119ee5bdc5",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-1",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "be5935f8d2595bcd97b05718ef1eeae08d812e10",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-2",
-			Version:   "2.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-2",
-			Version:   "2.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "82dcc8e914dabd9305ab9ae580709a7825e824f5",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-3",
-			Version:   "2.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-3",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "82ae8802978da40d7f1be5ad5943c9e550ab2c89",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-4",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "af885e2e890b9ef0875edd2b117305119ee5bdc5",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-5",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "af885e2e890b9ef0875edd2b117305119ee5bdc5",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "postcss-calc",
-			Version:   "7.0.1",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
-		},
-		{
-			Name:      "raven-js",
-			Version:   "",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "c2b377e7a254264fd4a1fe328e4e3cfc9e245570",
-		},
-		{
-			Name:      "slick-carousel",
-			Version:   "1.7.1",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "280b560161b751ba226d50c7db1e0a14a78c2de0",
-			DepGroups: []string{"dev"},
+			Name:         "@segment/analytics.js-integration-facebook-pixel",
+			Version:      "2.4.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "3b1bb80b302c2e552685dc8a029797ec832ea7c9",
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "ansi-styles",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "babel-preset-php",
+			Version:      "1.1.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "c5a7ba5e0ad98b8db1cb8ce105403dd4b768cced",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "is-number-1",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "is-number-1",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "be5935f8d2595bcd97b05718ef1eeae08d812e10",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "is-number-2",
+			Version:      "2.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "is-number-2",
+			Version:      "2.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "82dcc8e914dabd9305ab9ae580709a7825e824f5",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "is-number-3",
+			Version:      "2.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "is-number-3",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "82ae8802978da40d7f1be5ad5943c9e550ab2c89",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "is-number-4",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "is-number-5",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "postcss-calc",
+			Version:      "7.0.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "raven-js",
+			Version:      "",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "c2b377e7a254264fd4a1fe328e4e3cfc9e245570",
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "slick-carousel",
+			Version:      "1.7.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "280b560161b751ba226d50c7db1e0a14a78c2de0",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -316,28 +346,31 @@ func TestNodeModulesExtractor_Extract_npm_v2_Files(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "etag",
-			Version:   "1.8.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
-			DepGroups: []string{"dev"},
+			Name:         "etag",
+			Version:      "1.8.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "abbrev",
-			Version:   "1.0.9",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
-			DepGroups: []string{"dev"},
+			Name:         "abbrev",
+			Version:      "1.0.9",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "abbrev",
-			Version:   "2.3.4",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
-			DepGroups: []string{"dev"},
+			Name:         "abbrev",
+			Version:      "2.3.4",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -353,22 +386,25 @@ func TestNodeModulesExtractor_Extract_npm_v2_Alias(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "@babel/code-frame",
-			Version:   "7.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "@babel/code-frame",
+			Version:      "7.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "string-width",
-			Version:   "4.2.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "string-width",
+			Version:      "4.2.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "string-width",
-			Version:   "5.1.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "string-width",
+			Version:      "5.1.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -384,18 +420,20 @@ func TestNodeModulesExtractor_Extract_npm_v2_OptionalPackage(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "wrappy",
-			Version:   "1.0.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			DepGroups: []string{"optional"},
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			DepGroups:    []string{"optional"},
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "supports-color",
-			Version:   "5.5.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			DepGroups: []string{"dev", "optional"},
+			Name:         "supports-color",
+			Version:      "5.5.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			DepGroups:    []string{"dev", "optional"},
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
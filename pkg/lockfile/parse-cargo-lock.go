@@ -3,6 +3,8 @@ package lockfile
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 )
@@ -10,6 +12,11 @@ import (
 type CargoLockPackage struct {
 	Name    string `toml:"name"`
 	Version string `toml:"version"`
+	Source  string `toml:"source"`
+
+	// Dependencies are like "name", or "name version" or "name version (source)"
+	// when there is more than one package with the name
+	Dependencies []string `toml:"dependencies"`
 }
 
 type CargoLockFile struct {
@@ -19,6 +26,60 @@ type CargoLockFile struct {
 
 const CargoEcosystem Ecosystem = "crates.io"
 
+// cratesIOSources are the sources of packages that come from the crates.io registry
+var cratesIOSources = []string{
+	"registry+https://github.com/rust-lang/crates.io-index",
+	"sparse+https://index.crates.io/",
+}
+
+// resolve returns the version and commit that the package should be matched by, based on where it
+// comes from: only packages from crates.io have a version that can be matched against its advisories,
+// git packages are matched by the commit they are pinned to, and other packages (such as path
+// dependencies, the members of a workspace, and packages from other registries) cannot be matched
+func (pkg CargoLockPackage) resolve() (string, string) {
+	switch {
+	case slices.Contains(cratesIOSources, pkg.Source):
+		return pkg.Version, ""
+	case strings.HasPrefix(pkg.Source, "git+"):
+		_, commit, _ := strings.Cut(pkg.Source, "#")
+
+		return "", commit
+	default:
+		return "", ""
+	}
+}
+
+// cargoDependencies returns the DependencyKey of each package that the package at index i depends on,
+// given the details that each of the packages in the lockfile were extracted as
+func cargoDependencies(lockPackages []CargoLockPackage, packages []PackageDetails, i int) []string {
+	keys := make([]string, 0, len(lockPackages[i].Dependencies))
+
+	for _, dependency := range lockPackages[i].Dependencies {
+		fields := strings.Fields(dependency)
+		if len(fields) == 0 {
+			continue
+		}
+
+		for j, lockPackage := range lockPackages {
+			if lockPackage.Name != fields[0] || (len(fields) > 1 && lockPackage.Version != fields[1]) {
+				continue
+			}
+
+			keys = append(keys, packages[j].DependencyKey())
+
+			break
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	slices.Sort(keys)
+
+	return slices.Compact(keys)
+}
+
 type CargoLockExtractor struct{}
 
 func (e CargoLockExtractor) ShouldExtract(path string) bool {
@@ -37,14 +98,21 @@ func (e CargoLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 	packages := make([]PackageDetails, 0, len(parsedLockfile.Packages))
 
 	for _, lockPackage := range parsedLockfile.Packages {
+		version, commit := lockPackage.resolve()
+
 		packages = append(packages, PackageDetails{
 			Name:      lockPackage.Name,
-			Version:   lockPackage.Version,
+			Version:   version,
 			Ecosystem: CargoEcosystem,
 			CompareAs: CargoEcosystem,
+			Commit:    commit,
 		})
 	}
 
+	for i := range packages {
+		packages[i].Dependencies = cargoDependencies(parsedLockfile.Packages, packages, i)
+	}
+
 	return packages, nil
 }
 
@@ -151,7 +151,7 @@ func TestParseCargoLock_TwoPackagesWithLocal(t *testing.T) {
 		},
 		{
 			Name:      "local-rust-pkg",
-			Version:   "0.1.0",
+			Version:   "",
 			Ecosystem: lockfile.CargoEcosystem,
 			CompareAs: lockfile.CargoEcosystem,
 		},
@@ -176,3 +176,55 @@ func TestParseCargoLock_PackageWithBuildString(t *testing.T) {
 		},
 	})
 }
+
+func TestParseCargoLock_Workspace(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseCargoLock("fixtures/cargo/workspace.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "addr2line",
+			Version:   "0.15.2",
+			Ecosystem: lockfile.CargoEcosystem,
+			CompareAs: lockfile.CargoEcosystem,
+		},
+		{
+			Name:      "gimli",
+			Version:   "0.24.0",
+			Ecosystem: lockfile.CargoEcosystem,
+			CompareAs: lockfile.CargoEcosystem,
+		},
+		{
+			Name:         "my-app",
+			Version:      "",
+			Ecosystem:    lockfile.CargoEcosystem,
+			CompareAs:    lockfile.CargoEcosystem,
+			Dependencies: []string{"addr2line@0.15.2", "internal-utils", "my-lib", "regex@9f9f693768c584971a4d53bc3c586c33ed3a6831"},
+		},
+		{
+			Name:         "my-lib",
+			Version:      "",
+			Ecosystem:    lockfile.CargoEcosystem,
+			CompareAs:    lockfile.CargoEcosystem,
+			Dependencies: []string{"gimli@0.24.0"},
+		},
+		{
+			Name:      "regex",
+			Version:   "",
+			Ecosystem: lockfile.CargoEcosystem,
+			CompareAs: lockfile.CargoEcosystem,
+			Commit:    "9f9f693768c584971a4d53bc3c586c33ed3a6831",
+		},
+		{
+			Name:      "internal-utils",
+			Version:   "",
+			Ecosystem: lockfile.CargoEcosystem,
+			CompareAs: lockfile.CargoEcosystem,
+		},
+	})
+}
@@ -0,0 +1,81 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ComposerJSON contains the dependencies declared by a Composer manifest, as defined in
+// https://getcomposer.org/doc/04-schema.md
+type ComposerJSON struct {
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+// isComposerPlatformPackage returns true if the name is that of a platform package
+// such as "php" or "ext-json", which are provided by the system rather than Packagist
+func isComposerPlatformPackage(name string) bool {
+	return !strings.Contains(name, "/")
+}
+
+func parseComposerJSONRequirements(requirements map[string]string, depGroups []string) []PackageDetails {
+	packages := make([]PackageDetails, 0, len(requirements))
+
+	for name, constraint := range requirements {
+		if isComposerPlatformPackage(name) {
+			continue
+		}
+
+		packages = append(packages, PackageDetails{
+			Name:              name,
+			Ecosystem:         ComposerEcosystem,
+			CompareAs:         ComposerEcosystem,
+			DepGroups:         depGroups,
+			Relationship:      DirectDependency,
+			VersionConstraint: constraint,
+		})
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+
+	return packages
+}
+
+type ComposerJSONExtractor struct{}
+
+func (e ComposerJSONExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "composer.json"
+}
+
+// Extract returns the packages required by the manifest without a version, as only the
+// constraints on their versions are known until they are resolved against Packagist
+func (e ComposerJSONExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	var parsedManifest *ComposerJSON
+
+	err := json.NewDecoder(f).Decode(&parsedManifest)
+
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+
+	packages := parseComposerJSONRequirements(parsedManifest.Require, nil)
+	packages = append(packages, parseComposerJSONRequirements(parsedManifest.RequireDev, []string{"dev"})...)
+
+	return packages, nil
+}
+
+var _ Extractor = ComposerJSONExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("composer.json", ComposerJSONExtractor{})
+}
+
+func ParseComposerJSON(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, ComposerJSONExtractor{})
+}
@@ -0,0 +1,125 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestComposerJSONExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "composer.json",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/composer.json",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/composer.json/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/composer.json.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.composer.json",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.ComposerJSONExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseComposerJSON_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseComposerJSON("fixtures/composer-json/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseComposerJSON_InvalidJson(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseComposerJSON("fixtures/composer-json/not-json.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseComposerJSON_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseComposerJSON("fixtures/composer-json/empty.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseComposerJSON_RequireAndDev(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseComposerJSON("fixtures/composer-json/require-and-dev.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:              "guzzlehttp/guzzle",
+			Ecosystem:         lockfile.ComposerEcosystem,
+			CompareAs:         lockfile.ComposerEcosystem,
+			Relationship:      lockfile.DirectDependency,
+			VersionConstraint: "^7.4",
+		},
+		{
+			Name:              "monolog/monolog",
+			Ecosystem:         lockfile.ComposerEcosystem,
+			CompareAs:         lockfile.ComposerEcosystem,
+			Relationship:      lockfile.DirectDependency,
+			VersionConstraint: "~2.9.1",
+		},
+		{
+			Name:              "phpunit/phpunit",
+			Ecosystem:         lockfile.ComposerEcosystem,
+			CompareAs:         lockfile.ComposerEcosystem,
+			DepGroups:         []string{"dev"},
+			Relationship:      lockfile.DirectDependency,
+			VersionConstraint: "^10.0 || ^11.0",
+		},
+	})
+}
@@ -22,6 +22,26 @@ func deduplicatePackages(packages map[string]PackageDetails) map[string]PackageD
 	return details
 }
 
+// goModIgnoreComments returns the vulnerabilities ignored by comments
+// directly before or at the end of the given go.mod line
+func goModIgnoreComments(line *modfile.Line) []IgnoreComment {
+	if line == nil {
+		return nil
+	}
+
+	var ignores []IgnoreComment
+
+	for _, comment := range line.Before {
+		ignores = append(ignores, parseIgnoreComments(comment.Token)...)
+	}
+
+	for _, comment := range line.Suffix {
+		ignores = append(ignores, parseIgnoreComments(comment.Token)...)
+	}
+
+	return ignores
+}
+
 type GoLockExtractor struct{}
 
 func (e GoLockExtractor) ShouldExtract(path string) bool {
@@ -49,6 +69,7 @@ func (e GoLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 			Version:   strings.TrimPrefix(require.Mod.Version, "v"),
 			Ecosystem: GoEcosystem,
 			CompareAs: GoEcosystem,
+			Ignores:   goModIgnoreComments(require.Syntax),
 		}
 	}
 
@@ -79,6 +100,7 @@ func (e GoLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 				Version:   strings.TrimPrefix(replace.New.Version, "v"),
 				Ecosystem: GoEcosystem,
 				CompareAs: GoEcosystem,
+				Ignores:   goModIgnoreComments(replace.Syntax),
 			}
 		}
 	}
@@ -325,3 +325,41 @@ func TestParseGoLock_Replacements_NoVersion(t *testing.T) {
 		},
 	})
 }
+
+func TestParseGoLock_WithIgnoreComments(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoLock("fixtures/go/with-ignore-comments.mod")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "github.com/BurntSushi/toml",
+			Version:   "1.0.0",
+			Ecosystem: lockfile.GoEcosystem,
+			CompareAs: lockfile.GoEcosystem,
+			Ignores: []lockfile.IgnoreComment{
+				{ID: "GO-2022-0001", Reason: "only used in tests"},
+			},
+		},
+		{
+			Name:      "gopkg.in/yaml.v2",
+			Version:   "2.4.0",
+			Ecosystem: lockfile.GoEcosystem,
+			CompareAs: lockfile.GoEcosystem,
+			Ignores: []lockfile.IgnoreComment{
+				{ID: "GHSA-xxxx-xxxx-xxxx", Reason: ""},
+				{ID: "GO-2022-0002", Reason: ""},
+			},
+		},
+		{
+			Name:      "golang.org/x/mod",
+			Version:   "0.4.2",
+			Ecosystem: lockfile.GoEcosystem,
+			CompareAs: lockfile.GoEcosystem,
+		},
+	})
+}
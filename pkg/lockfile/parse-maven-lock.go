@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/google/osv-scanner/internal/cachedregexp"
@@ -31,41 +32,80 @@ func (mld MavenLockDependency) parseResolvedVersion(version string) string {
 	return results[1]
 }
 
-func (mld MavenLockDependency) resolveVersionValue(lockfile MavenLockFile) string {
-	interpolationReg := cachedregexp.MustCompile(`\${(.+)}`)
+// maxPropertyInterpolationDepth is how deeply properties referencing other properties are resolved
+const maxPropertyInterpolationDepth = 10
 
-	results := interpolationReg.FindStringSubmatch(mld.Version)
+// interpolate replaces the ${property} placeholders in the given value, returning
+// the name of the first property that could not be resolved if there is one
+func (lockfile MavenLockFile) interpolate(value string) (string, string) {
+	interpolationReg := cachedregexp.MustCompile(`\${([^}]+)}`)
 
-	// no interpolation, so just return the version as-is
-	if results == nil {
-		return mld.Version
-	}
-	if val, ok := lockfile.Properties.m[results[1]]; ok {
-		return val
+	for i := 0; i < maxPropertyInterpolationDepth; i++ {
+		if !interpolationReg.MatchString(value) {
+			return value, ""
+		}
+
+		missing := ""
+		value = interpolationReg.ReplaceAllStringFunc(value, func(match string) string {
+			name := interpolationReg.FindStringSubmatch(match)[1]
+			if val, ok := lockfile.property(name); ok {
+				return val
+			}
+
+			if missing == "" {
+				missing = name
+			}
+
+			return match
+		})
+
+		if missing != "" {
+			return value, missing
+		}
 	}
 
-	fmt.Fprintf(
-		os.Stderr,
-		"Failed to resolve version of %s: property \"%s\" could not be found for \"%s\"\n",
-		mld.GroupID+":"+mld.ArtifactID,
-		results[1],
-		lockfile.GroupID+":"+lockfile.ArtifactID,
-	)
+	return value, interpolationReg.FindStringSubmatch(value)[1]
+}
 
-	return "0"
+// resolveVersionValue returns the version of the dependency with any properties
+// interpolated, along with the name of the first property that could not be resolved
+func (mld MavenLockDependency) resolveVersionValue(lockfile MavenLockFile) (string, string) {
+	return lockfile.interpolate(mld.Version)
 }
 
 func (mld MavenLockDependency) ResolveVersion(lockfile MavenLockFile) string {
-	version := mld.resolveVersionValue(lockfile)
+	version, missing := mld.resolveVersionValue(lockfile)
+
+	if missing != "" {
+		fmt.Fprintf(
+			os.Stderr,
+			"Failed to resolve version of %s: property \"%s\" could not be found for \"%s\"\n",
+			mld.GroupID+":"+mld.ArtifactID,
+			missing,
+			lockfile.GroupID+":"+lockfile.ArtifactID,
+		)
+
+		return "0"
+	}
 
 	return mld.parseResolvedVersion(version)
 }
 
+// MavenLockParent is the parent of a project, which it inherits properties and dependencies from
+type MavenLockParent struct {
+	GroupID      string `xml:"groupId"`
+	ArtifactID   string `xml:"artifactId"`
+	Version      string `xml:"version"`
+	RelativePath string `xml:"relativePath"`
+}
+
 type MavenLockFile struct {
 	XMLName             xml.Name              `xml:"project"`
 	ModelVersion        string                `xml:"modelVersion"`
 	GroupID             string                `xml:"groupId"`
 	ArtifactID          string                `xml:"artifactId"`
+	Version             string                `xml:"version"`
+	Parent              MavenLockParent       `xml:"parent"`
 	Properties          MavenLockProperties   `xml:"properties"`
 	Dependencies        []MavenLockDependency `xml:"dependencies>dependency"`
 	ManagedDependencies []MavenLockDependency `xml:"dependencyManagement>dependencies>dependency"`
@@ -104,6 +144,154 @@ func (p *MavenLockProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElemen
 	}
 }
 
+// property returns the value of the given property, including the built-in project properties
+func (lockfile MavenLockFile) property(name string) (string, bool) {
+	switch name {
+	case "project.groupId", "pom.groupId":
+		return lockfile.GroupID, lockfile.GroupID != ""
+	case "project.artifactId", "pom.artifactId":
+		return lockfile.ArtifactID, lockfile.ArtifactID != ""
+	case "project.version", "pom.version", "version":
+		return lockfile.Version, lockfile.Version != ""
+	case "project.parent.groupId", "parent.groupId":
+		return lockfile.Parent.GroupID, lockfile.Parent.GroupID != ""
+	case "project.parent.version", "parent.version":
+		return lockfile.Parent.Version, lockfile.Parent.Version != ""
+	}
+
+	val, ok := lockfile.Properties.m[name]
+
+	return val, ok
+}
+
+// maxParentDepth is how many levels of parents are resolved, to guard against cycles
+const maxParentDepth = 10
+
+// openParent opens the pom.xml of the parent of the project from the local filesystem,
+// resolving up to depth levels of its own parents, and returning nil if the parent
+// is not available locally
+func (lockfile MavenLockFile) openParent(f DepFile, depth int) *MavenLockFile {
+	if lockfile.Parent.ArtifactID == "" {
+		return nil
+	}
+
+	relativePath := lockfile.Parent.RelativePath
+	if relativePath == "" {
+		relativePath = "../pom.xml"
+	}
+	if filepath.Ext(relativePath) != ".xml" {
+		relativePath = filepath.Join(relativePath, "pom.xml")
+	}
+
+	pf, err := f.Open(relativePath)
+	if err != nil {
+		return nil
+	}
+	defer pf.Close()
+
+	var parent *MavenLockFile
+	if err := xml.NewDecoder(pf).Decode(&parent); err != nil {
+		return nil
+	}
+
+	// the pom at the relative path is only the parent if it has the expected coordinates
+	if parent.ArtifactID != lockfile.Parent.ArtifactID {
+		return nil
+	}
+
+	return parent.withParents(pf, depth-1)
+}
+
+// withParents returns the project after inheriting the properties and dependencies of its local parents
+func (lockfile *MavenLockFile) withParents(f DepFile, depth int) *MavenLockFile {
+	if depth <= 0 {
+		return lockfile
+	}
+
+	if lockfile.GroupID == "" {
+		lockfile.GroupID = lockfile.Parent.GroupID
+	}
+	if lockfile.Version == "" {
+		lockfile.Version = lockfile.Parent.Version
+	}
+
+	parent := lockfile.openParent(f, depth)
+
+	if parent == nil {
+		return lockfile
+	}
+
+	properties := maps.Clone(parent.Properties.m)
+	if properties == nil {
+		properties = map[string]string{}
+	}
+	maps.Copy(properties, lockfile.Properties.m)
+	lockfile.Properties.m = properties
+
+	// dependencies declared by the child take precedence over those it inherited
+	lockfile.ManagedDependencies = append(slices.Clone(parent.ManagedDependencies), lockfile.ManagedDependencies...)
+	lockfile.Dependencies = append(slices.Clone(parent.Dependencies), lockfile.Dependencies...)
+
+	return lockfile
+}
+
+// name returns the "groupId:artifactId" name of the dependency, which may also use properties
+func (mld MavenLockDependency) name(lockfile MavenLockFile) string {
+	groupID, _ := lockfile.interpolate(mld.GroupID)
+	artifactID, _ := lockfile.interpolate(mld.ArtifactID)
+
+	return groupID + ":" + artifactID
+}
+
+// toPackageDetails returns the details of the dependency, or false if its
+// version cannot be resolved statically in which case it is skipped
+func (mld MavenLockDependency) toPackageDetails(lockfile MavenLockFile, defaultScope string) (PackageDetails, bool) {
+	finalName := mld.name(lockfile)
+
+	if mld.Version == "" {
+		fmt.Fprintf(
+			os.Stderr,
+			"Skipping %s as its version could not be found for \"%s\"\n",
+			finalName,
+			lockfile.GroupID+":"+lockfile.ArtifactID,
+		)
+
+		return PackageDetails{}, false
+	}
+
+	version, missing := mld.resolveVersionValue(lockfile)
+
+	if missing != "" {
+		fmt.Fprintf(
+			os.Stderr,
+			"Skipping %s as its version could not be resolved: property \"%s\" could not be found for \"%s\"\n",
+			finalName,
+			missing,
+			lockfile.GroupID+":"+lockfile.ArtifactID,
+		)
+
+		return PackageDetails{}, false
+	}
+
+	pkgDetails := PackageDetails{
+		Name:      finalName,
+		Version:   mld.parseResolvedVersion(version),
+		Ecosystem: MavenEcosystem,
+		CompareAs: MavenEcosystem,
+	}
+
+	scope := strings.TrimSpace(mld.Scope)
+	if scope == "" {
+		scope = strings.TrimSpace(defaultScope)
+	}
+	if scope != "" && scope != "compile" {
+		// Only append non-default scope (compile is the default scope).
+		pkgDetails.DepGroups = append(pkgDetails.DepGroups, scope)
+	}
+
+	return pkgDetails, true
+}
+
 type MavenLockExtractor struct{}
 
 func (e MavenLockExtractor) ShouldExtract(path string) bool {
@@ -119,38 +307,32 @@ func (e MavenLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
 	}
 
+	parsedLockfile = parsedLockfile.withParents(f, maxParentDepth)
+
 	details := map[string]PackageDetails{}
+	managed := map[string]MavenLockDependency{}
 
 	for _, lockPackage := range parsedLockfile.ManagedDependencies {
-		finalName := lockPackage.GroupID + ":" + lockPackage.ArtifactID
-		pkgDetails := PackageDetails{
-			Name:      finalName,
-			Version:   lockPackage.ResolveVersion(*parsedLockfile),
-			Ecosystem: MavenEcosystem,
-			CompareAs: MavenEcosystem,
-		}
-		if scope := strings.TrimSpace(lockPackage.Scope); scope != "" && scope != "compile" {
-			// Only append non-default scope (compile is the default scope).
-			pkgDetails.DepGroups = append(pkgDetails.DepGroups, scope)
+		finalName := lockPackage.name(*parsedLockfile)
+		managed[finalName] = lockPackage
+
+		if pkgDetails, ok := lockPackage.toPackageDetails(*parsedLockfile, ""); ok {
+			details[finalName] = pkgDetails
 		}
-		details[finalName] = pkgDetails
 	}
 
 	// standard dependencies take precedent over managed dependencies
 	for _, lockPackage := range parsedLockfile.Dependencies {
-		finalName := lockPackage.GroupID + ":" + lockPackage.ArtifactID
+		finalName := lockPackage.name(*parsedLockfile)
 
-		pkgDetails := PackageDetails{
-			Name:      finalName,
-			Version:   lockPackage.ResolveVersion(*parsedLockfile),
-			Ecosystem: MavenEcosystem,
-			CompareAs: MavenEcosystem,
+		// dependencies without a version get it from the managed dependencies
+		if lockPackage.Version == "" {
+			lockPackage.Version = managed[finalName].Version
 		}
-		if scope := strings.TrimSpace(lockPackage.Scope); scope != "" && scope != "compile" {
-			// Only append non-default scope (compile is the default scope).
-			pkgDetails.DepGroups = append(pkgDetails.DepGroups, scope)
+
+		if pkgDetails, ok := lockPackage.toPackageDetails(*parsedLockfile, managed[finalName].Scope); ok {
+			details[finalName] = pkgDetails
 		}
-		details[finalName] = pkgDetails
 	}
 
 	return maps.Values(details), nil
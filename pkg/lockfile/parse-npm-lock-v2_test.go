@@ -48,10 +48,11 @@ func TestParseNpmLock_v2_OnePackage(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "wrappy",
-			Version:   "1.0.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -67,11 +68,12 @@ func TestParseNpmLock_v2_OnePackageDev(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "wrappy",
-			Version:   "1.0.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			DepGroups: []string{"dev"},
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -87,16 +89,18 @@ func TestParseNpmLock_v2_TwoPackages(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "wrappy",
-			Version:   "1.0.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "supports-color",
-			Version:   "5.5.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "supports-color",
+			Version:      "5.5.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -112,16 +116,18 @@ func TestParseNpmLock_v2_ScopedPackages(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "wrappy",
-			Version:   "1.0.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@babel/code-frame",
-			Version:   "7.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "@babel/code-frame",
+			Version:      "7.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -137,34 +143,42 @@ func TestParseNpmLock_v2_NestedDependencies(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "postcss",
-			Version:   "6.0.23",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "postcss",
+			Version:      "6.0.23",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+			Dependencies: []string{"supports-color@5.5.0"},
 		},
 		{
-			Name:      "postcss",
-			Version:   "7.0.16",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "postcss",
+			Version:      "7.0.16",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+			Dependencies: []string{"supports-color@6.1.0"},
 		},
 		{
-			Name:      "postcss-calc",
-			Version:   "7.0.1",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "postcss-calc",
+			Version:      "7.0.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+			Dependencies: []string{"postcss@7.0.16"},
 		},
 		{
-			Name:      "supports-color",
-			Version:   "6.1.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "supports-color",
+			Version:      "6.1.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "supports-color",
-			Version:   "5.5.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "supports-color",
+			Version:      "5.5.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -180,16 +194,18 @@ func TestParseNpmLock_v2_NestedDependenciesDup(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "supports-color",
-			Version:   "6.1.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "supports-color",
+			Version:      "6.1.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "supports-color",
-			Version:   "2.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "supports-color",
+			Version:      "2.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -205,112 +221,126 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "@segment/analytics.js-integration-facebook-pixel",
-			Version:   "2.4.1",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "3b1bb80b302c2e552685dc8a029797ec832ea7c9",
-		},
-		{
-			Name:      "ansi-styles",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
-		},
-		{
-			Name:      "babel-preset-php",
-			Version:   "1.1.1",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "c5a7ba5e0ad98b8db1cb8ce105403dd4b768cced",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-1",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "af885e2e890b9ef0875edd2b117305119ee5bdc5",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-1",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "be5935f8d2595bcd97b05718ef1eeae08d812e10",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-2",
-			Version:   "2.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-2",
-			Version:   "2.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "82dcc8e914dabd9305ab9ae580709a7825e824f5",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-3",
-			Version:   "2.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-3",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "82ae8802978da40d7f1be5ad5943c9e550ab2c89",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-4",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "af885e2e890b9ef0875edd2b117305119ee5bdc5",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "is-number-5",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "af885e2e890b9ef0875edd2b117305119ee5bdc5",
-			DepGroups: []string{"dev"},
-		},
-		{
-			Name:      "postcss-calc",
-			Version:   "7.0.1",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
-		},
-		{
-			Name:      "raven-js",
-			Version:   "",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "c2b377e7a254264fd4a1fe328e4e3cfc9e245570",
-		},
-		{
-			Name:      "slick-carousel",
-			Version:   "1.7.1",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "280b560161b751ba226d50c7db1e0a14a78c2de0",
-			DepGroups: []string{"dev"},
+			Name:         "@segment/analytics.js-integration-facebook-pixel",
+			Version:      "2.4.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "3b1bb80b302c2e552685dc8a029797ec832ea7c9",
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "ansi-styles",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "babel-preset-php",
+			Version:      "1.1.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "c5a7ba5e0ad98b8db1cb8ce105403dd4b768cced",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "is-number-1",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "is-number-1",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "be5935f8d2595bcd97b05718ef1eeae08d812e10",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "is-number-2",
+			Version:      "2.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "is-number-2",
+			Version:      "2.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "82dcc8e914dabd9305ab9ae580709a7825e824f5",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "is-number-3",
+			Version:      "2.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "is-number-3",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "82ae8802978da40d7f1be5ad5943c9e550ab2c89",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "is-number-4",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "is-number-5",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "postcss-calc",
+			Version:      "7.0.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "raven-js",
+			Version:      "",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "c2b377e7a254264fd4a1fe328e4e3cfc9e245570",
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "slick-carousel",
+			Version:      "1.7.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "280b560161b751ba226d50c7db1e0a14a78c2de0",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -326,28 +356,31 @@ func TestParseNpmLock_v2_Files(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "etag",
-			Version:   "1.8.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
-			DepGroups: []string{"dev"},
+			Name:         "etag",
+			Version:      "1.8.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "abbrev",
-			Version:   "1.0.9",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
-			DepGroups: []string{"dev"},
+			Name:         "abbrev",
+			Version:      "1.0.9",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "abbrev",
-			Version:   "2.3.4",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
-			DepGroups: []string{"dev"},
+			Name:         "abbrev",
+			Version:      "2.3.4",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -363,22 +396,25 @@ func TestParseNpmLock_v2_Alias(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "@babel/code-frame",
-			Version:   "7.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "@babel/code-frame",
+			Version:      "7.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "string-width",
-			Version:   "4.2.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "string-width",
+			Version:      "4.2.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "string-width",
-			Version:   "5.1.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
+			Name:         "string-width",
+			Version:      "5.1.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -394,18 +430,97 @@ func TestParseNpmLock_v2_OptionalPackage(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "wrappy",
-			Version:   "1.0.2",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			DepGroups: []string{"optional"},
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			DepGroups:    []string{"optional"},
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "supports-color",
+			Version:      "5.5.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			DepGroups:    []string{"dev", "optional"},
+			Relationship: lockfile.TransitiveDependency,
+		},
+	})
+}
+
+func TestParseNpmLock_v2_Workspaces(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNpmLock("fixtures/npm/workspaces.v2.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:         "@my-monorepo/app",
+			Version:      "",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "@my-monorepo/lib",
+			Version:      "",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
+			Workspace:    "packages/app",
+		},
+		{
+			Name:         "@my-monorepo/app",
+			Version:      "0.1.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+			Workspace:    "packages/app",
+			Dependencies: []string{"@my-monorepo/lib@0.1.0", "chalk@4.1.2", "lodash@3.10.1"},
+		},
+		{
+			Name:         "@my-monorepo/lib",
+			Version:      "0.1.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+			Workspace:    "packages/lib",
+			Dependencies: []string{"lodash@4.17.21", "wrappy@1.0.2"},
+		},
+		{
+			Name:         "chalk",
+			Version:      "4.1.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
+			Workspace:    "packages/app",
+		},
+		{
+			Name:         "lodash",
+			Version:      "3.10.1",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
+			Workspace:    "packages/app",
+		},
+		{
+			Name:         "lodash",
+			Version:      "4.17.21",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
+			Workspace:    "packages/lib",
 		},
 		{
-			Name:      "supports-color",
-			Version:   "5.5.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			DepGroups: []string{"dev", "optional"},
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
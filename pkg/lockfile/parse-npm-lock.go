@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"golang.org/x/exp/maps"
@@ -21,6 +22,40 @@ type NpmLockDependency struct {
 	Requires map[string]string `json:"requires,omitempty"`
 }
 
+// npmWorkspaces are the globs of the workspaces of a project, which can either be given
+// as a list, or like yarn as an object with the list under "packages"
+type npmWorkspaces []string
+
+func (w *npmWorkspaces) UnmarshalJSON(data []byte) error {
+	var globs []string
+	if err := json.Unmarshal(data, &globs); err == nil {
+		*w = globs
+
+		return nil
+	}
+
+	var obj struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*w = obj.Packages
+
+	return nil
+}
+
+// includes returns true if the package at namePath is one of the workspaces
+func (w npmWorkspaces) includes(namePath string) bool {
+	for _, glob := range w {
+		if matched, _ := path.Match(path.Clean(glob), namePath); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
 type NpmLockPackage struct {
 	// For an aliased package, Name is the real package name
 	Name     string `json:"name"`
@@ -37,6 +72,9 @@ type NpmLockPackage struct {
 	Optional    bool `json:"optional,omitempty"`
 
 	Link bool `json:"link,omitempty"`
+
+	// Workspaces is only present on the root package of a project that has workspaces
+	Workspaces npmWorkspaces `json:"workspaces,omitempty"`
 }
 
 type NpmLockfile struct {
@@ -136,18 +174,182 @@ func (pkg NpmLockPackage) depGroups() []string {
 	return nil
 }
 
+// directDependencies returns the names of the dependencies declared by the package itself
+func (pkg NpmLockPackage) directDependencies() []string {
+	var names []string
+
+	for _, deps := range []map[string]string{
+		pkg.Dependencies,
+		pkg.DevDependencies,
+		pkg.OptionalDependencies,
+		pkg.PeerDependencies,
+	} {
+		names = append(names, maps.Keys(deps)...)
+	}
+
+	return names
+}
+
+// npmDirectDependencyPaths returns the paths of the packages that are direct dependencies
+// of the project or one of its workspaces, which are the packages without "node_modules"
+// in their path (the root being the empty path)
+func npmDirectDependencyPaths(packages map[string]NpmLockPackage) map[string]struct{} {
+	paths := map[string]struct{}{}
+
+	for namePath, detail := range packages {
+		if strings.Contains(namePath, "node_modules/") {
+			continue
+		}
+
+		for _, name := range detail.directDependencies() {
+			paths[path.Join(namePath, "node_modules", name)] = struct{}{}
+			// dependencies of workspaces are usually hoisted to the root
+			paths[path.Join("node_modules", name)] = struct{}{}
+		}
+	}
+
+	return paths
+}
+
+// npmHoistedWorkspaceDependencies returns the workspace that each dependency hoisted to the root
+// node_modules belongs to, for the dependencies that only one workspace (and not the root) depends on
+func npmHoistedWorkspaceDependencies(packages map[string]NpmLockPackage) map[string]string {
+	owners := map[string]string{}
+	shared := map[string]struct{}{}
+	workspaces := packages[""].Workspaces
+
+	for namePath, detail := range packages {
+		if namePath != "" && !workspaces.includes(namePath) {
+			continue
+		}
+
+		for _, name := range detail.directDependencies() {
+			// workspaces that have their own copy of the dependency don't use the hoisted one
+			if _, ok := packages[path.Join(namePath, "node_modules", name)]; ok && namePath != "" {
+				continue
+			}
+
+			hoistedPath := path.Join("node_modules", name)
+			owner, seen := owners[hoistedPath]
+
+			if namePath == "" || (seen && owner != namePath) {
+				shared[hoistedPath] = struct{}{}
+			}
+			owners[hoistedPath] = namePath
+		}
+	}
+
+	for hoistedPath := range shared {
+		delete(owners, hoistedPath)
+	}
+
+	return owners
+}
+
+// npmWorkspace returns the workspace that the package at namePath belongs to, which is the workspace itself
+// for the workspace's own package, or the workspace whose node_modules the package is installed in
+func npmWorkspace(namePath string, workspaces npmWorkspaces, hoisted map[string]string) string {
+	if workspaces.includes(namePath) {
+		return namePath
+	}
+
+	if workspace, _, ok := strings.Cut(namePath, "/node_modules/"); ok && workspaces.includes(workspace) {
+		return workspace
+	}
+
+	return hoisted[namePath]
+}
+
+// npmPackageName returns the name of the package at namePath, which is its real name for aliased packages
+func npmPackageName(namePath string, detail NpmLockPackage) string {
+	if detail.Name != "" {
+		return detail.Name
+	}
+
+	return extractNpmPackageName(namePath)
+}
+
+// npmResolveDependency returns the path of the package that the dependency with the given name
+// of the package at namePath resolves to, by looking in each parent node_modules the same way as node
+func npmResolveDependency(packages map[string]NpmLockPackage, namePath string, name string) (string, bool) {
+	dir := namePath
+
+	for {
+		depPath := path.Join(dir, "node_modules", name)
+
+		if dep, ok := packages[depPath]; ok {
+			// workspaces are linked into node_modules from where they actually are
+			if dep.Link {
+				_, ok = packages[dep.Resolved]
+
+				return dep.Resolved, ok
+			}
+
+			return depPath, true
+		}
+
+		if dir == "" {
+			return "", false
+		}
+
+		if i := strings.LastIndex(dir, "/node_modules/"); i != -1 {
+			dir = dir[:i]
+		} else {
+			dir = ""
+		}
+	}
+}
+
+// npmDependencies returns the DependencyKey of each package that the package at namePath depends on
+func npmDependencies(packages map[string]NpmLockPackage, namePath string) []string {
+	detail := packages[namePath]
+	groups := []map[string]string{
+		detail.Dependencies,
+		detail.OptionalDependencies,
+		detail.PeerDependencies,
+	}
+
+	// dev dependencies are only installed for the project and its workspaces
+	if !strings.Contains(namePath, "node_modules/") {
+		groups = append(groups, detail.DevDependencies)
+	}
+
+	var keys []string
+
+	for _, deps := range groups {
+		for name := range deps {
+			depPath, ok := npmResolveDependency(packages, namePath, name)
+			if !ok {
+				continue
+			}
+
+			dep := packages[depPath]
+			keys = append(keys, PackageDetails{Name: npmPackageName(depPath, dep), Version: dep.Version}.DependencyKey())
+		}
+	}
+
+	slices.Sort(keys)
+
+	return slices.Compact(keys)
+}
+
 func parseNpmLockPackages(packages map[string]NpmLockPackage) map[string]PackageDetails {
 	details := map[string]PackageDetails{}
 
+	// only the root package records which dependencies are direct, and what the workspaces are
+	var directPaths map[string]struct{}
+	var hoisted map[string]string
+	if _, ok := packages[""]; ok {
+		directPaths = npmDirectDependencyPaths(packages)
+		hoisted = npmHoistedWorkspaceDependencies(packages)
+	}
+
 	for namePath, detail := range packages {
 		if namePath == "" {
 			continue
 		}
 
-		finalName := detail.Name
-		if finalName == "" {
-			finalName = extractNpmPackageName(namePath)
-		}
+		finalName := npmPackageName(namePath, detail)
 
 		finalVersion := detail.Version
 
@@ -159,14 +361,39 @@ func parseNpmLockPackages(packages map[string]NpmLockPackage) map[string]Package
 			finalVersion = commit
 		}
 
-		details[finalName+"@"+finalVersion] = PackageDetails{
-			Name:      finalName,
-			Version:   detail.Version,
-			Ecosystem: NpmEcosystem,
-			CompareAs: NpmEcosystem,
-			Commit:    commit,
-			DepGroups: detail.depGroups(),
+		pkgDetails := PackageDetails{
+			Name:         finalName,
+			Version:      detail.Version,
+			Ecosystem:    NpmEcosystem,
+			CompareAs:    NpmEcosystem,
+			Commit:       commit,
+			DepGroups:    detail.depGroups(),
+			Dependencies: npmDependencies(packages, namePath),
+		}
+
+		existing, seen := details[finalName+"@"+finalVersion]
+
+		// each installation of a package can resolve its dependencies to different versions
+		if seen && len(existing.Dependencies) > 0 {
+			pkgDetails.Dependencies = append(pkgDetails.Dependencies, existing.Dependencies...)
+			slices.Sort(pkgDetails.Dependencies)
+			pkgDetails.Dependencies = slices.Compact(pkgDetails.Dependencies)
 		}
+
+		if directPaths != nil {
+			_, isDirect := directPaths[namePath]
+
+			// a package is direct if any of its installations are
+			pkgDetails.Relationship = relationship(isDirect || (seen && existing.Relationship == DirectDependency))
+
+			// a package only belongs to a workspace if all of its installations do
+			pkgDetails.Workspace = npmWorkspace(namePath, packages[""].Workspaces, hoisted)
+			if seen && existing.Workspace != pkgDetails.Workspace {
+				pkgDetails.Workspace = ""
+			}
+		}
+
+		details[finalName+"@"+finalVersion] = pkgDetails
 	}
 
 	return details
@@ -155,3 +155,28 @@ func TestParseNuGetLock_v1_TwoFrameworks_DuplicatePackages(t *testing.T) {
 		},
 	})
 }
+
+func TestParseNuGetLock_v1_WithProjectReference(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNuGetLock("fixtures/nuget/with-project-reference.v1.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "Test.Core",
+			Version:   "6.0.5",
+			Ecosystem: lockfile.NuGetEcosystem,
+			CompareAs: lockfile.NuGetEcosystem,
+		},
+		{
+			Name:      "Test.Logging",
+			Version:   "2.1.0",
+			Ecosystem: lockfile.NuGetEcosystem,
+			CompareAs: lockfile.NuGetEcosystem,
+		},
+	})
+}
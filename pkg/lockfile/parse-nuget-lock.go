@@ -9,6 +9,7 @@ import (
 )
 
 type NuGetLockPackage struct {
+	Type     string `json:"type"`
 	Resolved string `json:"resolved"`
 }
 
@@ -25,6 +26,11 @@ func parseNuGetLockDependencies(dependencies map[string]NuGetLockPackage) map[st
 	details := map[string]PackageDetails{}
 
 	for name, dependency := range dependencies {
+		// references to other projects in the solution are not packages
+		if dependency.Type == "Project" {
+			continue
+		}
+
 		details[name+"@"+dependency.Resolved] = PackageDetails{
 			Name:      name,
 			Version:   dependency.Resolved,
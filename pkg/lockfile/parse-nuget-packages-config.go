@@ -0,0 +1,56 @@
+package lockfile
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+)
+
+// NuGetPackagesConfig contains the packages referenced by a legacy .NET project, as defined in
+// https://learn.microsoft.com/en-us/nuget/reference/packages-config
+type NuGetPackagesConfig struct {
+	Packages []struct {
+		ID      string `xml:"id,attr"`
+		Version string `xml:"version,attr"`
+	} `xml:"package"`
+}
+
+type NuGetPackagesConfigExtractor struct{}
+
+func (e NuGetPackagesConfigExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "packages.config"
+}
+
+func (e NuGetPackagesConfigExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	var parsedConfig *NuGetPackagesConfig
+
+	err := xml.NewDecoder(f).Decode(&parsedConfig)
+
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+
+	pkgs := make([]PackageDetails, 0, len(parsedConfig.Packages))
+
+	for _, pkg := range parsedConfig.Packages {
+		pkgs = append(pkgs, PackageDetails{
+			Name:      pkg.ID,
+			Version:   pkg.Version,
+			Ecosystem: NuGetEcosystem,
+			CompareAs: NuGetEcosystem,
+		})
+	}
+
+	return pkgs, nil
+}
+
+var _ Extractor = NuGetPackagesConfigExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("packages.config", NuGetPackagesConfigExtractor{})
+}
+
+func ParseNuGetPackagesConfig(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, NuGetPackagesConfigExtractor{})
+}
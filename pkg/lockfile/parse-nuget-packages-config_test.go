@@ -0,0 +1,140 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestNuGetPackagesConfigExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "packages.config",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/packages.config",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/packages.config/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/packages.config.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.packages.config",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.NuGetPackagesConfigExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNuGetPackagesConfig_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNuGetPackagesConfig("fixtures/nuget-packages-config/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseNuGetPackagesConfig_InvalidXml(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNuGetPackagesConfig("fixtures/nuget-packages-config/not-xml.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseNuGetPackagesConfig_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNuGetPackagesConfig("fixtures/nuget-packages-config/empty.config")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseNuGetPackagesConfig_OnePackage(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNuGetPackagesConfig("fixtures/nuget-packages-config/one-package.config")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "Newtonsoft.Json",
+			Version:   "12.0.1",
+			Ecosystem: lockfile.NuGetEcosystem,
+			CompareAs: lockfile.NuGetEcosystem,
+		},
+	})
+}
+
+func TestParseNuGetPackagesConfig_MultiplePackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNuGetPackagesConfig("fixtures/nuget-packages-config/multiple-packages.config")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "Newtonsoft.Json",
+			Version:   "12.0.1",
+			Ecosystem: lockfile.NuGetEcosystem,
+			CompareAs: lockfile.NuGetEcosystem,
+		},
+		{
+			Name:      "log4net",
+			Version:   "2.0.8",
+			Ecosystem: lockfile.NuGetEcosystem,
+			CompareAs: lockfile.NuGetEcosystem,
+		},
+		{
+			Name:      "xunit",
+			Version:   "2.4.1",
+			Ecosystem: lockfile.NuGetEcosystem,
+			CompareAs: lockfile.NuGetEcosystem,
+		},
+	})
+}
@@ -29,10 +29,11 @@ func TestParsePnpmLock_v9_OnePackage(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "acorn",
-			Version:   "8.11.3",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "acorn",
+			Version:      "8.11.3",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -48,10 +49,11 @@ func TestParsePnpmLock_v9_OnePackageDev(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "acorn",
-			Version:   "8.11.3",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "acorn",
+			Version:      "8.11.3",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -67,10 +69,11 @@ func TestParsePnpmLock_v9_ScopedPackages(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "@typescript-eslint/types",
-			Version:   "5.62.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/types",
+			Version:      "5.62.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -86,16 +89,18 @@ func TestParsePnpmLock_v9_PeerDependencies(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "acorn-jsx",
-			Version:   "5.3.2",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "acorn-jsx",
+			Version:      "5.3.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "acorn",
-			Version:   "8.11.3",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "acorn",
+			Version:      "8.11.3",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -111,82 +116,95 @@ func TestParsePnpmLock_v9_PeerDependenciesAdvanced(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "@eslint-community/eslint-utils",
-			Version:   "4.4.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@eslint-community/eslint-utils",
+			Version:      "4.4.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@eslint/eslintrc",
-			Version:   "2.1.4",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@eslint/eslintrc",
+			Version:      "2.1.4",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@typescript-eslint/eslint-plugin",
-			Version:   "5.62.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/eslint-plugin",
+			Version:      "5.62.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "@typescript-eslint/parser",
-			Version:   "5.62.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/parser",
+			Version:      "5.62.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "@typescript-eslint/type-utils",
-			Version:   "5.62.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/type-utils",
+			Version:      "5.62.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@typescript-eslint/typescript-estree",
-			Version:   "5.62.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/typescript-estree",
+			Version:      "5.62.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@typescript-eslint/utils",
-			Version:   "5.62.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/utils",
+			Version:      "5.62.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "debug",
-			Version:   "4.3.4",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "debug",
+			Version:      "4.3.4",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "eslint",
-			Version:   "8.57.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "eslint",
+			Version:      "8.57.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "has-flag",
-			Version:   "4.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "has-flag",
+			Version:      "4.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "supports-color",
-			Version:   "7.2.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "supports-color",
+			Version:      "7.2.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "tsutils",
-			Version:   "3.21.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "tsutils",
+			Version:      "3.21.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "typescript",
-			Version:   "4.9.5",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "typescript",
+			Version:      "4.9.5",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -202,22 +220,25 @@ func TestParsePnpmLock_v9_MultipleVersions(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "uuid",
-			Version:   "8.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "uuid",
+			Version:      "8.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "uuid",
-			Version:   "8.3.2",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "uuid",
+			Version:      "8.3.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "xmlbuilder",
-			Version:   "11.0.1",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "xmlbuilder",
+			Version:      "11.0.1",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -233,18 +254,20 @@ func TestParsePnpmLock_v9_Commits(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "ansi-regex",
-			Version:   "6.0.1",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
-			Commit:    "02fa893d619d3da85411acc8fd4e2eea0e95a9d9",
+			Name:         "ansi-regex",
+			Version:      "6.0.1",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Commit:       "02fa893d619d3da85411acc8fd4e2eea0e95a9d9",
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "is-number",
-			Version:   "7.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
-			Commit:    "98e8ff1da1a89f93d1397a24d7413ed15421c139",
+			Name:         "is-number",
+			Version:      "7.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Commit:       "98e8ff1da1a89f93d1397a24d7413ed15421c139",
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -260,22 +283,60 @@ func TestParsePnpmLock_v9_MixedGroups(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "ansi-regex",
-			Version:   "5.0.1",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "ansi-regex",
+			Version:      "5.0.1",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "uuid",
-			Version:   "8.3.2",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "uuid",
+			Version:      "8.3.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "is-number",
-			Version:   "7.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "is-number",
+			Version:      "7.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+	})
+}
+
+func TestParsePnpmLock_v9_Workspaces(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePnpmLock("fixtures/pnpm/workspaces.v9.yaml")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:         "chalk",
+			Version:      "4.1.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
+			Workspace:    "packages/app",
+		},
+		{
+			Name:         "lodash",
+			Version:      "4.17.21",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "wrappy",
+			Version:      "1.0.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
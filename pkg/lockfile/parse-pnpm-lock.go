@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/google/osv-scanner/internal/cachedregexp"
+	"golang.org/x/exp/maps"
 	"gopkg.in/yaml.v3"
 )
 
@@ -29,11 +30,78 @@ type PnpmLockPackage struct {
 type PnpmLockfile struct {
 	Version  float64                    `yaml:"lockfileVersion"`
 	Packages map[string]PnpmLockPackage `yaml:"packages,omitempty"`
+
+	// the "name@version" of the direct dependencies of the project and its workspaces,
+	// which is nil if the lockfile does not record them
+	directDependencies map[string]struct{}
+	// the workspace that each "name@version" direct dependency belongs to, for
+	// the dependencies that only one workspace (and not the root) depends on
+	workspaceDependencies map[string]string
+}
+
+// pnpmDependencyVersion is the version of a dependency in an importer, which is
+// either just the version (v5) or a mapping including the version (v6+)
+type pnpmDependencyVersion string
+
+func (v *pnpmDependencyVersion) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*v = pnpmDependencyVersion(value.Value)
+
+		return nil
+	}
+
+	var dep struct {
+		Version string `yaml:"version"`
+	}
+
+	if err := value.Decode(&dep); err != nil {
+		return err
+	}
+
+	*v = pnpmDependencyVersion(dep.Version)
+
+	return nil
+}
+
+// pnpmImporter is the project or a workspace within it, along with its direct dependencies
+type pnpmImporter struct {
+	Dependencies         map[string]pnpmDependencyVersion `yaml:"dependencies,omitempty"`
+	DevDependencies      map[string]pnpmDependencyVersion `yaml:"devDependencies,omitempty"`
+	OptionalDependencies map[string]pnpmDependencyVersion `yaml:"optionalDependencies,omitempty"`
+}
+
+// nameAtVersions returns the "name@version" of each of the dependencies of the importer
+func (i pnpmImporter) nameAtVersions() []string {
+	var deps []string
+
+	for _, m := range []map[string]pnpmDependencyVersion{i.Dependencies, i.DevDependencies, i.OptionalDependencies} {
+		for name, version := range m {
+			v := string(version)
+
+			// aliased dependencies have a version like "npm:name@version"
+			if alias, ok := strings.CutPrefix(v, "npm:"); ok {
+				name, v = parseNameAtVersion(alias)
+			}
+
+			// strip any peer dependencies (e.g. "1.0.0_peer@1.0.0" or "1.0.0(peer@1.0.0)")
+			v, _, _ = strings.Cut(v, "(")
+			v, _, _ = strings.Cut(v, "_")
+
+			deps = append(deps, name+"@"+v)
+		}
+	}
+
+	return deps
 }
 
 type pnpmLockfileV6 struct {
 	Version  string                     `yaml:"lockfileVersion"`
 	Packages map[string]PnpmLockPackage `yaml:"packages,omitempty"`
+
+	// lockfiles for a single project list its dependencies at the top level
+	pnpmImporter `yaml:",inline"`
+	// lockfiles for workspaces (and all lockfiles since v9) list them per importer
+	Importers map[string]pnpmImporter `yaml:"importers,omitempty"`
 }
 
 func (l *PnpmLockfile) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -52,6 +120,36 @@ func (l *PnpmLockfile) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	l.Version = parsedVersion
 	l.Packages = lockfileV6.Packages
 
+	importers := maps.Clone(lockfileV6.Importers)
+	if lockfileV6.Dependencies != nil || lockfileV6.DevDependencies != nil || lockfileV6.OptionalDependencies != nil {
+		if importers == nil {
+			importers = map[string]pnpmImporter{}
+		}
+		importers["."] = lockfileV6.pnpmImporter
+	}
+
+	if len(importers) > 0 {
+		l.directDependencies = map[string]struct{}{}
+		l.workspaceDependencies = map[string]string{}
+		shared := map[string]struct{}{}
+
+		for workspace, importer := range importers {
+			for _, dep := range importer.nameAtVersions() {
+				l.directDependencies[dep] = struct{}{}
+
+				owner, seen := l.workspaceDependencies[dep]
+				if workspace == "." || (seen && owner != workspace) {
+					shared[dep] = struct{}{}
+				}
+				l.workspaceDependencies[dep] = workspace
+			}
+		}
+
+		for dep := range shared {
+			delete(l.workspaceDependencies, dep)
+		}
+	}
+
 	return nil
 }
 
@@ -172,14 +270,22 @@ func parsePnpmLock(lockfile PnpmLockfile) []PackageDetails {
 			depGroups = append(depGroups, "dev")
 		}
 
-		packages = append(packages, PackageDetails{
+		pkgDetails := PackageDetails{
 			Name:      name,
 			Version:   version,
 			Ecosystem: PnpmEcosystem,
 			CompareAs: PnpmEcosystem,
 			Commit:    commit,
 			DepGroups: depGroups,
-		})
+		}
+
+		if lockfile.directDependencies != nil {
+			_, isDirect := lockfile.directDependencies[name+"@"+version]
+			pkgDetails.Relationship = relationship(isDirect)
+			pkgDetails.Workspace = lockfile.workspaceDependencies[name+"@"+version]
+		}
+
+		packages = append(packages, pkgDetails)
 	}
 
 	return packages
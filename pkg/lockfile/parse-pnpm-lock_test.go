@@ -112,10 +112,11 @@ func TestParsePnpmLock_OnePackage(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "acorn",
-			Version:   "8.7.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "acorn",
+			Version:      "8.7.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -131,10 +132,11 @@ func TestParsePnpmLock_OnePackageV6Lockfile(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "acorn",
-			Version:   "8.7.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "acorn",
+			Version:      "8.7.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -150,10 +152,11 @@ func TestParsePnpmLock_OnePackageDev(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "acorn",
-			Version:   "8.7.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "acorn",
+			Version:      "8.7.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -169,10 +172,11 @@ func TestParsePnpmLock_ScopedPackages(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "@typescript-eslint/types",
-			Version:   "5.13.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/types",
+			Version:      "5.13.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -188,10 +192,11 @@ func TestParsePnpmLock_ScopedPackagesV6Lockfile(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "@typescript-eslint/types",
-			Version:   "5.57.1",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/types",
+			Version:      "5.57.1",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -207,16 +212,18 @@ func TestParsePnpmLock_PeerDependencies(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "acorn-jsx",
-			Version:   "5.3.2",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "acorn-jsx",
+			Version:      "5.3.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "acorn",
-			Version:   "8.7.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "acorn",
+			Version:      "8.7.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 	})
 }
@@ -232,58 +239,67 @@ func TestParsePnpmLock_PeerDependenciesAdvanced(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "@typescript-eslint/eslint-plugin",
-			Version:   "5.13.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/eslint-plugin",
+			Version:      "5.13.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "@typescript-eslint/parser",
-			Version:   "5.13.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/parser",
+			Version:      "5.13.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "@typescript-eslint/type-utils",
-			Version:   "5.13.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/type-utils",
+			Version:      "5.13.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@typescript-eslint/types",
-			Version:   "5.13.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/types",
+			Version:      "5.13.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@typescript-eslint/typescript-estree",
-			Version:   "5.13.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/typescript-estree",
+			Version:      "5.13.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@typescript-eslint/utils",
-			Version:   "5.13.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@typescript-eslint/utils",
+			Version:      "5.13.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "eslint-utils",
-			Version:   "3.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "eslint-utils",
+			Version:      "3.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "eslint",
-			Version:   "8.10.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "eslint",
+			Version:      "8.10.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "tsutils",
-			Version:   "3.21.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "tsutils",
+			Version:      "3.21.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -299,88 +315,102 @@ func TestParsePnpmLock_MultiplePackages(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "aws-sdk",
-			Version:   "2.1087.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "aws-sdk",
+			Version:      "2.1087.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "base64-js",
-			Version:   "1.5.1",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "base64-js",
+			Version:      "1.5.1",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "buffer",
-			Version:   "4.9.2",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "buffer",
+			Version:      "4.9.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "events",
-			Version:   "1.1.1",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "events",
+			Version:      "1.1.1",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "ieee754",
-			Version:   "1.1.13",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "ieee754",
+			Version:      "1.1.13",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "isarray",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "isarray",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "jmespath",
-			Version:   "0.16.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "jmespath",
+			Version:      "0.16.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "punycode",
-			Version:   "1.3.2",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "punycode",
+			Version:      "1.3.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "querystring",
-			Version:   "0.2.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "querystring",
+			Version:      "0.2.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "sax",
-			Version:   "1.2.1",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "sax",
+			Version:      "1.2.1",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "url",
-			Version:   "0.10.3",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "url",
+			Version:      "0.10.3",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "uuid",
-			Version:   "3.3.2",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "uuid",
+			Version:      "3.3.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "xml2js",
-			Version:   "0.4.19",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "xml2js",
+			Version:      "0.4.19",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "xmlbuilder",
-			Version:   "9.0.7",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "xmlbuilder",
+			Version:      "9.0.7",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -396,22 +426,25 @@ func TestParsePnpmLock_MultipleVersions(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "uuid",
-			Version:   "3.3.2",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "uuid",
+			Version:      "3.3.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "uuid",
-			Version:   "8.3.2",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "uuid",
+			Version:      "8.3.2",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.DirectDependency,
 		},
 		{
-			Name:      "xmlbuilder",
-			Version:   "9.0.7",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "xmlbuilder",
+			Version:      "9.0.7",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -427,12 +460,13 @@ func TestParsePnpmLock_Tarball(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "@my-org/my-package",
-			Version:   "3.2.3",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
-			Commit:    "",
-			DepGroups: []string{"dev"},
+			Name:         "@my-org/my-package",
+			Version:      "3.2.3",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Commit:       "",
+			DepGroups:    []string{"dev"},
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -448,46 +482,53 @@ func TestParsePnpmLock_Exotic(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "foo",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "foo",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@foo/bar",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@foo/bar",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "foo",
-			Version:   "1.1.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "foo",
+			Version:      "1.1.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@foo/bar",
-			Version:   "1.1.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "@foo/bar",
+			Version:      "1.1.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "foo",
-			Version:   "1.2.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "foo",
+			Version:      "1.2.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "foo",
-			Version:   "1.3.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "foo",
+			Version:      "1.3.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "foo",
-			Version:   "1.4.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
+			Name:         "foo",
+			Version:      "1.4.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -503,39 +544,44 @@ func TestParsePnpmLock_Commits(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "my-bitbucket-package",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
-			Commit:    "6104ae42cd32c3d724036d3964678f197b2c9cdb",
+			Name:         "my-bitbucket-package",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Commit:       "6104ae42cd32c3d724036d3964678f197b2c9cdb",
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@my-scope/my-package",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
-			Commit:    "267087851ad5fac92a184749c27cd539e2fc862e",
+			Name:         "@my-scope/my-package",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Commit:       "267087851ad5fac92a184749c27cd539e2fc862e",
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "@my-scope/my-other-package",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
-			Commit:    "fbfc962ab51eb1d754749b68c064460221fbd689",
+			Name:         "@my-scope/my-other-package",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Commit:       "fbfc962ab51eb1d754749b68c064460221fbd689",
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "faker-parser",
-			Version:   "0.0.1",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
-			Commit:    "d2dc42a9351d4d89ec48c525e34f612b6d77993f",
+			Name:         "faker-parser",
+			Version:      "0.0.1",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Commit:       "d2dc42a9351d4d89ec48c525e34f612b6d77993f",
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "mocks",
-			Version:   "20.0.1",
-			Ecosystem: lockfile.PnpmEcosystem,
-			CompareAs: lockfile.PnpmEcosystem,
-			Commit:    "590f321b4eb3f692bb211bd74e22947639a6f79d",
+			Name:         "mocks",
+			Version:      "20.0.1",
+			Ecosystem:    lockfile.PnpmEcosystem,
+			CompareAs:    lockfile.PnpmEcosystem,
+			Commit:       "590f321b4eb3f692bb211bd74e22947639a6f79d",
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -551,39 +597,44 @@ func TestParsePnpmLock_Files(t *testing.T) {
 
 	expectPackages(t, packages, []lockfile.PackageDetails{
 		{
-			Name:      "my-file-package",
-			Version:   "0.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
+			Name:         "my-file-package",
+			Version:      "0.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "a-local-package",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
+			Name:         "a-local-package",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "a-nested-local-package",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
+			Name:         "a-nested-local-package",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "one-up",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
+			Name:         "one-up",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			Relationship: lockfile.TransitiveDependency,
 		},
 		{
-			Name:      "one-up-with-peer",
-			Version:   "1.0.0",
-			Ecosystem: lockfile.NpmEcosystem,
-			CompareAs: lockfile.NpmEcosystem,
-			Commit:    "",
+			Name:         "one-up-with-peer",
+			Version:      "1.0.0",
+			Ecosystem:    lockfile.NpmEcosystem,
+			CompareAs:    lockfile.NpmEcosystem,
+			Commit:       "",
+			Relationship: lockfile.TransitiveDependency,
 		},
 	})
 }
@@ -0,0 +1,94 @@
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+// PodfileLockPod is an entry of the PODS section of a Podfile.lock, which is either
+// just the pod (such as "Alamofire (5.4.3)"), or a map of the pod to its dependencies
+type PodfileLockPod struct {
+	Spec string
+}
+
+var _ yaml.Unmarshaler = &PodfileLockPod{}
+
+func (plp *PodfileLockPod) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.MappingNode {
+		if len(value.Content) == 0 {
+			return errors.New("pod has no spec")
+		}
+
+		return value.Content[0].Decode(&plp.Spec)
+	}
+
+	return value.Decode(&plp.Spec)
+}
+
+type PodfileLockfile struct {
+	Pods []PodfileLockPod `yaml:"PODS"`
+}
+
+const CocoaPodsEcosystem Ecosystem = "CocoaPods"
+
+type PodfileLockExtractor struct{}
+
+func (e PodfileLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "Podfile.lock"
+}
+
+func (e PodfileLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	var parsedLockfile *PodfileLockfile
+
+	err := yaml.NewDecoder(f).Decode(&parsedLockfile)
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+	if parsedLockfile == nil {
+		return []PackageDetails{}, nil
+	}
+
+	re := cachedregexp.MustCompile(`^(\S+) \((.+)\)$`)
+
+	packages := make(map[string]PackageDetails, len(parsedLockfile.Pods))
+
+	for _, pod := range parsedLockfile.Pods {
+		matched := re.FindStringSubmatch(pod.Spec)
+
+		if matched == nil {
+			continue
+		}
+
+		// subspecs (such as "Firebase/Analytics") are part of the same pod as their parent
+		name, _, _ := strings.Cut(matched[1], "/")
+		version := matched[2]
+
+		packages[name+"@"+version] = PackageDetails{
+			Name:      name,
+			Version:   version,
+			Ecosystem: CocoaPodsEcosystem,
+			CompareAs: CocoaPodsEcosystem,
+		}
+	}
+
+	return maps.Values(packages), nil
+}
+
+var _ Extractor = PodfileLockExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("Podfile.lock", PodfileLockExtractor{})
+}
+
+func ParsePodfileLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, PodfileLockExtractor{})
+}
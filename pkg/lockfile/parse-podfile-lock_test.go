@@ -0,0 +1,169 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestPodfileLockExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "Podfile.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/Podfile.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/Podfile.lock/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/Podfile.lock.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.Podfile.lock",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/Podfile",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.PodfileLockExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePodfileLock_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePodfileLock("fixtures/cocoapods/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePodfileLock_InvalidYaml(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePodfileLock("fixtures/cocoapods/not-yaml.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePodfileLock_Empty(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePodfileLock("fixtures/cocoapods/empty.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePodfileLock_NoPods(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePodfileLock("fixtures/cocoapods/no-pods.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePodfileLock_OnePod(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePodfileLock("fixtures/cocoapods/one-pod.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "Alamofire",
+			Version:   "5.4.3",
+			Ecosystem: lockfile.CocoaPodsEcosystem,
+			CompareAs: lockfile.CocoaPodsEcosystem,
+		},
+	})
+}
+
+func TestParsePodfileLock_Subspecs(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePodfileLock("fixtures/cocoapods/subspecs.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "Firebase",
+			Version:   "8.0.0",
+			Ecosystem: lockfile.CocoaPodsEcosystem,
+			CompareAs: lockfile.CocoaPodsEcosystem,
+		},
+		{
+			Name:      "FirebaseAnalytics",
+			Version:   "8.0.0",
+			Ecosystem: lockfile.CocoaPodsEcosystem,
+			CompareAs: lockfile.CocoaPodsEcosystem,
+		},
+		{
+			Name:      "FirebaseCore",
+			Version:   "8.0.0",
+			Ecosystem: lockfile.CocoaPodsEcosystem,
+			CompareAs: lockfile.CocoaPodsEcosystem,
+		},
+		{
+			Name:      "GoogleUtilities",
+			Version:   "7.4.1",
+			Ecosystem: lockfile.CocoaPodsEcosystem,
+			CompareAs: lockfile.CocoaPodsEcosystem,
+		},
+		{
+			Name:      "SDWebImage",
+			Version:   "5.12.1",
+			Ecosystem: lockfile.CocoaPodsEcosystem,
+			CompareAs: lockfile.CocoaPodsEcosystem,
+		},
+	})
+}
@@ -92,6 +92,15 @@ func isNotRequirementLine(line string) bool {
 		strings.HasPrefix(line, "/")
 }
 
+// isOptionLine returns true if the line is an option (such as --index-url), rather than
+// a requirement (including editable ones) or an include of another requirements file
+func isOptionLine(line string) bool {
+	return strings.HasPrefix(line, "-") &&
+		!strings.HasPrefix(line, "-r ") &&
+		!strings.HasPrefix(line, "-e ") &&
+		!strings.HasPrefix(line, "--editable")
+}
+
 func isLineContinuation(line string) bool {
 	// checks that the line ends with an odd number of back slashes,
 	// meaning the last one isn't escaped
@@ -124,6 +133,10 @@ func parseRequirementsTxt(f DepFile, requiredAlready map[string]struct{}) ([]Pac
 		return false
 	}
 
+	// ignore comments on their own line apply to the requirement (or include) that follows them,
+	// regardless of any blank lines, other comments or options in between
+	var pendingIgnores []IgnoreComment
+
 	scanner := bufio.NewScanner(f)
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -136,7 +149,22 @@ func parseRequirementsTxt(f DepFile, requiredAlready map[string]struct{}) ([]Pac
 			}
 		}
 
+		ignores := parseIgnoreComments(line)
 		line = removeComments(line)
+
+		if line == "" {
+			pendingIgnores = append(pendingIgnores, ignores...)
+
+			continue
+		}
+
+		// options do not declare a requirement, so the ignores before them are kept for the next one
+		if isOptionLine(line) {
+			continue
+		}
+
+		ignores = append(pendingIgnores, ignores...)
+		pendingIgnores = nil
 		if ar := strings.TrimPrefix(line, "-r "); ar != line {
 			err := func() error {
 				af, err := f.Open(ar)
@@ -185,8 +213,9 @@ func parseRequirementsTxt(f DepFile, requiredAlready map[string]struct{}) ([]Pac
 		d := packages[key]
 		if !hasGroup(d.DepGroups) {
 			d.DepGroups = append(d.DepGroups, group)
-			packages[key] = d
 		}
+		d.Ignores = append(d.Ignores, ignores...)
+		packages[key] = d
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -717,3 +717,94 @@ func TestParseRequirementsTxt_LineContinuation(t *testing.T) {
 		},
 	})
 }
+
+func TestParseRequirementsTxt_WithIgnoreComments(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseRequirementsTxt("fixtures/pip/with-ignore-comments.txt")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "flask",
+			Version:   "1.0.0",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+			DepGroups: []string{"with-ignore-comments"},
+			Ignores: []lockfile.IgnoreComment{
+				{ID: "GHSA-m2qf-hxjv-5gpq", Reason: "we do not use the affected feature"},
+			},
+		},
+		{
+			Name:      "django",
+			Version:   "2.2.24",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+			DepGroups: []string{"with-ignore-comments"},
+			Ignores: []lockfile.IgnoreComment{
+				{ID: "PYSEC-2023-1", Reason: ""},
+				{ID: "PYSEC-2023-2", Reason: ""},
+			},
+		},
+		{
+			Name:      "requests",
+			Version:   "2.20.0",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+			DepGroups: []string{"with-ignore-comments"},
+		},
+	})
+}
+
+func TestParseRequirementsTxt_WithSeparatedIgnoreComments(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseRequirementsTxt("fixtures/pip/with-separated-ignore-comments.txt")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "django",
+			Version:   "2.2.24",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+			DepGroups: []string{"with-separated-ignore-comments"},
+			Ignores: []lockfile.IgnoreComment{
+				{ID: "PYSEC-2023-1", Reason: "separated by a blank line"},
+			},
+		},
+		{
+			Name:      "flask",
+			Version:   "1.0.0",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+			DepGroups: []string{"with-separated-ignore-comments"},
+			Ignores: []lockfile.IgnoreComment{
+				{ID: "GHSA-aaaa-bbbb-cccc", Reason: ""},
+			},
+		},
+		{
+			Name:      "requests",
+			Version:   "2.20.0",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+			DepGroups: []string{"with-separated-ignore-comments"},
+			Ignores: []lockfile.IgnoreComment{
+				{ID: "GHSA-dddd-eeee-ffff", Reason: ""},
+			},
+		},
+		{
+			Name:      "urllib3",
+			Version:   "1.26.0",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+			DepGroups: []string{"with-separated-ignore-comments"},
+		},
+	})
+}
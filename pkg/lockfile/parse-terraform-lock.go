@@ -0,0 +1,124 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+)
+
+const TerraformEcosystem Ecosystem = "Terraform"
+
+// TerraformLockExtractor extracts the providers from a .terraform.lock.hcl, which is
+// written by both Terraform and OpenTofu when running `init`
+type TerraformLockExtractor struct{}
+
+func (e TerraformLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == ".terraform.lock.hcl"
+}
+
+// parseTerraformLockString parses the value of an attribute of a provider block, which is always
+// a quoted string for the attributes that are needed
+func parseTerraformLockString(value string) (string, error) {
+	return strconv.Unquote(strings.TrimSpace(value))
+}
+
+func (e TerraformLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	providerRe := cachedregexp.MustCompile(`^provider\s+("(?:[^"\\]|\\.)*")\s*\{$`)
+	attributeRe := cachedregexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_-]*)\s*=\s*(.*)$`)
+
+	var packages []PackageDetails
+	var current *PackageDetails
+	// the lines of a list (such as the hashes) are skipped until it is closed
+	inList := false
+
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		if inList {
+			inList = !strings.HasSuffix(line, "]")
+			continue
+		}
+
+		if current == nil {
+			matches := providerRe.FindStringSubmatch(line)
+			if matches == nil {
+				return []PackageDetails{}, fmt.Errorf("could not extract from %s: unexpected content on line %d", f.Path(), lineNumber)
+			}
+
+			name, err := parseTerraformLockString(matches[1])
+			if err != nil {
+				return []PackageDetails{}, fmt.Errorf("could not extract from %s: invalid provider on line %d: %w", f.Path(), lineNumber, err)
+			}
+
+			current = &PackageDetails{
+				Name:      name,
+				Ecosystem: TerraformEcosystem,
+				CompareAs: TerraformEcosystem,
+			}
+
+			continue
+		}
+
+		if line == "}" {
+			// providers without a version cannot be checked for vulnerabilities
+			if current.Version != "" {
+				packages = append(packages, *current)
+			}
+			current = nil
+
+			continue
+		}
+
+		matches := attributeRe.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		switch key, value := matches[1], matches[2]; key {
+		case "version", "constraints":
+			str, err := parseTerraformLockString(value)
+			if err != nil {
+				return []PackageDetails{}, fmt.Errorf("could not extract from %s: invalid %s on line %d: %w", f.Path(), key, lineNumber, err)
+			}
+
+			if key == "version" {
+				current.Version = str
+			} else {
+				current.VersionConstraint = str
+			}
+		default:
+			// such as the hashes, which are a list that can span multiple lines
+			inList = strings.HasPrefix(value, "[") && !strings.HasSuffix(value, "]")
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	if current != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: the block of provider %s is not closed", f.Path(), current.Name)
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = TerraformLockExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("terraform-lock", TerraformLockExtractor{})
+}
+
+func ParseTerraformLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, TerraformLockExtractor{})
+}
@@ -340,3 +340,51 @@ func TestParseYarnLock_v2_WithAliases(t *testing.T) {
 		},
 	})
 }
+
+func TestParseYarnLock_v2_WithPackageJson(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseYarnLock("fixtures/yarn/with-package-json-v2/yarn.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:         "@babel/code-frame",
+			Version:      "7.16.7",
+			Ecosystem:    lockfile.YarnEcosystem,
+			CompareAs:    lockfile.YarnEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "ansi-regex",
+			Version:      "5.0.1",
+			Ecosystem:    lockfile.YarnEcosystem,
+			CompareAs:    lockfile.YarnEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "concat-map",
+			Version:      "0.0.1",
+			Ecosystem:    lockfile.YarnEcosystem,
+			CompareAs:    lockfile.YarnEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+		},
+		{
+			Name:         "concat-stream",
+			Version:      "1.6.2",
+			Ecosystem:    lockfile.YarnEcosystem,
+			CompareAs:    lockfile.YarnEcosystem,
+			Relationship: lockfile.DirectDependency,
+		},
+		{
+			Name:         "my-project",
+			Version:      "0.0.0-use.local",
+			Ecosystem:    lockfile.YarnEcosystem,
+			CompareAs:    lockfile.YarnEcosystem,
+			Relationship: lockfile.TransitiveDependency,
+		},
+	})
+}
@@ -2,6 +2,7 @@ package lockfile
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
@@ -177,6 +178,67 @@ func parseYarnPackageGroup(group []string) PackageDetails {
 	}
 }
 
+// extractYarnPackageDescriptors returns the descriptors (e.g. "name@^1.0.0") that
+// resolved to the package, as listed on the first line of its group
+func extractYarnPackageDescriptors(header string) []string {
+	header = strings.TrimSuffix(header, ":")
+	descriptors := strings.Split(header, ", ")
+
+	for i, descriptor := range descriptors {
+		descriptors[i] = strings.Trim(descriptor, "\"")
+	}
+
+	return descriptors
+}
+
+// yarnDirectDependencies returns the descriptors of the dependencies declared
+// by the package.json next to the yarn.lock, if there is one
+func yarnDirectDependencies(f DepFile) (map[string]struct{}, bool) {
+	pf, err := f.Open("package.json")
+
+	if err != nil {
+		return nil, false
+	}
+
+	defer pf.Close()
+
+	var manifest struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+
+	if err := json.NewDecoder(pf).Decode(&manifest); err != nil {
+		return nil, false
+	}
+
+	descriptors := map[string]struct{}{}
+
+	for _, deps := range []map[string]string{
+		manifest.Dependencies,
+		manifest.DevDependencies,
+		manifest.OptionalDependencies,
+	} {
+		for name, constraint := range deps {
+			descriptors[name+"@"+constraint] = struct{}{}
+			// yarn v2+ prefixes constraints without a protocol with "npm:"
+			descriptors[name+"@npm:"+constraint] = struct{}{}
+		}
+	}
+
+	return descriptors, true
+}
+
+func isYarnDirectDependency(group []string, directDependencies map[string]struct{}) bool {
+	for _, descriptor := range extractYarnPackageDescriptors(group[0]) {
+		if _, ok := directDependencies[descriptor]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 type YarnLockExtractor struct{}
 
 func (e YarnLockExtractor) ShouldExtract(path string) bool {
@@ -192,6 +254,10 @@ func (e YarnLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
 	}
 
+	// yarn.lock does not record which dependencies are direct,
+	// so we need the package.json of the project to know that
+	directDependencies, hasManifest := yarnDirectDependencies(f)
+
 	packages := make([]PackageDetails, 0, len(packageGroups))
 
 	for _, group := range packageGroups {
@@ -199,7 +265,13 @@ func (e YarnLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 			continue
 		}
 
-		packages = append(packages, parseYarnPackageGroup(group))
+		pkg := parseYarnPackageGroup(group)
+
+		if hasManifest {
+			pkg.Relationship = relationship(isYarnDirectDependency(group, directDependencies))
+		}
+
+		packages = append(packages, pkg)
 	}
 
 	return packages, nil
@@ -20,8 +20,10 @@ func FindParser(pathToLockfile string, parseAs string) (PackageDetailsParser, st
 
 // this is an optimisation and read-only
 var parsers = map[string]PackageDetailsParser{
+	".terraform.lock.hcl":         ParseTerraformLock,
 	"buildscript-gradle.lockfile": ParseGradleLock,
 	"Cargo.lock":                  ParseCargoLock,
+	"composer.json":               ParseComposerJSON,
 	"composer.lock":               ParseComposerLock,
 	"conan.lock":                  ParseConanLock,
 	"Gemfile.lock":                ParseGemfileLock,
@@ -31,9 +33,11 @@ var parsers = map[string]PackageDetailsParser{
 	"mix.lock":                    ParseMixLock,
 	"Pipfile.lock":                ParsePipenvLock,
 	"package-lock.json":           ParseNpmLock,
+	"packages.config":             ParseNuGetPackagesConfig,
 	"packages.lock.json":          ParseNuGetLock,
 	"pdm.lock":                    ParsePdmLock,
 	"pnpm-lock.yaml":              ParsePnpmLock,
+	"Podfile.lock":                ParsePodfileLock,
 	"poetry.lock":                 ParsePoetryLock,
 	"pom.xml":                     ParseMavenLock,
 	"pubspec.lock":                ParsePubspecLock,
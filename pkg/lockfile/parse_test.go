@@ -43,8 +43,10 @@ func TestFindParser(t *testing.T) {
 	t.Parallel()
 
 	lockfiles := []string{
+		".terraform.lock.hcl",
 		"buildscript-gradle.lockfile",
 		"Cargo.lock",
+		"composer.json",
 		"composer.lock",
 		"Gemfile.lock",
 		"go.mod",
@@ -53,8 +55,10 @@ func TestFindParser(t *testing.T) {
 		"pdm.lock",
 		"Pipfile.lock",
 		"package-lock.json",
+		"packages.config",
 		"packages.lock.json",
 		"pnpm-lock.yaml",
+		"Podfile.lock",
 		"poetry.lock",
 		"pom.xml",
 		"pubspec.lock",
@@ -94,8 +98,10 @@ func TestParse_FindsExpectedParsers(t *testing.T) {
 	t.Parallel()
 
 	lockfiles := []string{
+		".terraform.lock.hcl",
 		"buildscript-gradle.lockfile",
 		"Cargo.lock",
+		"composer.json",
 		"composer.lock",
 		"conan.lock",
 		"Gemfile.lock",
@@ -106,8 +112,10 @@ func TestParse_FindsExpectedParsers(t *testing.T) {
 		"Pipfile.lock",
 		"pdm.lock",
 		"package-lock.json",
+		"packages.config",
 		"packages.lock.json",
 		"pnpm-lock.yaml",
+		"Podfile.lock",
 		"poetry.lock",
 		"pom.xml",
 		"pubspec.lock",
@@ -161,7 +169,7 @@ func TestListParsers(t *testing.T) {
 
 	parsers := lockfile.ListParsers()
 
-	firstExpected := "buildscript-gradle.lockfile"
+	firstExpected := ".terraform.lock.hcl"
 	//nolint:ifshort
 	lastExpected := "yarn.lock"
 
@@ -0,0 +1,144 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// pythonMetadataFile returns the path of the file containing the metadata
+// of the installed distribution at the given path, if it is one
+func pythonMetadataFile(path string, d fs.DirEntry) (string, bool) {
+	switch {
+	case d.IsDir() && strings.HasSuffix(d.Name(), ".dist-info"):
+		return filepath.Join(path, "METADATA"), true
+	case d.IsDir() && strings.HasSuffix(d.Name(), ".egg-info"):
+		return filepath.Join(path, "PKG-INFO"), true
+	// older versions of setuptools installed the metadata as a single file
+	case !d.IsDir() && strings.HasSuffix(d.Name(), ".egg-info"):
+		return path, true
+	}
+
+	return "", false
+}
+
+// parsePythonMetadata reads the name and version from the headers of a
+// distribution's metadata, which end at the first empty line
+func parsePythonMetadata(pathToMetadata string) (PackageDetails, error) {
+	f, err := os.Open(pathToMetadata)
+
+	if err != nil {
+		return PackageDetails{}, err
+	}
+
+	defer f.Close()
+
+	pkg := PackageDetails{
+		Ecosystem: PipEcosystem,
+		CompareAs: PipEcosystem,
+	}
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			break
+		}
+
+		key, value, found := strings.Cut(line, ":")
+
+		if !found {
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "name":
+			if pkg.Name == "" {
+				pkg.Name = normalizedRequirementName(strings.TrimSpace(value))
+			}
+		case "version":
+			if pkg.Version == "" {
+				pkg.Version = strings.TrimSpace(value)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return PackageDetails{}, fmt.Errorf("error while scanning %s: %w", pathToMetadata, err)
+	}
+
+	return pkg, nil
+}
+
+// ParsePythonInstalled walks the given directory (such as a site-packages directory)
+// for the metadata of installed Python distributions
+func ParsePythonInstalled(pathToSitePackages string) ([]PackageDetails, error) {
+	details := map[string]PackageDetails{}
+
+	err := filepath.WalkDir(pathToSitePackages, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		pathToMetadata, ok := pythonMetadataFile(path, d)
+
+		if !ok {
+			return nil
+		}
+
+		pkg, err := parsePythonMetadata(pathToMetadata)
+
+		// a distribution directory without metadata is not one we can use
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if pkg.Name != "" && pkg.Version != "" {
+			details[pkg.Name+"@"+pkg.Version] = pkg
+		}
+
+		if d.IsDir() {
+			return fs.SkipDir
+		}
+
+		return nil
+	})
+
+	packages := make([]PackageDetails, 0, len(details))
+
+	for _, pkg := range details {
+		packages = append(packages, pkg)
+	}
+
+	if err != nil {
+		return packages, fmt.Errorf("could not extract from %s: %w", pathToSitePackages, err)
+	}
+
+	return packages, nil
+}
+
+// FromPythonInstalled attempts to parse the given directory as a "python-installed" lockfile,
+// by reading the metadata of the Python distributions installed within it.
+func FromPythonInstalled(pathToSitePackages string) (Lockfile, error) {
+	packages, err := ParsePythonInstalled(pathToSitePackages)
+
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name == packages[j].Name {
+			return packages[i].Version < packages[j].Version
+		}
+
+		return packages[i].Name < packages[j].Name
+	})
+
+	return Lockfile{
+		FilePath: pathToSitePackages,
+		ParsedAs: "python-installed",
+		Packages: packages,
+	}, err
+}
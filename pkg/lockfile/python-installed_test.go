@@ -0,0 +1,90 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestParsePythonInstalled_DirectoryDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePythonInstalled("fixtures/python-installed/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePythonInstalled_Empty(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePythonInstalled("fixtures/python-installed/empty")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePythonInstalled_Venv(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePythonInstalled("fixtures/python-installed/venv")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:      "flask",
+			Version:   "2.0.1",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+		},
+		{
+			Name:      "pyyaml",
+			Version:   "6.0",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+		},
+		{
+			Name:      "requests",
+			Version:   "2.31.0",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+		},
+		{
+			Name:      "six",
+			Version:   "1.16.0",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+		},
+		{
+			Name:      "zope-interface",
+			Version:   "6.1",
+			Ecosystem: lockfile.PipEcosystem,
+			CompareAs: lockfile.PipEcosystem,
+		},
+	})
+}
+
+func TestFromPythonInstalled(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := lockfile.FromPythonInstalled("fixtures/python-installed/venv/lib/python3.11/site-packages")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	if parsed.ParsedAs != "python-installed" {
+		t.Errorf("Expected ParsedAs to be %s but got %s", "python-installed", parsed.ParsedAs)
+	}
+
+	if len(parsed.Packages) != 5 || parsed.Packages[0].Name != "flask" {
+		t.Errorf("Expected packages to be sorted by name, but got %v", parsed.Packages)
+	}
+}
@@ -1,5 +1,7 @@
 package lockfile
 
+import "slices"
+
 type PackageDetails struct {
 	Name      string    `json:"name"`
 	Version   string    `json:"version"`
@@ -7,6 +9,53 @@ type PackageDetails struct {
 	Ecosystem Ecosystem `json:"ecosystem,omitempty"`
 	CompareAs Ecosystem `json:"compareAs,omitempty"`
 	DepGroups []string  `json:"-"`
+	// Ignores are the vulnerabilities ignored by comments next to the package, for lockfiles that support them
+	Ignores []IgnoreComment `json:"-"`
+	// Relationship is whether the package is a direct or transitive dependency, for lockfiles that record it
+	Relationship DependencyRelationship `json:"-"`
+	// VersionConstraint is the constraint on the version of the package, for manifests
+	// that do not pin the exact version, in which case Version is empty
+	VersionConstraint string `json:"-"`
+	// Workspace is the path of the workspace member that the package belongs to, relative to the
+	// lockfile of a workspace root, or empty if it belongs to the root or is shared between members
+	Workspace string `json:"-"`
+	// Dependencies are the DependencyKey of each package in the lockfile that the package directly depends on,
+	// for lockfiles that record them
+	Dependencies []string `json:"-"`
+}
+
+// DependencyKey identifies the package within the dependency graph of the lockfile it is from,
+// which is its name and version (or commit, if it does not have a version)
+func (pd PackageDetails) DependencyKey() string {
+	switch {
+	case pd.Version != "":
+		return pd.Name + "@" + pd.Version
+	case pd.Commit != "":
+		return pd.Name + "@" + pd.Commit
+	}
+
+	return pd.Name
+}
+
+// DependencyRelationship is how a package is depended on by the project
+type DependencyRelationship string
+
+const (
+	// UnknownDependency is used when the lockfile does not record how a package is depended on
+	UnknownDependency DependencyRelationship = ""
+	// DirectDependency is a package that is depended on by the project itself
+	DirectDependency DependencyRelationship = "direct"
+	// TransitiveDependency is a package that is only depended on by other packages
+	TransitiveDependency DependencyRelationship = "transitive"
+)
+
+// relationship returns DirectDependency if isDirect is true, and TransitiveDependency otherwise
+func relationship(isDirect bool) DependencyRelationship {
+	if isDirect {
+		return DirectDependency
+	}
+
+	return TransitiveDependency
 }
 
 type Ecosystem string
@@ -23,9 +72,10 @@ func (sys Ecosystem) IsDevGroup(groups []string) bool {
 	case ConanEcosystem:
 		dev = "build-requires"
 	case MavenEcosystem:
-		dev = "test"
-	case AlpineEcosystem, BundlerEcosystem, CargoEcosystem, CRANEcosystem,
-		DebianEcosystem, GoEcosystem, MixEcosystem, NuGetEcosystem:
+		// provided dependencies are supplied by the runtime, so like test dependencies are not shipped
+		return slices.Contains(groups, "test") || slices.Contains(groups, "provided")
+	case AlpineEcosystem, BrewEcosystem, BundlerEcosystem, CargoEcosystem, CocoaPodsEcosystem, CRANEcosystem,
+		DebianEcosystem, GoEcosystem, MixEcosystem, NuGetEcosystem, TerraformEcosystem:
 		// We are not able to report development dependencies for these ecosystems.
 		return false
 	}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// purlType is the type and namespace of the Package URLs of an ecosystem,
+// with an empty namespace meaning that it comes from the name of each package
+type purlType struct {
+	Type      string
+	Namespace string
+}
+
+// purlTypes are the Package URL types of the ecosystems that have one,
+// which are the reverse of purlEcosystems
+var purlTypes = map[Ecosystem]purlType{
+	EcosystemAlpine:        {Type: packageurl.TypeApk, Namespace: "alpine"},
+	EcosystemBitnami:       {Type: packageurl.TypeBitnami},
+	EcosystemConanCenter:   {Type: packageurl.TypeConan},
+	EcosystemCRAN:          {Type: packageurl.TypeCran},
+	EcosystemCratesIO:      {Type: packageurl.TypeCargo},
+	EcosystemDebian:        {Type: packageurl.TypeDebian, Namespace: "debian"},
+	EcosystemGitHubActions: {Type: packageurl.TypeGithub},
+	EcosystemGo:            {Type: packageurl.TypeGolang},
+	EcosystemHex:           {Type: packageurl.TypeHex},
+	EcosystemMaven:         {Type: packageurl.TypeMaven},
+	EcosystemNPM:           {Type: packageurl.TypeNPM},
+	EcosystemNuGet:         {Type: packageurl.TypeNuget},
+	EcosystemOSSFuzz:       {Type: packageurl.TypeGeneric},
+	EcosystemPackagist:     {Type: packageurl.TypeComposer},
+	EcosystemPub:           {Type: packageurl.TypePub},
+	EcosystemPyPI:          {Type: packageurl.TypePyPi},
+	EcosystemRubyGems:      {Type: packageurl.TypeGem},
+	EcosystemSwiftURL:      {Type: packageurl.TypeSwift},
+}
+
+// PURL returns the canonical Package URL of the package, which can be converted back with PURLToPackage,
+// or an empty string if its ecosystem does not have a Package URL type (such as packages only identified by a commit).
+//
+// The release of the ecosystem (such as "11" in "Debian:11") is not included, as the Package URL
+// identifies the package rather than the distribution it was installed from.
+func (p PackageInfo) PURL() string {
+	if p.Name == "" {
+		return ""
+	}
+
+	t, ok := purlTypes[p.BaseEcosystem()]
+	if !ok {
+		return ""
+	}
+
+	namespace, name := t.Namespace, p.Name
+	if namespace == "" {
+		switch t.Type {
+		case packageurl.TypeMaven:
+			// Maven uses : to separate the group and artifact
+			namespace, name, _ = strings.Cut(p.Name, ":")
+			if name == "" {
+				namespace, name = "", p.Name
+			}
+		default:
+			if i := strings.LastIndex(p.Name, "/"); i != -1 {
+				namespace, name = p.Name[:i], p.Name[i+1:]
+			}
+		}
+	}
+
+	purl := packageurl.NewPackageURL(t.Type, namespace, name, p.Version, nil, "")
+	if err := purl.Normalize(); err != nil {
+		return ""
+	}
+
+	return purl.ToString()
+}
@@ -0,0 +1,140 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestPackageInfo_PURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		pkg  models.PackageInfo
+		want string
+	}{
+		{
+			name: "npm scoped package",
+			pkg:  models.PackageInfo{Name: "@babel/core", Version: "7.22.0", Ecosystem: "npm"},
+			want: "pkg:npm/%40babel/core@7.22.0",
+		},
+		{
+			name: "Maven package",
+			pkg:  models.PackageInfo{Name: "org.hdrhistogram:HdrHistogram", Version: "2.1.12", Ecosystem: "Maven"},
+			want: "pkg:maven/org.hdrhistogram/HdrHistogram@2.1.12",
+		},
+		{
+			name: "PyPI package is normalized",
+			pkg:  models.PackageInfo{Name: "Django_Rest", Version: "1.0.0", Ecosystem: "PyPI"},
+			want: "pkg:pypi/django-rest@1.0.0",
+		},
+		{
+			name: "Go module",
+			pkg:  models.PackageInfo{Name: "github.com/gogo/protobuf", Version: "1.3.2", Ecosystem: "Go"},
+			want: "pkg:golang/github.com/gogo/protobuf@1.3.2",
+		},
+		{
+			name: "Debian package with a release",
+			pkg:  models.PackageInfo{Name: "nginx", Version: "1.18.0-6.1", Ecosystem: "Debian:11"},
+			want: "pkg:deb/debian/nginx@1.18.0-6.1",
+		},
+		{
+			name: "package without a version",
+			pkg:  models.PackageInfo{Name: "rails", Ecosystem: "RubyGems"},
+			want: "pkg:gem/rails",
+		},
+		{
+			name: "ecosystem without a Package URL type",
+			pkg:  models.PackageInfo{Name: "linux", Version: "6.1.0", Ecosystem: "Linux"},
+			want: "",
+		},
+		{
+			name: "package identified by a commit",
+			pkg:  models.PackageInfo{Commit: "9a6bd55c9d0722cb101fe85a3b22d89e4ff4fe52"},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Reinitialize for t.Parallel()
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.pkg.PURL(); got != tt.want {
+				t.Errorf("PURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageInfo_PURL_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// a package for each ecosystem, which is nil for ecosystems that do not have a Package URL type
+	packages := map[models.Ecosystem]*models.PackageInfo{
+		models.EcosystemGo:            {Name: "github.com/gogo/protobuf", Version: "1.3.2"},
+		models.EcosystemNPM:           {Name: "@babel/core", Version: "7.22.0"},
+		models.EcosystemOSSFuzz:       {Name: "libxml2", Version: "2.9.14"},
+		models.EcosystemPyPI:          {Name: "django", Version: "4.2.0"},
+		models.EcosystemRubyGems:      {Name: "rails", Version: "7.0.4"},
+		models.EcosystemCratesIO:      {Name: "memoffset", Version: "0.6.1"},
+		models.EcosystemPackagist:     {Name: "symfony/http-kernel", Version: "6.3.0"},
+		models.EcosystemMaven:         {Name: "org.hdrhistogram:HdrHistogram", Version: "2.1.12"},
+		models.EcosystemNuGet:         {Name: "Newtonsoft.Json", Version: "13.0.1"},
+		models.EcosystemLinux:         nil,
+		models.EcosystemDebian:        {Name: "nginx", Version: "1.18.0-6.1"},
+		models.EcosystemAlpine:        {Name: "zlib", Version: "1.2.13-r0"},
+		models.EcosystemHex:           {Name: "plug", Version: "1.14.0"},
+		models.EcosystemAndroid:       nil,
+		models.EcosystemGitHubActions: {Name: "actions/checkout", Version: "4.1.0"},
+		models.EcosystemPub:           {Name: "http", Version: "0.13.5"},
+		models.EcosystemConanCenter:   {Name: "openssl", Version: "3.0.8"},
+		models.EcosystemRockyLinux:    nil,
+		models.EcosystemAlmaLinux:     nil,
+		models.EcosystemBitnami:       {Name: "node", Version: "18.19.1"},
+		models.EcosystemPhotonOS:      nil,
+		models.EcosystemCRAN:          {Name: "ggplot2", Version: "3.4.0"},
+		models.EcosystemBioconductor:  nil,
+		models.EcosystemSwiftURL:      {Name: "github.com/apple/swift-nio", Version: "2.41.0"},
+	}
+
+	for _, ecosystem := range models.Ecosystems {
+		pkg, ok := packages[ecosystem]
+		if !ok {
+			t.Errorf("No package to round trip for the %s ecosystem", ecosystem)
+
+			continue
+		}
+
+		if pkg == nil {
+			if purl := (models.PackageInfo{Name: "name", Version: "1.0.0", Ecosystem: string(ecosystem)}).PURL(); purl != "" {
+				t.Errorf("Expected %s to not have a Package URL type, but got %q", ecosystem, purl)
+			}
+
+			continue
+		}
+
+		want := *pkg
+		want.Ecosystem = string(ecosystem)
+
+		purl := want.PURL()
+		if purl == "" {
+			t.Errorf("Expected %s to have a Package URL type", ecosystem)
+
+			continue
+		}
+
+		got, err := models.PURLToPackage(purl)
+		if err != nil {
+			t.Errorf("PURLToPackage(%q) error = %v", purl, err)
+
+			continue
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("PURLToPackage(%q) (-want +got):\n%s", purl, diff)
+		}
+	}
+}
@@ -8,8 +8,12 @@ import (
 // * means it should match any namespace string
 var purlEcosystems = map[string]map[string]Ecosystem{
 	"apk":      {"alpine": EcosystemAlpine},
+	"bitnami":  {"*": EcosystemBitnami},
 	"cargo":    {"*": EcosystemCratesIO},
+	"conan":    {"*": EcosystemConanCenter},
+	"cran":     {"*": EcosystemCRAN},
 	"deb":      {"debian": EcosystemDebian},
+	"github":   {"*": EcosystemGitHubActions},
 	"hex":      {"*": EcosystemHex},
 	"golang":   {"*": EcosystemGo},
 	"maven":    {"*": EcosystemMaven},
@@ -17,8 +21,10 @@ var purlEcosystems = map[string]map[string]Ecosystem{
 	"npm":      {"*": EcosystemNPM},
 	"composer": {"*": EcosystemPackagist},
 	"generic":  {"*": EcosystemOSSFuzz},
+	"pub":      {"*": EcosystemPub},
 	"pypi":     {"*": EcosystemPyPI},
 	"gem":      {"*": EcosystemRubyGems},
+	"swift":    {"*": EcosystemSwiftURL},
 }
 
 func getPURLEcosystem(pkgURL packageurl.PackageURL) Ecosystem {
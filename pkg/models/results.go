@@ -1,14 +1,49 @@
 package models
 
 import (
+	"encoding/json"
+	"io"
+	"reflect"
 	"slices"
 	"strings"
+	"time"
 )
 
 // Combined vulnerabilities found for the scanned packages
 type VulnerabilityResults struct {
 	Results                    []PackageSource            `json:"results"`
 	ExperimentalAnalysisConfig ExperimentalAnalysisConfig `json:"experimental_config"`
+	Databases                  []LocalDatabase            `json:"databases,omitempty"`
+	PackageCounts              *PackageCounts             `json:"package_counts,omitempty"`
+}
+
+// PackageCounts is the number of packages that were scanned, regardless of whether
+// they were included in the results, in total and by ecosystem
+type PackageCounts struct {
+	Total int `json:"total"`
+	// Ecosystems is keyed by the base ecosystem of the packages, and so does not
+	// include packages without an ecosystem (such as those identified by a commit)
+	Ecosystems map[string]int `json:"ecosystems"`
+}
+
+// ScanMetadata describes the build of osv-scanner that produced a report and how it was run,
+// so that the report can be audited later
+type ScanMetadata struct {
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit"`
+	BuildDate string    `json:"build_date"`
+	ScannedAt time.Time `json:"scanned_at"`
+	// Args are the command-line arguments osv-scanner was run with, with any secrets redacted
+	Args []string `json:"args"`
+}
+
+// LocalDatabase describes a local copy of the OSV database that packages were checked against
+type LocalDatabase struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// the most recent modified time of the vulnerabilities in the database
+	LastModified       time.Time `json:"last_modified"`
+	VulnerabilityCount int       `json:"vulnerability_count"`
 }
 
 // ExperimentalAnalysisConfig is an experimental type intended to contain the
@@ -20,6 +55,61 @@ type ExperimentalAnalysisConfig struct {
 type ExperimentalLicenseConfig struct {
 	Summary   bool      `json:"summary"`
 	Allowlist []License `json:"allowlist"`
+	Denylist  []License `json:"denylist,omitempty"`
+}
+
+// HasViolationPolicy returns true if licenses were checked against either an allowlist or a denylist
+func (config ExperimentalLicenseConfig) HasViolationPolicy() bool {
+	return len(config.Allowlist) > 0 || len(config.Denylist) > 0
+}
+
+// WithoutFiltered returns the results without any groups that were filtered out, and their vulnerabilities,
+// removing packages and sources that are left without any findings
+func (vulns *VulnerabilityResults) WithoutFiltered() VulnerabilityResults {
+	results := *vulns
+	results.Results = []PackageSource{}
+
+	for _, pkgSrc := range vulns.Results {
+		var packages []PackageVulns
+		for _, pkg := range pkgSrc.Packages {
+			var filteredIDs []string
+			var groups []GroupInfo
+			for _, group := range pkg.Groups {
+				if group.Filtered {
+					filteredIDs = append(filteredIDs, group.IDs...)
+				} else {
+					groups = append(groups, group)
+				}
+			}
+
+			if len(filteredIDs) == 0 {
+				packages = append(packages, pkg)
+				continue
+			}
+
+			var vulnerabilities []Vulnerability
+			for _, v := range pkg.Vulnerabilities {
+				if !slices.Contains(filteredIDs, v.ID) {
+					vulnerabilities = append(vulnerabilities, v)
+				}
+			}
+
+			if len(vulnerabilities) == 0 && len(pkg.LicenseViolations) == 0 {
+				continue
+			}
+
+			pkg.Groups = groups
+			pkg.Vulnerabilities = vulnerabilities
+			packages = append(packages, pkg)
+		}
+
+		if len(packages) > 0 {
+			pkgSrc.Packages = packages
+			results.Results = append(results.Results, pkgSrc)
+		}
+	}
+
+	return results
 }
 
 // Flatten the grouped/nested vulnerability results into one flat array.
@@ -51,6 +141,70 @@ func (vulns *VulnerabilityResults) Flatten() []VulnerabilityFlattened {
 	return results
 }
 
+// LoadResults reads results that have previously been written in JSON format,
+// such as by the osv-scanner JSON output.
+func LoadResults(r io.Reader) (VulnerabilityResults, error) {
+	var results VulnerabilityResults
+	err := json.NewDecoder(r).Decode(&results)
+
+	return results, err
+}
+
+// Merge appends the results of other into these results, skipping any package sources
+// that are identical to one that is already present, and summing the package counts.
+func (vulns *VulnerabilityResults) Merge(other VulnerabilityResults) {
+	for _, pkgSrc := range other.Results {
+		isDuplicate := slices.ContainsFunc(vulns.Results, func(existing PackageSource) bool {
+			return reflect.DeepEqual(existing, pkgSrc)
+		})
+		if !isDuplicate {
+			vulns.Results = append(vulns.Results, pkgSrc)
+		}
+	}
+
+	licenses := &vulns.ExperimentalAnalysisConfig.Licenses
+	licenses.Summary = licenses.Summary || other.ExperimentalAnalysisConfig.Licenses.Summary
+	for _, l := range other.ExperimentalAnalysisConfig.Licenses.Allowlist {
+		if !slices.Contains(licenses.Allowlist, l) {
+			licenses.Allowlist = append(licenses.Allowlist, l)
+		}
+	}
+	for _, l := range other.ExperimentalAnalysisConfig.Licenses.Denylist {
+		if !slices.Contains(licenses.Denylist, l) {
+			licenses.Denylist = append(licenses.Denylist, l)
+		}
+	}
+
+	for _, db := range other.Databases {
+		if !slices.Contains(vulns.Databases, db) {
+			vulns.Databases = append(vulns.Databases, db)
+		}
+	}
+
+	vulns.PackageCounts = vulns.PackageCounts.merge(other.PackageCounts)
+}
+
+// merge returns the sum of the counts, which is nil if both are nil
+func (counts *PackageCounts) merge(other *PackageCounts) *PackageCounts {
+	if counts == nil && other == nil {
+		return nil
+	}
+
+	merged := &PackageCounts{Ecosystems: map[string]int{}}
+	for _, c := range []*PackageCounts{counts, other} {
+		if c == nil {
+			continue
+		}
+
+		merged.Total += c.Total
+		for ecosystem, count := range c.Ecosystems {
+			merged.Ecosystems[ecosystem] += count
+		}
+	}
+
+	return merged
+}
+
 func getGroupInfoForVuln(groups []GroupInfo, vulnID string) GroupInfo {
 	// groupIdx should never be -1 since vulnerabilities should always be in one group
 	groupIdx := slices.IndexFunc(groups, func(g GroupInfo) bool { return slices.Contains(g.IDs, vulnID) })
@@ -73,6 +227,9 @@ type VulnerabilityFlattened struct {
 type SourceInfo struct {
 	Path string `json:"path"`
 	Type string `json:"type"`
+	// Workspace is the path of the workspace member that the packages belong to, relative to
+	// the lockfile of the workspace root, for lockfiles that record which member uses what
+	Workspace string `json:"workspace,omitempty"`
 }
 
 type Metadata struct {
@@ -81,6 +238,10 @@ type Metadata struct {
 }
 
 func (s SourceInfo) String() string {
+	if s.Workspace != "" {
+		return s.Type + ":" + s.Path + " (" + s.Workspace + ")"
+	}
+
 	return s.Type + ":" + s.Path
 }
 
@@ -88,6 +249,9 @@ func (s SourceInfo) String() string {
 type PackageSource struct {
 	Source   SourceInfo     `json:"source"`
 	Packages []PackageVulns `json:"packages"`
+	// DependencyGraph maps each package in the source (as "name@version") to the packages that directly
+	// depend on it, which is only present for sources that record the dependencies of their packages
+	DependencyGraph map[string][]string `json:"dependency_graph,omitempty"`
 }
 
 // License is an SPDX license.
@@ -96,12 +260,13 @@ type License string
 // Vulnerabilities grouped by package
 // TODO: rename this to be Package as it now includes license information too.
 type PackageVulns struct {
-	Package           PackageInfo     `json:"package"`
-	DepGroups         []string        `json:"dependency_groups,omitempty"`
-	Vulnerabilities   []Vulnerability `json:"vulnerabilities,omitempty"`
-	Groups            []GroupInfo     `json:"groups,omitempty"`
-	Licenses          []License       `json:"licenses,omitempty"`
-	LicenseViolations []License       `json:"license_violations,omitempty"`
+	Package                PackageInfo     `json:"package"`
+	DepGroups              []string        `json:"dependency_groups,omitempty"`
+	DependencyRelationship string          `json:"dependency_relationship,omitempty"`
+	Vulnerabilities        []Vulnerability `json:"vulnerabilities,omitempty"`
+	Groups                 []GroupInfo     `json:"groups,omitempty"`
+	Licenses               []License       `json:"licenses,omitempty"`
+	LicenseViolations      []License       `json:"license_violations,omitempty"`
 }
 
 type GroupInfo struct {
@@ -112,8 +277,27 @@ type GroupInfo struct {
 	// Map of Vulnerability IDs to AnalysisInfo
 	ExperimentalAnalysis map[string]AnalysisInfo `json:"experimentalAnalysis,omitempty"`
 	MaxSeverity          string                  `json:"max_severity"`
+	// AffectedRanges are the version ranges of the package that are affected by the vulnerabilities in the group,
+	// which are only included when requested
+	AffectedRanges []string `json:"affected_ranges,omitempty"`
+	// Summary is the summary of the first vulnerability in the group that has one, which is only included when requested
+	Summary string `json:"summary,omitempty"`
+	// Related are the IDs of the vulnerabilities that are related to those in the group
+	// (but are not aliases of them), which are only included when requested
+	Related []string `json:"related,omitempty"`
+	// Filtered is true if the group was ignored by a config file or an inline comment, which is
+	// only included in the results when requested, so that there is a record of what was ignored
+	Filtered bool `json:"filtered,omitempty"`
+	// FilterReason is the reason given for ignoring the group, if it is filtered
+	FilterReason string `json:"filterReason,omitempty"`
+	// FilterSource is where the group was ignored, which is the path of the config file or
+	// InlineIgnoreFilterSource, if it is filtered
+	FilterSource string `json:"filterSource,omitempty"`
 }
 
+// InlineIgnoreFilterSource is the FilterSource of groups that were ignored by an inline comment next to the package
+const InlineIgnoreFilterSource = "inline comment"
+
 // IsCalled returns true if any analysis performed determines that the vulnerability is being called
 // Also returns true if no analysis is performed
 func (groupInfo *GroupInfo) IsCalled() bool {
@@ -147,14 +331,17 @@ func (v *Vulnerability) FixedVersions() map[Package][]string {
 	for _, a := range v.Affected {
 		packageKey := a.Package
 		packageKey.Purl = ""
+		// Also record the fixed versions under the ecosystem without any release suffix
+		// (e.g. "Debian" for "Debian:11"), as that is what some package sources report
+		baseKey := packageKey
+		baseKey.Ecosystem = baseEcosystem(string(packageKey.Ecosystem))
 		for _, r := range a.Ranges {
 			for _, e := range r.Events {
 				if e.Fixed != "" {
 					output[packageKey] = append(output[packageKey], e.Fixed)
-					if strings.Contains(string(packageKey.Ecosystem), ":") {
-						packageKey.Ecosystem = Ecosystem(strings.Split(string(packageKey.Ecosystem), ":")[0])
+					if baseKey != packageKey {
+						output[baseKey] = append(output[baseKey], e.Fixed)
 					}
-					output[packageKey] = append(output[packageKey], e.Fixed)
 				}
 			}
 		}
@@ -163,8 +350,104 @@ func (v *Vulnerability) FixedVersions() map[Package][]string {
 	return output
 }
 
+// AffectedRanges returns a map of human-readable affected version ranges for each package,
+// such as ">=1.0.0, <1.2.3", with one entry per disjoint range.
+//
+// Git ranges are not included, as they are described by commits rather than versions.
+func (v *Vulnerability) AffectedRanges() map[Package][]string {
+	output := map[Package][]string{}
+	for _, a := range v.Affected {
+		packageKey := a.Package
+		packageKey.Purl = ""
+		// Also record the ranges under the ecosystem without any release suffix, like FixedVersions
+		baseKey := packageKey
+		baseKey.Ecosystem = baseEcosystem(string(packageKey.Ecosystem))
+		for _, r := range a.Ranges {
+			if r.Type == RangeGit {
+				continue
+			}
+
+			for _, affectedRange := range describeRangeEvents(r.Events) {
+				output[packageKey] = append(output[packageKey], affectedRange)
+				if baseKey != packageKey {
+					output[baseKey] = append(output[baseKey], affectedRange)
+				}
+			}
+		}
+	}
+
+	return output
+}
+
+// describeRangeEvents turns the timeline of events of a range into a description of each
+// of the version ranges it covers, where an introduced version of "0" has no lower bound
+func describeRangeEvents(events []Event) []string {
+	var descriptions []string
+	lower := ""
+	open := false
+
+	describe := func(upper string) {
+		var bounds []string
+		if lower != "" && lower != "0" {
+			bounds = append(bounds, ">="+lower)
+		}
+		if upper != "" {
+			bounds = append(bounds, upper)
+		}
+		if len(bounds) == 0 {
+			bounds = append(bounds, "*")
+		}
+
+		descriptions = append(descriptions, strings.Join(bounds, ", "))
+		lower = ""
+		open = false
+	}
+
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			// a range that was never closed is still affected up to the next introduced version
+			if open {
+				describe("")
+			}
+			lower = e.Introduced
+			open = true
+		case !open:
+			continue
+		case e.Fixed != "":
+			describe("<" + e.Fixed)
+		case e.LastAffected != "":
+			describe("<=" + e.LastAffected)
+		case e.Limit != "":
+			describe("<" + e.Limit)
+		}
+	}
+
+	if open {
+		describe("")
+	}
+
+	return descriptions
+}
+
 type AnalysisInfo struct {
 	Called bool `json:"called"`
+	// Evidence of where the vulnerable code is called, if it is called
+	Evidence []AnalysisEvidence `json:"evidence,omitempty"`
+}
+
+// AnalysisEvidence describes a call to vulnerable code that was found during call analysis
+type AnalysisEvidence struct {
+	// Symbol is the vulnerable function (or method) that is called
+	Symbol string `json:"symbol"`
+	// Caller is the function in the scanned code that (possibly indirectly) calls the symbol, if known
+	Caller string `json:"caller,omitempty"`
+	// File and Line are the position of the call within the caller, if known
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+	// CallPath is one of the chains of functions through which the caller reaches the symbol,
+	// starting with the caller and ending with the symbol, if it was requested and is known
+	CallPath []string `json:"callPath,omitempty"`
 }
 
 // Specific package information
@@ -173,4 +456,35 @@ type PackageInfo struct {
 	Version   string `json:"version"`
 	Ecosystem string `json:"ecosystem"`
 	Commit    string `json:"commit,omitempty"`
+	// ImageLayerDigest is the digest of the container image layer that introduced the package,
+	// for packages that were found by scanning an image
+	ImageLayerDigest string `json:"imageLayerDigest,omitempty"`
+	// ImageLayerCommand is the command that created the layer, such as a line of the Dockerfile
+	ImageLayerCommand string `json:"imageLayerCommand,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// This method includes the Package URL of the package, for it to be identified by tools
+// that do not know about the ecosystems of OSV (such as SBOM tools).
+func (p PackageInfo) MarshalJSON() ([]byte, error) {
+	type rawPackageInfo PackageInfo // alias PackageInfo to avoid recursion during Marshal
+	type wrapper struct {
+		rawPackageInfo
+		PURL string `json:"purl,omitempty"`
+	}
+
+	return json.Marshal(wrapper{rawPackageInfo: rawPackageInfo(p), PURL: p.PURL()})
+}
+
+// BaseEcosystem returns the ecosystem of the package without any release suffix,
+// such as "Debian" for "Debian:11" or "Alpine" for "Alpine:v3.16"
+func (p PackageInfo) BaseEcosystem() Ecosystem {
+	return baseEcosystem(p.Ecosystem)
+}
+
+func baseEcosystem(ecosystem string) Ecosystem {
+	base, _, _ := strings.Cut(ecosystem, ":")
+
+	return Ecosystem(base)
 }
@@ -1,7 +1,11 @@
 package models_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/osv-scanner/pkg/models"
@@ -120,3 +124,295 @@ func TestFlatten(t *testing.T) {
 		t.Errorf("Flatten() returned unexpected result (-got +want):\n%s", diff)
 	}
 }
+
+func TestLoadResults_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "mine1", Version: "1.2.3", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{
+							{
+								ID:       "GHSA-123",
+								Aliases:  []string{"CVE-2023-1"},
+								Modified: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+							},
+						},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"GHSA-123"}, Aliases: []string{"CVE-2023-1", "GHSA-123"}, MaxSeverity: "7.5"},
+						},
+					},
+				},
+			},
+		},
+		ExperimentalAnalysisConfig: models.ExperimentalAnalysisConfig{
+			Licenses: models.ExperimentalLicenseConfig{Allowlist: []models.License{"MIT"}},
+		},
+		PackageCounts: &models.PackageCounts{Total: 2, Ecosystems: map[string]int{"npm": 1}},
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Failed to marshal results: %v", err)
+	}
+
+	got, err := models.LoadResults(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("LoadResults() error = %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LoadResults() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadResults_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := models.LoadResults(strings.NewReader(`{"results": {}}`))
+	if err == nil {
+		t.Errorf("LoadResults() did not return an error")
+	}
+}
+
+func TestVulnerabilityResults_Merge(t *testing.T) {
+	t.Parallel()
+
+	sourceA := models.PackageSource{
+		Source: models.SourceInfo{Path: "/service-a/go.mod", Type: "lockfile"},
+		Packages: []models.PackageVulns{
+			{Package: models.PackageInfo{Name: "github.com/gogo/protobuf", Version: "1.3.1", Ecosystem: "Go"}},
+		},
+	}
+	sourceB := models.PackageSource{
+		Source: models.SourceInfo{Path: "/service-b/go.mod", Type: "lockfile"},
+		Packages: []models.PackageVulns{
+			{Package: models.PackageInfo{Name: "golang.org/x/net", Version: "0.1.0", Ecosystem: "Go"}},
+		},
+	}
+	sourceBChanged := models.PackageSource{
+		Source: models.SourceInfo{Path: "/service-b/go.mod", Type: "lockfile"},
+		Packages: []models.PackageVulns{
+			{Package: models.PackageInfo{Name: "golang.org/x/net", Version: "0.2.0", Ecosystem: "Go"}},
+		},
+	}
+
+	dbGo := models.LocalDatabase{Name: "Go", Path: "/cache/Go/all.zip", VulnerabilityCount: 10}
+	dbNpm := models.LocalDatabase{Name: "npm", Path: "/cache/npm/all.zip", VulnerabilityCount: 20}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{sourceA, sourceB},
+		ExperimentalAnalysisConfig: models.ExperimentalAnalysisConfig{
+			Licenses: models.ExperimentalLicenseConfig{Allowlist: []models.License{"MIT"}},
+		},
+		Databases:     []models.LocalDatabase{dbGo},
+		PackageCounts: &models.PackageCounts{Total: 3, Ecosystems: map[string]int{"Go": 2}},
+	}
+
+	results.Merge(models.VulnerabilityResults{
+		Results: []models.PackageSource{sourceB, sourceBChanged},
+		ExperimentalAnalysisConfig: models.ExperimentalAnalysisConfig{
+			Licenses: models.ExperimentalLicenseConfig{Summary: true, Allowlist: []models.License{"Apache-2.0", "MIT"}},
+		},
+		Databases:     []models.LocalDatabase{dbGo, dbNpm},
+		PackageCounts: &models.PackageCounts{Total: 5, Ecosystems: map[string]int{"Go": 1, "npm": 4}},
+	})
+
+	want := models.VulnerabilityResults{
+		Results: []models.PackageSource{sourceA, sourceB, sourceBChanged},
+		ExperimentalAnalysisConfig: models.ExperimentalAnalysisConfig{
+			Licenses: models.ExperimentalLicenseConfig{Summary: true, Allowlist: []models.License{"MIT", "Apache-2.0"}},
+		},
+		Databases:     []models.LocalDatabase{dbGo, dbNpm},
+		PackageCounts: &models.PackageCounts{Total: 8, Ecosystems: map[string]int{"Go": 3, "npm": 4}},
+	}
+
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Errorf("Merge() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// merging into results without counts (such as from an older version) takes the other counts
+	empty := models.VulnerabilityResults{}
+	empty.Merge(models.VulnerabilityResults{PackageCounts: want.PackageCounts})
+	if diff := cmp.Diff(want.PackageCounts, empty.PackageCounts); diff != "" {
+		t.Errorf("Merge() returned unexpected package counts (-want +got):\n%s", diff)
+	}
+}
+
+func TestVulnerabilityResults_WithoutFiltered(t *testing.T) {
+	t.Parallel()
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GHSA-1"}, {ID: "GHSA-2"}},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"GHSA-1"}, Filtered: true, FilterReason: "not exploitable"},
+							{IDs: []string{"GHSA-2"}},
+						},
+					},
+					{
+						Package:         models.PackageInfo{Name: "mine2", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GHSA-3"}},
+						Groups:          []models.GroupInfo{{IDs: []string{"GHSA-3"}, Filtered: true}},
+					},
+				},
+			},
+			{
+				Source: models.SourceInfo{Path: "/path/to/go.mod", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "mine3", Version: "1.0.0", Ecosystem: "Go"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GO-1"}},
+						Groups:          []models.GroupInfo{{IDs: []string{"GO-1"}, Filtered: true}},
+					},
+				},
+			},
+		},
+	}
+
+	want := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GHSA-2"}},
+						Groups:          []models.GroupInfo{{IDs: []string{"GHSA-2"}}},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, results.WithoutFiltered()); diff != "" {
+		t.Errorf("WithoutFiltered() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestVulnerability_FixedVersions(t *testing.T) {
+	t.Parallel()
+
+	vuln := models.Vulnerability{
+		ID: "DSA-1234-1",
+		Affected: []models.Affected{
+			{
+				Package: models.Package{Ecosystem: "Debian:11", Name: "curl", Purl: "pkg:deb/debian/curl"},
+				Ranges: []models.Range{{
+					Type:   models.RangeEcosystem,
+					Events: []models.Event{{Introduced: "0"}, {Fixed: "7.74.0-1.3+deb11u1"}},
+				}},
+			},
+			{
+				Package: models.Package{Ecosystem: "npm", Name: "mine1"},
+				Ranges: []models.Range{{
+					Type:   models.RangeSemVer,
+					Events: []models.Event{{Introduced: "0"}, {Fixed: "1.0.1"}, {Introduced: "2.0.0"}, {Fixed: "2.0.1"}},
+				}},
+			},
+		},
+	}
+
+	want := map[models.Package][]string{
+		{Ecosystem: "Debian:11", Name: "curl"}: {"7.74.0-1.3+deb11u1"},
+		{Ecosystem: "Debian", Name: "curl"}:    {"7.74.0-1.3+deb11u1"},
+		{Ecosystem: "npm", Name: "mine1"}:      {"1.0.1", "2.0.1"},
+	}
+
+	if diff := cmp.Diff(want, vuln.FixedVersions()); diff != "" {
+		t.Errorf("FixedVersions() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestVulnerability_AffectedRanges(t *testing.T) {
+	t.Parallel()
+
+	vuln := models.Vulnerability{
+		ID: "DSA-1234-1",
+		Affected: []models.Affected{
+			{
+				Package: models.Package{Ecosystem: "Debian:11", Name: "curl", Purl: "pkg:deb/debian/curl"},
+				Ranges: []models.Range{{
+					Type:   models.RangeEcosystem,
+					Events: []models.Event{{Introduced: "0"}, {Fixed: "7.74.0-1.3+deb11u1"}},
+				}},
+			},
+			{
+				Package: models.Package{Ecosystem: "npm", Name: "mine1"},
+				Ranges: []models.Range{{
+					Type:   models.RangeSemVer,
+					Events: []models.Event{{Introduced: "1.0.0"}, {Fixed: "1.2.3"}, {Introduced: "2.0.0"}, {LastAffected: "2.1.0"}},
+				}},
+			},
+			{
+				Package: models.Package{Ecosystem: "npm", Name: "mine2"},
+				Ranges: []models.Range{
+					{
+						Type:   models.RangeSemVer,
+						Events: []models.Event{{Introduced: "0"}},
+					},
+					{
+						Type:   models.RangeSemVer,
+						Events: []models.Event{{Introduced: "3.0.0"}},
+					},
+				},
+			},
+			{
+				Package: models.Package{Ecosystem: "GIT", Name: "github.com/mine/mine3"},
+				Ranges: []models.Range{{
+					Type:   models.RangeGit,
+					Events: []models.Event{{Introduced: "0"}, {Fixed: "b1c95a196f22d06fcf80df8c6691cd113d8fefff"}},
+				}},
+			},
+		},
+	}
+
+	want := map[models.Package][]string{
+		{Ecosystem: "Debian:11", Name: "curl"}: {"<7.74.0-1.3+deb11u1"},
+		{Ecosystem: "Debian", Name: "curl"}:    {"<7.74.0-1.3+deb11u1"},
+		{Ecosystem: "npm", Name: "mine1"}:      {">=1.0.0, <1.2.3", ">=2.0.0, <=2.1.0"},
+		{Ecosystem: "npm", Name: "mine2"}:      {"*", ">=3.0.0"},
+	}
+
+	if diff := cmp.Diff(want, vuln.AffectedRanges()); diff != "" {
+		t.Errorf("AffectedRanges() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestPackageInfo_BaseEcosystem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ecosystem string
+		want      models.Ecosystem
+	}{
+		{ecosystem: "npm", want: models.EcosystemNPM},
+		{ecosystem: "", want: ""},
+		{ecosystem: "Alpine", want: models.EcosystemAlpine},
+		{ecosystem: "Alpine:v3.16", want: models.EcosystemAlpine},
+		{ecosystem: "Debian", want: models.EcosystemDebian},
+		{ecosystem: "Debian:11", want: models.EcosystemDebian},
+		{ecosystem: "Ubuntu:22.04:LTS", want: "Ubuntu"},
+		{ecosystem: "Ubuntu:Pro:18.04:LTS", want: "Ubuntu"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.ecosystem, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := models.PackageInfo{Name: "curl", Version: "1.0.0", Ecosystem: tt.ecosystem}
+			if got := pkg.BaseEcosystem(); got != tt.want {
+				t.Errorf("BaseEcosystem() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
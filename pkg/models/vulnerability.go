@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -167,3 +168,12 @@ func (v Vulnerability) MarshalYAML() (interface{}, error) {
 
 	return raw, nil
 }
+
+// DatabaseSpecificSeverity returns the qualitative severity (such as "HIGH") that some databases,
+// such as the GitHub Advisory Database, give in the database_specific field of the vulnerability
+// rather than as a CVSS vector, or an empty string if there is none.
+func (v Vulnerability) DatabaseSpecificSeverity() string {
+	severity, _ := v.DatabaseSpecific["severity"].(string)
+
+	return strings.ToUpper(strings.TrimSpace(severity))
+}
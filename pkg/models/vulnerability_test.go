@@ -231,3 +231,30 @@ func TestVulnerability_MarshalYAMLTimes(t *testing.T) {
 		})
 	}
 }
+
+func TestVulnerability_DatabaseSpecificSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		databaseSpecific map[string]interface{}
+		want             string
+	}{
+		{name: "no database specific", databaseSpecific: nil, want: ""},
+		{name: "no severity", databaseSpecific: map[string]interface{}{"cwe_ids": []string{"CWE-79"}}, want: ""},
+		{name: "qualitative severity", databaseSpecific: map[string]interface{}{"severity": "HIGH"}, want: "HIGH"},
+		{name: "lowercase severity", databaseSpecific: map[string]interface{}{"severity": "moderate"}, want: "MODERATE"},
+		{name: "not a string", databaseSpecific: map[string]interface{}{"severity": 7.5}, want: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vuln := models.Vulnerability{ID: "GHSA-1", DatabaseSpecific: tt.databaseSpecific}
+			if got := vuln.DatabaseSpecificSeverity(); got != tt.want {
+				t.Errorf("DatabaseSpecificSeverity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
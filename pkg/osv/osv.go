@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
+	"syscall"
 	"time"
 
 	"github.com/google/osv-scanner/pkg/lockfile"
@@ -36,6 +40,53 @@ const (
 
 var RequestUserAgent = ""
 
+// RequestHeaders are added to every request made to the OSV API, such as an Authorization
+// header for a mirror that requires authentication, overriding the User-Agent if set
+var RequestHeaders = http.Header{}
+
+// UnreachableError is returned when the OSV API could not be connected to at all, such as when
+// there is no network access or the host cannot be resolved, rather than when the API returns an error
+type UnreachableError struct {
+	// URL is the endpoint that could not be reached
+	URL string
+	Err error
+}
+
+func (e *UnreachableError) Error() string {
+	if e.URL == "" {
+		return fmt.Sprintf("could not reach the OSV API: %v", e.Err)
+	}
+
+	return fmt.Sprintf("could not reach the OSV API at %s: %v", e.URL, e.Err)
+}
+
+func (e *UnreachableError) Unwrap() error {
+	return e.Err
+}
+
+// isConnectivityError checks if the error is from failing to connect to a server, as opposed to
+// the server responding with an error
+func isConnectivityError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ENETUNREACH) ||
+		errors.Is(err, syscall.EHOSTUNREACH)
+}
+
 // Package represents a package identifier for OSV.
 type Package struct {
 	PURL      string `json:"purl,omitempty"`
@@ -198,9 +249,7 @@ func MakeRequestWithClient(request BatchedQuery, client *http.Client) (*BatchedR
 				return nil, err
 			}
 			req.Header.Set("Content-Type", "application/json")
-			if RequestUserAgent != "" {
-				req.Header.Set("User-Agent", RequestUserAgent)
-			}
+			setRequestHeaders(req)
 
 			return client.Do(req)
 		})
@@ -237,9 +286,7 @@ func GetWithClient(id string, client *http.Client) (*models.Vulnerability, error
 		if err != nil {
 			return nil, err
 		}
-		if RequestUserAgent != "" {
-			req.Header.Set("User-Agent", RequestUserAgent)
-		}
+		setRequestHeaders(req)
 
 		return client.Do(req)
 	})
@@ -317,10 +364,23 @@ func HydrateWithClient(resp *BatchedResponse, client *http.Client) (*HydratedBat
 	return &hydrated, nil
 }
 
-// makeRetryRequest will return an error on both network errors, and if the response is not 200
+// setRequestHeaders sets the User-Agent and any other headers that should be sent with requests to the OSV API
+func setRequestHeaders(req *http.Request) {
+	if RequestUserAgent != "" {
+		req.Header.Set("User-Agent", RequestUserAgent)
+	}
+
+	for name, values := range RequestHeaders {
+		req.Header[name] = values
+	}
+}
+
+// makeRetryRequest will return an error on both network errors, and if the response is not 200,
+// which is an UnreachableError if the server could not be connected to on the last attempt
 func makeRetryRequest(action func() (*http.Response, error)) (*http.Response, error) {
 	var resp *http.Response
 	var err error
+	var connErr bool
 
 	for i := 0; i < maxRetryAttempts; i++ {
 		// rand is initialized with a random number (since go1.20), and is also safe to use concurrently
@@ -330,6 +390,7 @@ func makeRetryRequest(action func() (*http.Response, error)) (*http.Response, er
 		time.Sleep(time.Duration(i*i)*time.Second + time.Duration(jitterAmount*1000)*time.Millisecond)
 
 		resp, err = action()
+		connErr = err != nil && isConnectivityError(err)
 		if err == nil {
 			// Check the response for HTTP errors
 			err = checkResponseError(resp)
@@ -339,6 +400,15 @@ func makeRetryRequest(action func() (*http.Response, error)) (*http.Response, er
 		}
 	}
 
+	if connErr {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return resp, &UnreachableError{URL: urlErr.URL, Err: urlErr.Err}
+		}
+
+		return resp, &UnreachableError{Err: err}
+	}
+
 	return resp, err
 }
 
@@ -364,9 +434,7 @@ func MakeDetermineVersionRequest(name string, hashes []DetermineVersionHash) (*D
 			return nil, err
 		}
 		req.Header.Set("Content-Type", "application/json")
-		if RequestUserAgent != "" {
-			req.Header.Set("User-Agent", RequestUserAgent)
-		}
+		setRequestHeaders(req)
 
 		return http.DefaultClient.Do(req)
 	})
@@ -0,0 +1,56 @@
+package osv
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+)
+
+func Test_isConnectivityError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "host cannot be resolved",
+			err:  &url.Error{Op: "Post", URL: QueryEndpoint, Err: &net.DNSError{Err: "no such host", Name: "api.osv.dev"}},
+			want: true,
+		},
+		{
+			name: "connection refused",
+			err:  &url.Error{Op: "Post", URL: QueryEndpoint, Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}},
+			want: true,
+		},
+		{
+			name: "network unreachable",
+			err:  fmt.Errorf("connect: %w", syscall.ENETUNREACH),
+			want: true,
+		},
+		{
+			name: "connection reset while reading",
+			err:  &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			want: false,
+		},
+		{
+			name: "server response error",
+			err:  errors.New("server response error: internal error"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isConnectivityError(tt.err); got != tt.want {
+				t.Errorf("isConnectivityError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
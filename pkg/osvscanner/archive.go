@@ -0,0 +1,247 @@
+package osvscanner
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+type archiveFormat string
+
+const (
+	archiveZip   archiveFormat = "zip"
+	archiveTar   archiveFormat = "tar"
+	archiveTarGz archiveFormat = "tar.gz"
+)
+
+// detectArchiveFormat returns the format of the archive at path based on the magic bytes at the start of it,
+// or an empty string if path is not a file or is not a supported archive
+func detectArchiveFormat(path string) archiveFormat {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return ""
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	// the "ustar" magic of tar files is after the name and other fields of the first header
+	header := make([]byte, 262)
+	n, _ := io.ReadFull(f, header)
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")), bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		return archiveZip
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		return archiveTarGz
+	case len(header) == 262 && bytes.HasPrefix(header[257:], []byte("ustar")):
+		return archiveTar
+	}
+
+	return ""
+}
+
+// archiveEntryPath returns where the entry with the given name should be extracted to within dest,
+// refusing names that would escape it (such as "../../etc/passwd") to guard against zip slip
+func archiveEntryPath(dest string, name string) (string, error) {
+	name = filepath.FromSlash(name)
+
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("refusing to extract %q as it is outside of the archive", name)
+	}
+
+	return filepath.Join(dest, name), nil
+}
+
+// writeArchiveFile writes the content of an archive entry to path, creating its parent directories
+func writeArchiveFile(path string, content io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, content)
+
+	return err
+}
+
+func extractZip(path string, dest string) error {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	for _, file := range archive.File {
+		target, err := archiveEntryPath(dest, file.Name)
+		if err != nil {
+			return err
+		}
+
+		// only directories and regular files are extracted, so symlinks cannot point outside of dest
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		content, err := file.Open()
+		if err != nil {
+			return err
+		}
+
+		err = writeArchiveFile(target, content)
+		content.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractTar(content io.Reader, dest string) error {
+	archive := tar.NewReader(content)
+
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := archiveEntryPath(dest, header.Name)
+		if err != nil {
+			return err
+		}
+
+		// only directories and regular files are extracted, so links cannot point outside of dest
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, archive); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractArchive extracts the archive at path in the given format into dest
+func extractArchive(path string, format archiveFormat, dest string) error {
+	if format == archiveZip {
+		return extractZip(path, dest)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var content io.Reader = f
+
+	if format == archiveTarGz {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+
+		content = gz
+	}
+
+	return extractTar(content, dest)
+}
+
+// extractArchiveToTemp extracts the archive at path into a new temporary directory, returning the
+// directory along with a function to remove it once it has been scanned
+func extractArchiveToTemp(r reporter.Reporter, path string, format archiveFormat) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "osv-scanner-archive-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to extract %s: %w", path, err)
+	}
+
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			r.Warnf("Failed to remove the files extracted from %s: %v\n", path, err)
+		}
+	}
+
+	if err := extractArchive(path, format, dir); err != nil {
+		cleanup()
+
+		return "", nil, fmt.Errorf("failed to extract %s: %w", path, err)
+	}
+
+	r.Infof("Extracted %s archive %s to %s\n", format, path, dir)
+
+	return dir, cleanup, nil
+}
+
+// relocateArchivePackages changes the source of each of the packages that were found in the files extracted to dir
+// to be the path of the file within the archive, so that the results are not reported against the temporary directory
+func relocateArchivePackages(packages []scannedPackage, dir string, archivePath string) {
+	for i, pkg := range packages {
+		rel, err := filepath.Rel(dir, pkg.Source.Path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		packages[i].Source.Path = filepath.Join(archivePath, rel)
+	}
+}
+
+// scanArchive extracts the archive at path and scans the extracted files like a directory,
+// removing them again before returning so that only one archive is extracted at a time
+func scanArchive(r reporter.Reporter, path string, format archiveFormat, actions ScannerActions, exclude []string, scanned scannedFiles, failures *parseFailures) ([]scannedPackage, error) {
+	archivePath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, cleanup, err := extractArchiveToTemp(r, path, format)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	r.Infof("Scanning dir %s\n", dir)
+	pkgs, err := scanDir(r, dir, newScanDirOptions(actions, exclude), scanned, failures)
+	if err != nil {
+		return nil, err
+	}
+
+	relocateArchivePackages(pkgs, dir, archivePath)
+
+	return pkgs, nil
+}
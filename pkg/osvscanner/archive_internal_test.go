@@ -0,0 +1,217 @@
+package osvscanner
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+type archiveEntry struct {
+	name    string
+	content string
+}
+
+func writeTestZip(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for _, entry := range entries {
+		fw, err := w.Create(entry.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(entry.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestTarGz(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	w := tar.NewWriter(gz)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0644, Size: int64(len(entry.content)), Typeflag: tar.TypeReg}
+		if err := w.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(entry.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_extractArchive(t *testing.T) {
+	t.Parallel()
+
+	entries := []archiveEntry{
+		{name: "project/requirements.txt", content: "flask==1.0.0\n"},
+		{name: "project/nested/go.mod", content: "module example.com/nested\n"},
+	}
+
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "source.zip")
+	tarGzPath := filepath.Join(dir, "source.tar.gz")
+	// the format is detected from the content rather than the extension
+	unnamedPath := filepath.Join(dir, "source")
+
+	writeTestZip(t, zipPath, entries)
+	writeTestTarGz(t, tarGzPath, entries)
+	writeTestTarGz(t, unnamedPath, entries)
+
+	tests := []struct {
+		path string
+		want archiveFormat
+	}{
+		{path: zipPath, want: archiveZip},
+		{path: tarGzPath, want: archiveTarGz},
+		{path: unnamedPath, want: archiveTarGz},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(filepath.Base(tt.path), func(t *testing.T) {
+			t.Parallel()
+
+			format := detectArchiveFormat(tt.path)
+			if format != tt.want {
+				t.Fatalf("detectArchiveFormat() = %q, want %q", format, tt.want)
+			}
+
+			dest := t.TempDir()
+			if err := extractArchive(tt.path, format, dest); err != nil {
+				t.Fatalf("extractArchive() error = %v", err)
+			}
+
+			for _, entry := range entries {
+				got, err := os.ReadFile(filepath.Join(dest, filepath.FromSlash(entry.name)))
+				if err != nil {
+					t.Errorf("%s was not extracted: %v", entry.name, err)
+				} else if string(got) != entry.content {
+					t.Errorf("%s was extracted as %q, want %q", entry.name, string(got), entry.content)
+				}
+			}
+		})
+	}
+}
+
+func Test_detectArchiveFormat_NotArchive(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "package-lock.json")
+	if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []string{dir, path, filepath.Join(dir, "does-not-exist.zip")} {
+		if got := detectArchiveFormat(p); got != "" {
+			t.Errorf("detectArchiveFormat(%q) = %q, want it to not be an archive", p, got)
+		}
+	}
+}
+
+func Test_extractArchive_ZipSlip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "dest")
+	entries := []archiveEntry{{name: "../evil.txt", content: "pwned"}}
+
+	zipPath := filepath.Join(dir, "evil.zip")
+	tarGzPath := filepath.Join(dir, "evil.tar.gz")
+	writeTestZip(t, zipPath, entries)
+	writeTestTarGz(t, tarGzPath, entries)
+
+	for _, path := range []string{zipPath, tarGzPath} {
+		if err := extractArchive(path, detectArchiveFormat(path), dest); err == nil {
+			t.Errorf("extractArchive(%q) did not return an error for an entry outside of the archive", path)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "evil.txt")); err == nil {
+		t.Errorf("an entry was extracted outside of the destination")
+	}
+}
+
+func Test_relocateArchivePackages(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join("/tmp", "osv-scanner-archive-123")
+	archivePath := filepath.Join("/ci", "source.zip")
+
+	packages := []scannedPackage{
+		{Name: "flask", Source: models.SourceInfo{Path: filepath.Join(dir, "project", "requirements.txt"), Type: "lockfile"}},
+		{Name: "other", Source: models.SourceInfo{Path: filepath.Join("/elsewhere", "requirements.txt"), Type: "lockfile"}},
+	}
+
+	relocateArchivePackages(packages, dir, archivePath)
+
+	if got, want := packages[0].Source.Path, filepath.Join(archivePath, "project", "requirements.txt"); got != want {
+		t.Errorf("relocateArchivePackages() set the path to %q, want %q", got, want)
+	}
+	if got, want := packages[1].Source.Path, filepath.Join("/elsewhere", "requirements.txt"); got != want {
+		t.Errorf("relocateArchivePackages() changed the path of a package outside of the archive to %q", got)
+	}
+}
+
+// Do not make this test parallel because it calls t.Setenv()
+func Test_scanArchive(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	archive := filepath.Join(t.TempDir(), "source.zip")
+	writeTestZip(t, archive, []archiveEntry{
+		{name: "project/requirements.txt", content: "flask==1.0.0\n"},
+	})
+
+	actions := ScannerActions{Recursive: true, SkipGit: true, Parallelism: 1}
+	pkgs, err := scanArchive(&reporter.VoidReporter{}, archive, archiveZip, actions, nil, scannedFiles{}, &parseFailures{})
+	if err != nil {
+		t.Fatalf("scanArchive() error = %v", err)
+	}
+
+	if len(pkgs) != 1 || pkgs[0].Name != "flask" {
+		t.Fatalf("scanArchive() = %v, want the flask package", pkgs)
+	}
+	if got, want := pkgs[0].Source.Path, filepath.Join(archive, "project", "requirements.txt"); got != want {
+		t.Errorf("scanArchive() reported the package against %q, want %q", got, want)
+	}
+
+	extracted, err := os.ReadDir(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(extracted) != 0 {
+		t.Errorf("scanArchive() did not remove the extracted files: %v", extracted)
+	}
+}
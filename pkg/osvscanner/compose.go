@@ -0,0 +1,51 @@
+package osvscanner
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/google/osv-scanner/pkg/reporter"
+	"gopkg.in/yaml.v3"
+)
+
+// composeFile is the part of a Docker Compose file that lists the images of its services
+type composeFile struct {
+	Services map[string]struct {
+		Image string `yaml:"image"`
+	} `yaml:"services"`
+}
+
+// composeImages returns the images used by the services of the Docker Compose file at path,
+// skipping services that are only built from a Dockerfile as there is no image to scan
+func composeImages(r reporter.Reporter, path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var compose composeFile
+	if err := yaml.Unmarshal(b, &compose); err != nil {
+		return nil, fmt.Errorf("could not parse Docker Compose file %s: %w", path, err)
+	}
+
+	names := make([]string, 0, len(compose.Services))
+	for name := range compose.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var images []string
+	for _, name := range names {
+		image := compose.Services[name].Image
+		if image == "" {
+			r.Infof("Skipping service %s in %s as it does not have an image, and is only built\n", name, path)
+
+			continue
+		}
+
+		images = append(images, image)
+	}
+
+	return images, nil
+}
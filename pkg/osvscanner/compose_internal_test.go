@@ -0,0 +1,45 @@
+package osvscanner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func Test_composeImages(t *testing.T) {
+	t.Parallel()
+
+	stdout := &bytes.Buffer{}
+	r := reporter.NewTableReporter(stdout, stdout, reporter.InfoLevel, false, 0)
+
+	got, err := composeImages(r, "fixtures/compose/compose.yaml")
+	if err != nil {
+		t.Fatalf("composeImages() error = %v", err)
+	}
+
+	// services that are built still have their image scanned if it is named
+	want := []string{"postgres:16", "nginx:1.25", "example/worker:latest"}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("composeImages() (-want +got):\n%s", diff)
+	}
+
+	if !strings.Contains(stdout.String(), "Skipping service api") {
+		t.Errorf("Expected the build-only service to be reported as skipped, but got:\n%s", stdout.String())
+	}
+}
+
+func Test_composeImages_Invalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := composeImages(&reporter.VoidReporter{}, "fixtures/compose/invalid.yaml"); err == nil {
+		t.Errorf("composeImages() expected an error for invalid yaml")
+	}
+
+	if _, err := composeImages(&reporter.VoidReporter{}, "fixtures/compose/does-not-exist.yaml"); err == nil {
+		t.Errorf("composeImages() expected an error for a file that does not exist")
+	}
+}
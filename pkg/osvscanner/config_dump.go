@@ -0,0 +1,122 @@
+package osvscanner
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/pkg/config"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// newConfigManager creates the config manager for the scan, using the override config if there is one
+func newConfigManager(r reporter.Reporter, actions ScannerActions) (config.ConfigManager, error) {
+	configManager := config.ConfigManager{
+		DefaultConfig: config.Config{},
+		ConfigMap:     make(map[string]config.Config),
+	}
+
+	if actions.ConfigOverridePath != "" {
+		err := configManager.UseOverride(actions.ConfigOverridePath)
+		if err != nil {
+			r.Errorf("Failed to read config file: %s\n", err)
+			return config.ConfigManager{}, err
+		}
+	}
+
+	return configManager, nil
+}
+
+// EffectiveConfig returns the config that is used when scanning the directories, lockfiles and SBOMs
+// of the actions, which is the override config if there is one, or otherwise the config alongside them.
+//
+// An error is returned if they would be scanned with different configs.
+func EffectiveConfig(r reporter.Reporter, actions ScannerActions) (config.Config, error) {
+	configManager, err := newConfigManager(r, actions)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	targets := slices.Clone(actions.DirectoryPaths)
+	for _, lockfileElem := range actions.LockfilePaths {
+		_, lockfilePath := parseLockfilePath(lockfileElem)
+		targets = append(targets, lockfilePath)
+	}
+	targets = append(targets, actions.SBOMPaths...)
+
+	if len(targets) == 0 {
+		return configManager.Get(r, "."), nil
+	}
+
+	var configs []config.Config
+	var loadPaths []string
+	for _, target := range targets {
+		c := configManager.Get(r, target)
+		if slices.Contains(loadPaths, c.LoadPath) {
+			continue
+		}
+
+		configs = append(configs, c)
+		loadPaths = append(loadPaths, c.LoadPath)
+	}
+
+	if len(configs) > 1 {
+		for i, loadPath := range loadPaths {
+			if loadPath == "" {
+				loadPaths[i] = "the default config"
+			}
+		}
+
+		return config.Config{}, fmt.Errorf(
+			"the given paths are scanned with different configs (%s), so each path needs to be given separately",
+			strings.Join(loadPaths, ", "),
+		)
+	}
+
+	return configs[0], nil
+}
+
+// DumpConfig writes the effective config of the scan to the file at path as TOML,
+// without scanning anything, so it is clear which config is used
+func DumpConfig(r reporter.Reporter, actions ScannerActions, path string) error {
+	c, err := EffectiveConfig(r, actions)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to dump config: %w", err)
+	}
+	defer f.Close()
+
+	if err := c.Write(f); err != nil {
+		return fmt.Errorf("failed to dump config: %w", err)
+	}
+
+	if c.LoadPath == "" {
+		r.Infof("Dumped the default config to %s\n", path)
+	} else {
+		r.Infof("Dumped the config loaded from %s to %s\n", c.LoadPath, path)
+	}
+
+	return nil
+}
+
+// ValidateConfig checks the config file at path without scanning anything, reporting each
+// problem with it as an error so that mistakes are caught before the config is used
+func ValidateConfig(r reporter.Reporter, path string) error {
+	errs := config.ValidateFile(path)
+	for _, err := range errs {
+		r.Errorf("%s: %s\n", path, err)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s is not valid, found %d problem(s)", path, len(errs))
+	}
+
+	r.Infof("%s is valid\n", path)
+
+	return nil
+}
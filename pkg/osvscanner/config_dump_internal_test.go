@@ -0,0 +1,94 @@
+package osvscanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/config"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, sub := range []string{"a", "b"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0750); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	configA := filepath.Join(dir, "a", "osv-scanner.toml")
+	if err := os.WriteFile(configA, []byte("[[IgnoredVulns]]\nid = \"GHSA-1\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a", "requirements.txt"), []byte("pkg-a==1.0.0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	override := filepath.Join(dir, "override.toml")
+	if err := os.WriteFile(override, []byte("[[IgnoredVulns]]\nid = \"GHSA-2\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		actions ScannerActions
+		want    config.Config
+		wantErr bool
+	}{
+		{
+			name:    "config alongside the directory",
+			actions: ScannerActions{DirectoryPaths: []string{filepath.Join(dir, "a")}},
+			want: config.Config{
+				IgnoredVulns: []config.IgnoreEntry{{ID: "GHSA-1"}},
+				LoadPath:     configA,
+			},
+		},
+		{
+			name:    "config alongside the lockfile",
+			actions: ScannerActions{LockfilePaths: []string{"requirements.txt:" + filepath.Join(dir, "a", "requirements.txt")}},
+			want: config.Config{
+				IgnoredVulns: []config.IgnoreEntry{{ID: "GHSA-1"}},
+				LoadPath:     configA,
+			},
+		},
+		{
+			name:    "no config alongside the directory",
+			actions: ScannerActions{DirectoryPaths: []string{filepath.Join(dir, "b")}},
+			want:    config.Config{},
+		},
+		{
+			name:    "different configs",
+			actions: ScannerActions{DirectoryPaths: []string{filepath.Join(dir, "a"), filepath.Join(dir, "b")}},
+			wantErr: true,
+		},
+		{
+			name: "override config",
+			actions: ScannerActions{
+				DirectoryPaths:     []string{filepath.Join(dir, "a"), filepath.Join(dir, "b")},
+				ConfigOverridePath: override,
+			},
+			want: config.Config{
+				IgnoredVulns: []config.IgnoreEntry{{ID: "GHSA-2"}},
+				LoadPath:     override,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := EffectiveConfig(&reporter.VoidReporter{}, tt.actions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EffectiveConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("EffectiveConfig() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package osvscanner
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/internal/packagist"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// manifestLockfiles are the lockfiles that are preferred over the manifest with the given name
+// when scanning a directory, as they have the exact versions of all the packages in the manifest
+var manifestLockfiles = map[string]string{
+	"composer.json": "composer.lock",
+}
+
+// hasPreferredLockfile returns true if the file at path is a manifest with a lockfile next to it
+func hasPreferredLockfile(path string) bool {
+	lockfileName, ok := manifestLockfiles[filepath.Base(path)]
+	if !ok {
+		return false
+	}
+
+	_, err := os.Stat(filepath.Join(filepath.Dir(path), lockfileName))
+
+	return err == nil
+}
+
+// resolveConstraints sets the version of each package that only has a version constraint to the
+// latest version published to its registry that satisfies the constraint, if there is one
+func resolveConstraints(r reporter.Reporter, packages []scannedPackage, client *packagist.Client) {
+	for i := range packages {
+		pkg := &packages[i]
+		if pkg.Version != "" || pkg.VersionConstraint == "" || pkg.Ecosystem != lockfile.ComposerEcosystem {
+			continue
+		}
+
+		version, err := client.Resolve(pkg.Name, pkg.VersionConstraint)
+		if err != nil {
+			r.Warnf("Failed to resolve %s %s: %v\n", pkg.Name, pkg.VersionConstraint, err)
+			continue
+		}
+		if version == "" {
+			r.Warnf("Failed to resolve %s %s: no published version satisfies the constraint\n", pkg.Name, pkg.VersionConstraint)
+			continue
+		}
+
+		r.Verbosef("Resolved %s %s to %s\n", pkg.Name, pkg.VersionConstraint, version)
+		pkg.Version = version
+	}
+}
+
+// reportUnresolvedConstraints reports the number of packages that only have a version constraint,
+// which cannot be checked for vulnerabilities unless they are resolved
+func reportUnresolvedConstraints(r reporter.Reporter, packages []scannedPackage) {
+	unresolved := 0
+	for _, pkg := range packages {
+		if pkg.Version == "" && pkg.VersionConstraint != "" {
+			unresolved++
+		}
+	}
+
+	if unresolved > 0 {
+		r.Infof(
+			"Found %d %s with a version constraint rather than an exact version, which will not be checked for vulnerabilities unless --resolve-constraints is set\n",
+			unresolved,
+			output.Form(unresolved, "package", "packages"),
+		)
+	}
+}
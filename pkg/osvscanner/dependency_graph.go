@@ -0,0 +1,54 @@
+package osvscanner
+
+import (
+	"slices"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+// dependencyKey is the key of the package in the dependency graph of the lockfile it is from
+func (p scannedPackage) dependencyKey() string {
+	return lockfile.PackageDetails{Name: p.Name, Version: p.Version, Commit: p.Commit}.DependencyKey()
+}
+
+// dependencyGraphs builds the dependency graph of each lockfile that records the dependencies of its packages,
+// which maps each of the packages to the packages that directly depend on it; the graphs are keyed by the path
+// of the lockfile rather than the source so that the graph of a project with workspaces is not split up
+func dependencyGraphs(packages []scannedPackage) map[string]map[string][]string {
+	graphs := map[string]map[string][]string{}
+
+	for _, p := range packages {
+		if len(p.Dependencies) > 0 {
+			graphs[p.Source.Path] = map[string][]string{}
+		}
+	}
+
+	for _, p := range packages {
+		if graph, ok := graphs[p.Source.Path]; ok {
+			graph[p.dependencyKey()] = []string{}
+		}
+	}
+
+	for _, p := range packages {
+		graph := graphs[p.Source.Path]
+		dependent := p.dependencyKey()
+
+		for _, dependency := range p.Dependencies {
+			// packages that are not being scanned (such as local packages) are left out of the graph
+			dependents, ok := graph[dependency]
+			if !ok || slices.Contains(dependents, dependent) {
+				continue
+			}
+
+			graph[dependency] = append(dependents, dependent)
+		}
+	}
+
+	for _, graph := range graphs {
+		for _, dependents := range graph {
+			slices.Sort(dependents)
+		}
+	}
+
+	return graphs
+}
@@ -0,0 +1,40 @@
+package osvscanner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func Test_dependencyGraphs(t *testing.T) {
+	t.Parallel()
+
+	npmLock := models.SourceInfo{Path: "/package-lock.json", Type: "lockfile"}
+	npmWorkspace := models.SourceInfo{Path: "/package-lock.json", Type: "lockfile", Workspace: "packages/app"}
+	requirements := models.SourceInfo{Path: "/requirements.txt", Type: "lockfile"}
+
+	packages := []scannedPackage{
+		{Name: "app", Version: "0.1.0", Ecosystem: lockfile.NpmEcosystem, Source: npmWorkspace, Dependencies: []string{"chalk@4.1.2", "lodash@4.17.21", "local-pkg"}},
+		{Name: "chalk", Version: "4.1.2", Ecosystem: lockfile.NpmEcosystem, Source: npmWorkspace, Dependencies: []string{"supports-color@7.2.0"}},
+		{Name: "lodash", Version: "4.17.21", Ecosystem: lockfile.NpmEcosystem, Source: npmLock},
+		{Name: "express", Version: "4.18.2", Ecosystem: lockfile.NpmEcosystem, Source: npmLock, Dependencies: []string{"supports-color@7.2.0"}},
+		{Name: "supports-color", Version: "7.2.0", Ecosystem: lockfile.NpmEcosystem, Source: npmLock},
+		{Name: "flask", Version: "2.0.0", Ecosystem: lockfile.PipEcosystem, Source: requirements},
+	}
+
+	want := map[string]map[string][]string{
+		"/package-lock.json": {
+			"app@0.1.0":            {},
+			"chalk@4.1.2":          {"app@0.1.0"},
+			"lodash@4.17.21":       {"app@0.1.0"},
+			"express@4.18.2":       {},
+			"supports-color@7.2.0": {"chalk@4.1.2", "express@4.18.2"},
+		},
+	}
+
+	if got := dependencyGraphs(packages); !reflect.DeepEqual(got, want) {
+		t.Errorf("dependencyGraphs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,207 @@
+package osvscanner
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"deps.dev/util/semver"
+	"golang.org/x/exp/maps"
+	"golang.org/x/mod/modfile"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// npmManifest is the part of a package.json that lists the direct dependencies
+type npmManifest struct {
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// reportDrift reports each way that the lockfiles the packages were extracted from disagree with
+// the manifest alongside them, which means the lockfile is stale and the results may be misleading
+func reportDrift(r reporter.Reporter, packages []scannedPackage, asError bool) {
+	var lockfilePaths []string
+	for _, pkg := range packages {
+		if pkg.Source.Type == "lockfile" && !slices.Contains(lockfilePaths, pkg.Source.Path) {
+			lockfilePaths = append(lockfilePaths, pkg.Source.Path)
+		}
+	}
+	slices.Sort(lockfilePaths)
+
+	report := r.Warnf
+	if asError {
+		report = r.Errorf
+	}
+
+	for _, path := range lockfilePaths {
+		drift, err := lockfileDrift(path)
+		if err != nil {
+			r.Verbosef("Could not check %s for drift: %s\n", path, err)
+			continue
+		}
+
+		for _, d := range drift {
+			report("%s is out of sync with its manifest: %s\n", path, d)
+		}
+	}
+}
+
+// lockfileDrift describes each way that the lockfile at path disagrees with the manifest alongside it,
+// for the lockfiles that can be checked; lockfiles without a manifest are never considered to drift
+func lockfileDrift(path string) ([]string, error) {
+	var drift []string
+	var err error
+
+	switch filepath.Base(path) {
+	case "package-lock.json":
+		drift, err = npmDrift(path, filepath.Join(filepath.Dir(path), "package.json"))
+	case "go.mod":
+		drift, err = goSumDrift(path, filepath.Join(filepath.Dir(path), "go.sum"))
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	return drift, err
+}
+
+// npmDrift compares the direct dependencies in package.json with the versions locked by package-lock.json
+func npmDrift(pathToLockfile, pathToManifest string) ([]string, error) {
+	var manifest npmManifest
+	if err := readJSON(pathToManifest, &manifest); err != nil {
+		return nil, err
+	}
+
+	var lock lockfile.NpmLockfile
+	if err := readJSON(pathToLockfile, &lock); err != nil {
+		return nil, err
+	}
+
+	lockedVersion := func(name string) (string, bool) {
+		if lock.Packages != nil {
+			pkg, ok := lock.Packages["node_modules/"+name]
+			return pkg.Version, ok
+		}
+		dep, ok := lock.Dependencies[name]
+
+		return dep.Version, ok
+	}
+
+	required := map[string]string{}
+	for _, deps := range []map[string]string{manifest.Dependencies, manifest.DevDependencies, manifest.OptionalDependencies} {
+		maps.Copy(required, deps)
+	}
+
+	names := maps.Keys(required)
+	slices.Sort(names)
+
+	var drift []string
+	for _, name := range names {
+		version, ok := lockedVersion(name)
+		if !ok {
+			drift = append(drift, fmt.Sprintf("%s is in package.json but not in package-lock.json", name))
+			continue
+		}
+
+		// requirements that are not semver ranges, like git urls and local paths, can't be compared
+		c, err := semver.NPM.ParseConstraint(required[name])
+		if err != nil {
+			continue
+		}
+
+		if !c.Match(version) {
+			drift = append(drift, fmt.Sprintf("%s is locked at %s, which does not satisfy %q in package.json", name, version, required[name]))
+		}
+	}
+
+	// only newer lockfiles record the direct dependencies of the project
+	if root, ok := lock.Packages[""]; ok {
+		var removed []string
+		for _, deps := range []map[string]string{root.Dependencies, root.DevDependencies, root.OptionalDependencies} {
+			for name := range deps {
+				if _, ok := required[name]; !ok {
+					removed = append(removed, name)
+				}
+			}
+		}
+		slices.Sort(removed)
+
+		for _, name := range slices.Compact(removed) {
+			drift = append(drift, fmt.Sprintf("%s is in package-lock.json but no longer in package.json", name))
+		}
+	}
+
+	return drift, nil
+}
+
+// goSumDrift checks that go.sum has an entry for each version of the direct dependencies required by go.mod
+func goSumDrift(pathToGoMod, pathToGoSum string) ([]string, error) {
+	b, err := os.ReadFile(pathToGoMod)
+	if err != nil {
+		return nil, err
+	}
+
+	goMod, err := modfile.Parse(pathToGoMod, b, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(pathToGoSum)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sums := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 {
+			sums[fields[0]+"@"+strings.TrimSuffix(fields[1], "/go.mod")] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var drift []string
+	for _, require := range goMod.Require {
+		// the sums of replaced modules are recorded under what they are replaced with
+		replaced := slices.ContainsFunc(goMod.Replace, func(replace *modfile.Replace) bool {
+			return replace.Old.Path == require.Mod.Path
+		})
+
+		if require.Indirect || replaced {
+			continue
+		}
+
+		if !sums[require.Mod.Path+"@"+require.Mod.Version] {
+			drift = append(drift, fmt.Sprintf("%s %s is required by go.mod but is not in go.sum", require.Mod.Path, require.Mod.Version))
+		}
+	}
+
+	return drift, nil
+}
+
+func readJSON(path string, v any) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	return nil
+}
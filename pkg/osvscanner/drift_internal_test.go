@@ -0,0 +1,130 @@
+package osvscanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_lockfileDrift(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		lockfile string
+		files    map[string]string
+		want     []string
+	}{
+		{
+			name:     "npm in sync",
+			lockfile: "package-lock.json",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"lodash": "^4.17.0"}}`,
+				"package-lock.json": `{
+					"lockfileVersion": 3,
+					"packages": {
+						"": {"dependencies": {"lodash": "^4.17.0"}},
+						"node_modules/lodash": {"version": "4.17.21"}
+					}
+				}`,
+			},
+			want: nil,
+		},
+		{
+			name:     "npm drift",
+			lockfile: "package-lock.json",
+			files: map[string]string{
+				"package.json": `{
+					"dependencies": {"lodash": "^4.17.21", "express": "github:expressjs/express"},
+					"devDependencies": {"jest": "^29.0.0"}
+				}`,
+				"package-lock.json": `{
+					"lockfileVersion": 3,
+					"packages": {
+						"": {"dependencies": {"lodash": "^4.17.0", "express": "github:expressjs/express", "left-pad": "^1.0.0"}},
+						"node_modules/lodash": {"version": "4.17.20"},
+						"node_modules/express": {"version": "4.18.2"},
+						"node_modules/left-pad": {"version": "1.3.0"}
+					}
+				}`,
+			},
+			want: []string{
+				"jest is in package.json but not in package-lock.json",
+				`lodash is locked at 4.17.20, which does not satisfy "^4.17.21" in package.json`,
+				"left-pad is in package-lock.json but no longer in package.json",
+			},
+		},
+		{
+			name:     "npm v1 lockfile",
+			lockfile: "package-lock.json",
+			files: map[string]string{
+				"package.json":      `{"dependencies": {"lodash": "~4.16.0"}}`,
+				"package-lock.json": `{"lockfileVersion": 1, "dependencies": {"lodash": {"version": "4.17.21"}}}`,
+			},
+			want: []string{
+				`lodash is locked at 4.17.21, which does not satisfy "~4.16.0" in package.json`,
+			},
+		},
+		{
+			name:     "npm without a manifest",
+			lockfile: "package-lock.json",
+			files: map[string]string{
+				"package-lock.json": `{"lockfileVersion": 1, "dependencies": {"lodash": {"version": "4.17.21"}}}`,
+			},
+			want: nil,
+		},
+		{
+			name:     "go drift",
+			lockfile: "go.mod",
+			files: map[string]string{
+				"go.mod": "module example.com/m\n\ngo 1.21\n\n" +
+					"require (\n" +
+					"\tgithub.com/BurntSushi/toml v1.3.2\n" +
+					"\tgolang.org/x/mod v0.17.0\n" +
+					"\tgolang.org/x/sys v0.20.0 // indirect\n" +
+					"\texample.com/replaced v1.0.0\n" +
+					")\n\n" +
+					"replace example.com/replaced => ../replaced\n",
+				"go.sum": "github.com/BurntSushi/toml v1.3.2 h1:abc=\n" +
+					"github.com/BurntSushi/toml v1.3.2/go.mod h1:def=\n" +
+					"golang.org/x/mod v0.16.0/go.mod h1:ghi=\n",
+			},
+			want: []string{
+				"golang.org/x/mod v0.17.0 is required by go.mod but is not in go.sum",
+			},
+		},
+		{
+			name:     "unsupported lockfile",
+			lockfile: "yarn.lock",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"lodash": "^4.17.21"}}`,
+				"yarn.lock":    "",
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for name, contents := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := lockfileDrift(filepath.Join(dir, tt.lockfile))
+			if err != nil {
+				t.Fatalf("lockfileDrift() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("lockfileDrift() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package osvscanner
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/internal/semantic"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+	"golang.org/x/exp/maps"
+)
+
+type packageKey struct {
+	ecosystem string
+	name      string
+}
+
+// duplicateVersion is one of the versions of a package that was found at more than one version
+type duplicateVersion struct {
+	version    string
+	sources    []string
+	vulnerable bool
+}
+
+// duplicateVersions finds the packages that are at more than one version across all of the scanned sources,
+// with each of their versions in order, marking those that have vulnerabilities in the results
+func duplicateVersions(packages []scannedPackage, results models.VulnerabilityResults) map[packageKey][]duplicateVersion {
+	vulnerable := map[models.PackageInfo]bool{}
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			if slices.ContainsFunc(pkg.Groups, func(g models.GroupInfo) bool { return !g.Filtered }) {
+				vulnerable[models.PackageInfo{Name: pkg.Package.Name, Version: pkg.Package.Version, Ecosystem: pkg.Package.Ecosystem}] = true
+			}
+		}
+	}
+
+	versions := map[packageKey]map[string]*duplicateVersion{}
+	for _, p := range packages {
+		// Packages identified by a commit or a Package URL do not have a name and version to compare
+		if p.Name == "" || p.Version == "" || p.Ecosystem == "" {
+			continue
+		}
+
+		key := packageKey{ecosystem: string(p.Ecosystem), name: p.Name}
+		if versions[key] == nil {
+			versions[key] = map[string]*duplicateVersion{}
+		}
+
+		dv, ok := versions[key][p.Version]
+		if !ok {
+			dv = &duplicateVersion{
+				version:    p.Version,
+				vulnerable: vulnerable[models.PackageInfo{Name: p.Name, Version: p.Version, Ecosystem: string(p.Ecosystem)}],
+			}
+			versions[key][p.Version] = dv
+		}
+
+		if !slices.Contains(dv.sources, p.Source.Path) {
+			dv.sources = append(dv.sources, p.Source.Path)
+		}
+	}
+
+	duplicates := map[packageKey][]duplicateVersion{}
+	for key, vs := range versions {
+		if len(vs) < 2 {
+			continue
+		}
+
+		sorted := make([]duplicateVersion, 0, len(vs))
+		for _, dv := range vs {
+			slices.Sort(dv.sources)
+			sorted = append(sorted, *dv)
+		}
+
+		ecosystem := models.PackageInfo{Ecosystem: key.ecosystem}.BaseEcosystem()
+		slices.SortFunc(sorted, func(a, b duplicateVersion) int {
+			if v, err := semantic.Parse(a.version, ecosystem); err == nil {
+				return v.CompareStr(b.version)
+			}
+
+			return strings.Compare(a.version, b.version)
+		})
+
+		duplicates[key] = sorted
+	}
+
+	return duplicates
+}
+
+// reportDuplicateVersions lists the packages that are at more than one version across all of the scanned sources,
+// since a vulnerable version can be hidden among the others, such as after only some lockfiles were upgraded
+func reportDuplicateVersions(r reporter.Reporter, packages []scannedPackage, results models.VulnerabilityResults) {
+	duplicates := duplicateVersions(packages, results)
+	if len(duplicates) == 0 {
+		return
+	}
+
+	keys := maps.Keys(duplicates)
+	slices.SortFunc(keys, func(a, b packageKey) int {
+		if c := strings.Compare(a.ecosystem, b.ecosystem); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.name, b.name)
+	})
+
+	r.Warnf(
+		"Found %d %s at more than one version:\n",
+		len(keys),
+		output.Form(len(keys), "package", "packages"),
+	)
+
+	for _, key := range keys {
+		details := make([]string, 0, len(duplicates[key]))
+		for _, dv := range duplicates[key] {
+			detail := dv.version
+			if dv.vulnerable {
+				detail += " [vulnerable]"
+			}
+			details = append(details, fmt.Sprintf("%s (%s)", detail, strings.Join(dv.sources, ", ")))
+		}
+
+		r.Warnf("  %s/%s: %s\n", key.ecosystem, key.name, strings.Join(details, "; "))
+	}
+}
@@ -0,0 +1,87 @@
+package osvscanner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func Test_reportDuplicateVersions(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{Name: "lodash", Version: "4.17.21", Ecosystem: lockfile.NpmEcosystem, Source: models.SourceInfo{Path: "/a/package-lock.json"}},
+		{Name: "lodash", Version: "4.17.9", Ecosystem: lockfile.NpmEcosystem, Source: models.SourceInfo{Path: "/b/package-lock.json"}},
+		{Name: "lodash", Version: "4.17.9", Ecosystem: lockfile.NpmEcosystem, Source: models.SourceInfo{Path: "/c/package-lock.json"}},
+		{Name: "lodash", Version: "4.17.21", Ecosystem: lockfile.NpmEcosystem, Source: models.SourceInfo{Path: "/a/package-lock.json"}},
+		{Name: "left-pad", Version: "1.3.0", Ecosystem: lockfile.NpmEcosystem, Source: models.SourceInfo{Path: "/a/package-lock.json"}},
+		{Name: "left-pad", Version: "1.3.0", Ecosystem: lockfile.NpmEcosystem, Source: models.SourceInfo{Path: "/b/package-lock.json"}},
+		{Name: "lodash", Version: "4.17.0", Ecosystem: lockfile.PipEcosystem, Source: models.SourceInfo{Path: "/requirements.txt"}},
+		{Commit: "abc123", Source: models.SourceInfo{Path: "/a"}},
+		{Commit: "def456", Source: models.SourceInfo{Path: "/b"}},
+	}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/b/package-lock.json"},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "lodash", Version: "4.17.9", Ecosystem: "npm"},
+						Groups:  []models.GroupInfo{{IDs: []string{"GHSA-1"}}},
+					},
+				},
+			},
+		},
+	}
+
+	want := "Found 1 package at more than one version:\n" +
+		"  npm/lodash: 4.17.9 [vulnerable] (/b/package-lock.json, /c/package-lock.json); 4.17.21 (/a/package-lock.json)\n"
+
+	out := &bytes.Buffer{}
+	r := reporter.NewTableReporter(out, out, reporter.InfoLevel, false, 0)
+
+	reportDuplicateVersions(r, packages, results)
+
+	if got := out.String(); got != want {
+		t.Errorf("reportDuplicateVersions() printed %q, want %q", got, want)
+	}
+}
+
+func Test_reportDuplicateVersions_IgnoresFiltered(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{Name: "lodash", Version: "4.17.21", Ecosystem: lockfile.NpmEcosystem, Source: models.SourceInfo{Path: "/a/package-lock.json"}},
+		{Name: "lodash", Version: "4.17.9", Ecosystem: lockfile.NpmEcosystem, Source: models.SourceInfo{Path: "/b/package-lock.json"}},
+	}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/b/package-lock.json"},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "lodash", Version: "4.17.9", Ecosystem: "npm"},
+						Groups:  []models.GroupInfo{{IDs: []string{"GHSA-1"}, Filtered: true}},
+					},
+				},
+			},
+		},
+	}
+
+	want := "Found 1 package at more than one version:\n" +
+		"  npm/lodash: 4.17.9 (/b/package-lock.json); 4.17.21 (/a/package-lock.json)\n"
+
+	out := &bytes.Buffer{}
+	r := reporter.NewTableReporter(out, out, reporter.InfoLevel, false, 0)
+
+	reportDuplicateVersions(r, packages, results)
+
+	if got := out.String(); got != want {
+		t.Errorf("reportDuplicateVersions() printed %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+package osvscanner
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// excludeMatcher matches paths against glob patterns (such as "**/testdata/**") to
+// exclude them from being scanned, relative to the directory being scanned.
+//
+// Each segment of a pattern is matched with path.Match, except for "**" which
+// matches any number of directories (including none)
+type excludeMatcher struct {
+	root     string
+	patterns []string
+}
+
+func newExcludeMatcher(root string, patterns []string) (*excludeMatcher, error) {
+	for _, pattern := range patterns {
+		if err := validateExcludePattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	return &excludeMatcher{root: root, patterns: patterns}, nil
+}
+
+// validateExcludePattern returns an error if the pattern is not a valid exclude glob
+func validateExcludePattern(pattern string) error {
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// match returns the pattern that absPath matches, if any
+func (m *excludeMatcher) match(absPath string) (string, bool) {
+	if m == nil || len(m.patterns) == 0 {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(m.root, absPath)
+	if err != nil || rel == "." {
+		return "", false
+	}
+
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+	for _, pattern := range m.patterns {
+		if matchExcludeSegments(strings.Split(strings.Trim(pattern, "/"), "/"), segments) {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+func matchExcludeSegments(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchExcludeSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+
+	return matchExcludeSegments(pattern[1:], segments[1:])
+}
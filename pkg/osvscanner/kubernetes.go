@@ -0,0 +1,109 @@
+package osvscanner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kubernetesContainerFields are the fields of a pod spec that list containers,
+// each of which has the image it runs
+var kubernetesContainerFields = []string{"containers", "initContainers", "ephemeralContainers"}
+
+// kubernetesManifestImages returns the container images referenced by the Kubernetes manifest at path,
+// which can have multiple documents (such as Deployments, Pods and StatefulSets) of any kind
+func kubernetesManifestImages(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var images []string
+	decoder := yaml.NewDecoder(f)
+
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not parse Kubernetes manifest %s: %w", path, err)
+		}
+
+		images = append(images, findContainerImages(&document)...)
+	}
+
+	return images, nil
+}
+
+// findContainerImages returns the images of every container listed anywhere within the node,
+// so that containers are found no matter how deeply their pod spec is nested (such as within
+// the template of a Deployment, or the job template of a CronJob)
+func findContainerImages(node *yaml.Node) []string {
+	var images []string
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			if slices.Contains(kubernetesContainerFields, key.Value) && value.Kind == yaml.SequenceNode {
+				for _, container := range value.Content {
+					if image := mappingValue(container, "image"); image != "" {
+						images = append(images, image)
+					}
+				}
+
+				continue
+			}
+
+			images = append(images, findContainerImages(value)...)
+		}
+
+		return images
+	}
+
+	for _, child := range node.Content {
+		images = append(images, findContainerImages(child)...)
+	}
+
+	return images
+}
+
+// mappingValue returns the value of key if node is a mapping that has it as a string
+func mappingValue(node *yaml.Node, key string) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key && node.Content[i+1].Kind == yaml.ScalarNode {
+			return node.Content[i+1].Value
+		}
+	}
+
+	return ""
+}
+
+// uniqueImages returns the images without any duplicates, in the order they were first referenced
+func uniqueImages(images []string) []string {
+	var unique []string
+	seen := map[string]struct{}{}
+
+	for _, image := range images {
+		if _, ok := seen[image]; ok {
+			continue
+		}
+
+		seen[image] = struct{}{}
+		unique = append(unique, image)
+	}
+
+	return unique
+}
@@ -0,0 +1,65 @@
+package osvscanner
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_kubernetesManifestImages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "multiple documents",
+			path: "fixtures/k8s/app.yaml",
+			want: []string{"example/migrate:1.0.0", "nginx:1.25", "example/sidecar:2.3.1", "debian:bookworm"},
+		},
+		{
+			name: "pod",
+			path: "fixtures/k8s/pod.yaml",
+			want: []string{"nginx:1.25", "debian:bookworm"},
+		},
+		{
+			name:    "invalid yaml",
+			path:    "fixtures/k8s/invalid.yaml",
+			wantErr: true,
+		},
+		{
+			name:    "does not exist",
+			path:    "fixtures/k8s/does-not-exist.yaml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := kubernetesManifestImages(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("kubernetesManifestImages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("kubernetesManifestImages() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_uniqueImages(t *testing.T) {
+	t.Parallel()
+
+	got := uniqueImages([]string{"nginx:1.25", "debian:bookworm", "nginx:1.25", "nginx:1.26", "debian:bookworm"})
+	want := []string{"nginx:1.25", "debian:bookworm", "nginx:1.26"}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("uniqueImages() (-want +got):\n%s", diff)
+	}
+}
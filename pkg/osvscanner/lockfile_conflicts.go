@@ -0,0 +1,110 @@
+package osvscanner
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+type lockfileConflictKey struct {
+	dir       string
+	ecosystem string
+}
+
+// conflictingLockfiles finds the directories with more than one lockfile for the same ecosystem,
+// such as both a package-lock.json and a yarn.lock, returning the paths of the lockfiles in the
+// order their packages were found for each directory and ecosystem, in the order they were found
+func conflictingLockfiles(packages []scannedPackage) ([]lockfileConflictKey, map[lockfileConflictKey][]string) {
+	var keys []lockfileConflictKey
+	lockfiles := map[lockfileConflictKey][]string{}
+
+	for _, pkg := range packages {
+		if pkg.Source.Type != "lockfile" || pkg.Ecosystem == "" {
+			continue
+		}
+
+		key := lockfileConflictKey{dir: filepath.Dir(pkg.Source.Path), ecosystem: string(pkg.Ecosystem)}
+		if slices.Contains(lockfiles[key], pkg.Source.Path) {
+			continue
+		}
+		if _, ok := lockfiles[key]; !ok {
+			keys = append(keys, key)
+		}
+		lockfiles[key] = append(lockfiles[key], pkg.Source.Path)
+	}
+
+	keys = slices.DeleteFunc(keys, func(key lockfileConflictKey) bool {
+		return len(lockfiles[key]) < 2
+	})
+
+	return keys, lockfiles
+}
+
+// preferredLockfile returns the path of the lockfile whose name comes first in preferences,
+// or an empty string if none of them are in it
+func preferredLockfile(paths []string, preferences []string) string {
+	for _, name := range preferences {
+		for _, path := range paths {
+			if filepath.Base(path) == name {
+				return path
+			}
+		}
+	}
+
+	return ""
+}
+
+// resolveLockfileConflicts warns about each directory with more than one lockfile for the same
+// ecosystem, as scanning all of them can count the same packages more than once or mix up packages
+// from different states of the project.
+//
+// If one of the lockfiles is in preferences (which are lockfile names in order of precedence),
+// the packages of the others are removed, otherwise they are all kept
+func resolveLockfileConflicts(r reporter.Reporter, packages []scannedPackage, preferences []string) []scannedPackage {
+	keys, lockfiles := conflictingLockfiles(packages)
+	skipped := map[string]bool{}
+
+	for _, key := range keys {
+		paths := lockfiles[key]
+		names := make([]string, 0, len(paths))
+		for _, path := range paths {
+			names = append(names, filepath.Base(path))
+		}
+
+		preferred := preferredLockfile(paths, preferences)
+		if preferred == "" {
+			r.Warnf(
+				"Found multiple lockfiles for the %s ecosystem in %s (%s), which will all be scanned; use --prefer-lockfile to only scan one of them\n",
+				key.ecosystem,
+				key.dir,
+				strings.Join(names, ", "),
+			)
+
+			continue
+		}
+
+		r.Warnf(
+			"Found multiple lockfiles for the %s ecosystem in %s (%s), only scanning %s as it is preferred\n",
+			key.ecosystem,
+			key.dir,
+			strings.Join(names, ", "),
+			filepath.Base(preferred),
+		)
+
+		for _, path := range paths {
+			if path != preferred {
+				skipped[path] = true
+			}
+		}
+	}
+
+	if len(skipped) == 0 {
+		return packages
+	}
+
+	return slices.DeleteFunc(packages, func(pkg scannedPackage) bool {
+		return pkg.Source.Type == "lockfile" && skipped[pkg.Source.Path]
+	})
+}
@@ -0,0 +1,89 @@
+package osvscanner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func Test_resolveLockfileConflicts(t *testing.T) {
+	t.Parallel()
+
+	lockfilePackage := func(name string, ecosystem lockfile.Ecosystem, path string) scannedPackage {
+		return scannedPackage{
+			Name:      name,
+			Version:   "1.0.0",
+			Ecosystem: ecosystem,
+			Source:    models.SourceInfo{Path: path, Type: "lockfile"},
+		}
+	}
+
+	packages := []scannedPackage{
+		lockfilePackage("lodash", lockfile.NpmEcosystem, "/a/package-lock.json"),
+		lockfilePackage("left-pad", lockfile.NpmEcosystem, "/a/package-lock.json"),
+		lockfilePackage("lodash", lockfile.NpmEcosystem, "/a/yarn.lock"),
+		lockfilePackage("requests", lockfile.PipEcosystem, "/a/requirements.txt"),
+		lockfilePackage("lodash", lockfile.NpmEcosystem, "/b/yarn.lock"),
+		lockfilePackage("lodash", lockfile.NpmEcosystem, "/a/b/package-lock.json"),
+		{Name: "lodash", Version: "1.0.0", Ecosystem: lockfile.NpmEcosystem, Source: models.SourceInfo{Path: "/a/bom.json", Type: "sbom"}},
+	}
+
+	tests := []struct {
+		name        string
+		preferences []string
+		want        []scannedPackage
+		wantOutput  string
+	}{
+		{
+			name:        "without preferences",
+			preferences: nil,
+			want:        packages,
+			wantOutput: "Found multiple lockfiles for the npm ecosystem in /a (package-lock.json, yarn.lock), " +
+				"which will all be scanned; use --prefer-lockfile to only scan one of them\n",
+		},
+		{
+			name:        "with a preference",
+			preferences: []string{"yarn.lock", "package-lock.json"},
+			want: []scannedPackage{
+				packages[2],
+				packages[3],
+				packages[4],
+				packages[5],
+				packages[6],
+			},
+			wantOutput: "Found multiple lockfiles for the npm ecosystem in /a (package-lock.json, yarn.lock), " +
+				"only scanning yarn.lock as it is preferred\n",
+		},
+		{
+			name:        "with preferences that do not include the lockfiles",
+			preferences: []string{"pnpm-lock.yaml"},
+			want:        packages,
+			wantOutput: "Found multiple lockfiles for the npm ecosystem in /a (package-lock.json, yarn.lock), " +
+				"which will all be scanned; use --prefer-lockfile to only scan one of them\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Reinitialize for t.Parallel()
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &bytes.Buffer{}
+			r := reporter.NewTableReporter(out, out, reporter.InfoLevel, false, 0)
+
+			got := resolveLockfileConflicts(r, append([]scannedPackage{}, packages...), tt.preferences)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("resolveLockfileConflicts() packages (-want +got):\n%s", diff)
+			}
+
+			if got := out.String(); got != tt.wantOutput {
+				t.Errorf("resolveLockfileConflicts() printed %q, want %q", got, tt.wantOutput)
+			}
+		})
+	}
+}
@@ -10,12 +10,16 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/google/osv-scanner/internal/cachedregexp"
 	"github.com/google/osv-scanner/internal/customgitignore"
 	"github.com/google/osv-scanner/internal/image"
 	"github.com/google/osv-scanner/internal/local"
 	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/internal/packagist"
 	"github.com/google/osv-scanner/internal/sbom"
 	"github.com/google/osv-scanner/internal/semantic"
 	"github.com/google/osv-scanner/internal/version"
@@ -29,19 +33,112 @@ import (
 	depsdevpb "deps.dev/api/v3"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"golang.org/x/exp/maps"
 )
 
 type ScannerActions struct {
-	LockfilePaths        []string
-	SBOMPaths            []string
-	DirectoryPaths       []string
-	GitCommits           []string
-	Recursive            bool
+	LockfilePaths []string
+	SBOMPaths     []string
+	// DirectoryPaths are the directories to scan, which can also be .zip or .tar(.gz) archives of source code
+	// that are extracted to a temporary directory to be scanned
+	DirectoryPaths []string
+	// GitCommits are the version control hashes (such as git commits) of code to scan directly,
+	// which are reported under the "hash" source
+	GitCommits []string
+	Recursive  bool
+	// MaxDepth limits how many directories deep a recursive scan descends from each directory,
+	// with 0 meaning there is no limit
+	MaxDepth int
+	// ExcludePatterns are globs (such as "**/testdata/**") of paths to skip when scanning directories,
+	// relative to each directory being scanned
+	ExcludePatterns      []string
 	SkipGit              bool
+	SkipGitSubmodules    bool
 	NoIgnore             bool
 	DockerContainerNames []string
-	ConfigOverridePath   string
-	CallAnalysisStates   map[string]bool
+	// KubernetesManifestPaths are the paths to Kubernetes manifests whose container images are scanned
+	// in the same way as DockerContainerNames, with each unique image only being scanned once
+	KubernetesManifestPaths []string
+	ConfigOverridePath      string
+	CallAnalysisStates      map[string]bool
+	OnlyFixable             bool
+	// StrictMatching re-checks that each vulnerability affects the installed version of the package
+	// using the versioning rules of its ecosystem, removing the matches that do not hold
+	StrictMatching bool
+	// ModifiedSince filters out vulnerabilities that have not been modified since this time, if set
+	ModifiedSince time.Time
+	// PURLs are the Package URLs (such as "pkg:npm/foo@1.2.3") of packages to scan directly
+	PURLs []string
+	// StrictEcosystems reports packages in ecosystems that are not supported by OSV as
+	// a warning (StrictEcosystemsWarn) or an error (StrictEcosystemsError), if set
+	StrictEcosystems string
+	// FailOn is which vulnerabilities cause VulnerabilitiesFoundErr to be returned, which is either
+	// only those that are called (FailOnCalled, the default) or any of them (FailOnAny)
+	FailOn string
+	// SeverityExitCodes are the exit codes to use when vulnerabilities in each severity bucket (see SeverityBuckets)
+	// fail the scan, in which case a ResultError with the highest matching exit code is returned
+	SeverityExitCodes map[string]int
+	// CVSSVersion is which version of CVSS vector is used to calculate the max severity of
+	// vulnerabilities that have more than one, falling back to all of them if they do not
+	// have that version; if empty or CVSSVersionAuto, the max across all versions is used
+	CVSSVersion string
+	// CheckDrift warns about lockfiles that disagree with the manifest alongside them,
+	// such as a package-lock.json that is out of sync with its package.json
+	CheckDrift bool
+	// FailOnDrift reports lockfiles that disagree with their manifest as errors instead, implying CheckDrift
+	FailOnDrift bool
+	// ReportDuplicateVersions warns about packages that are at more than one version across the scanned sources,
+	// marking which of those versions are vulnerable
+	ReportDuplicateVersions bool
+	// ScanRuntimes checks the versions of Node.js and Python that are pinned by .nvmrc, .node-version and
+	// .python-version files, and reports the version of Go in go.mod files as a runtime rather than a dependency,
+	// warning about any of them that have reached their end of life
+	ScanRuntimes bool
+	// IncludeGraph includes the graph of which packages depend on each other in the results,
+	// for the sources that record the dependencies of their packages
+	IncludeGraph bool
+	// MinPackages fails the scan with ErrTooFewPackages if fewer packages than this are found in total,
+	// as a safety net against scans that accidentally find (almost) nothing
+	MinPackages int
+	// RequireEcosystems fails the scan with ErrMissingEcosystems if no packages are found in any of these
+	// ecosystems (matched case-insensitively, such as "pypi" for PyPI), as a safety net against lockfiles
+	// that have been accidentally deleted or are no longer being picked up
+	RequireEcosystems []string
+	// ResolveConstraints resolves the version constraints of packages from manifests
+	// (such as composer.json) to the latest versions that satisfy them
+	ResolveConstraints bool
+	// Parallelism is the number of files to extract packages from at the same time,
+	// defaulting to the number of CPUs if it is not positive
+	Parallelism int
+	// AllowParseErrors continues the scan when a lockfile cannot be parsed, reporting it as a warning
+	// and returning ErrParseFailures along with the results of the other files
+	AllowParseErrors bool
+	// ExcludeDevDependencies skips scanning packages that are only development dependencies
+	ExcludeDevDependencies bool
+	// ShowDependencyRelationship includes whether each package is a direct or transitive dependency
+	// in the results, for lockfiles that record it
+	ShowDependencyRelationship bool
+	// ShowFiltered keeps the vulnerabilities that are ignored by config files or inline comments
+	// in the results, with their groups marked as filtered and annotated with why
+	ShowFiltered bool
+	// ShowAffectedRanges includes the version ranges of each package that are affected by the vulnerabilities in the results
+	ShowAffectedRanges bool
+	// ShowSummary includes the summary of the vulnerabilities in each group in the results
+	ShowSummary bool
+	// ShowRelated includes the IDs of the vulnerabilities related to those in each group in the results
+	ShowRelated bool
+	// ShowCallPaths includes the chain of functions through which the scanned code calls each
+	// called vulnerable symbol in the call analysis results, for languages where it is known
+	ShowCallPaths bool
+	// OfflineVulnerabilitiesPath is the path to a bundle of vulnerabilities to scan against, without network access
+	OfflineVulnerabilitiesPath string
+	// ExportOfflineVulnerabilitiesPath is the path to write a bundle of the vulnerabilities found by the scan
+	ExportOfflineVulnerabilitiesPath string
+	// PreferLockfiles are the names of lockfiles in order of precedence, such as "package-lock.json",
+	// which are used to only scan one of the lockfiles for the same ecosystem in a directory
+	PreferLockfiles []string
+	// Profile records the time spent in each phase of the scan if it is not nil
+	Profile *Profile
 
 	ExperimentalScannerActions
 }
@@ -52,9 +149,22 @@ type ExperimentalScannerActions struct {
 	ShowAllPackages       bool
 	ScanLicensesSummary   bool
 	ScanLicensesAllowlist []string
-	ScanOCIImage          string
+	// ScanLicensesDenylist reports packages with any of these licenses as violations,
+	// and cannot be used together with ScanLicensesAllowlist
+	ScanLicensesDenylist []string
+	ScanOCIImage         string
 
 	LocalDBPath string
+	// LocalDBEcosystems limits the local databases that are downloaded and loaded to these ecosystems
+	LocalDBEcosystems []string
+	// LocalDBTimeout is the maximum time to spend downloading each local database, including retries,
+	// with 0 meaning no limit
+	LocalDBTimeout time.Duration
+}
+
+// checksLicenseViolations returns true if licenses are checked against either an allowlist or a denylist
+func (actions ExperimentalScannerActions) checksLicenseViolations() bool {
+	return len(actions.ScanLicensesAllowlist) > 0 || len(actions.ScanLicensesDenylist) > 0
 }
 
 // NoPackagesFoundErr for when no packages are found during a scan.
@@ -62,6 +172,14 @@ type ExperimentalScannerActions struct {
 //nolint:errname,stylecheck // Would require version major bump to change
 var NoPackagesFoundErr = errors.New("no packages found in scan")
 
+// ErrTooFewPackages is returned when fewer packages are found than ScannerActions.MinPackages,
+// which usually means the scan was misconfigured (such as by scanning the wrong directory)
+var ErrTooFewPackages = errors.New("too few packages found in scan")
+
+// ErrMissingEcosystems is returned when no packages are found in some of ScannerActions.RequireEcosystems,
+// which usually means a lockfile is missing or is not being scanned
+var ErrMissingEcosystems = errors.New("required ecosystems were not scanned")
+
 // VulnerabilitiesFoundErr includes both vulnerabilities being found or license violations being found,
 // however, will not be raised if only uncalled vulnerabilities are found.
 //
@@ -73,6 +191,35 @@ var VulnerabilitiesFoundErr = errors.New("vulnerabilities found")
 //nolint:errname,stylecheck // Would require version bump to change
 var OnlyUncalledVulnerabilitiesFoundErr = errors.New("only uncalled vulnerabilities found")
 
+const (
+	// StrictEcosystemsWarn reports packages in ecosystems that are not supported by OSV as a warning
+	StrictEcosystemsWarn = "warn"
+	// StrictEcosystemsError reports packages in ecosystems that are not supported by OSV as an error
+	StrictEcosystemsError = "error"
+)
+
+const (
+	// FailOnCalled only fails the scan for vulnerabilities that call analysis did not determine are uncalled,
+	// which are all of them if call analysis was not run
+	FailOnCalled = "called"
+	// FailOnAny fails the scan for any vulnerabilities, even if call analysis determined they are uncalled
+	FailOnAny = "any"
+)
+
+const (
+	// CVSSVersionAuto uses the highest score across all the CVSS vectors of a vulnerability
+	CVSSVersionAuto = "auto"
+	CVSSVersionV2   = "v2"
+	CVSSVersionV3   = "v3"
+	CVSSVersionV4   = "v4"
+)
+
+var cvssVersionSeverityTypes = map[string]models.SeverityType{
+	CVSSVersionV2: models.SeverityCVSSV2,
+	CVSSVersionV3: models.SeverityCVSSV3,
+	CVSSVersionV4: models.SeverityCVSSV4,
+}
+
 // ErrAPIFailed describes errors related to querying API endpoints.
 var ErrAPIFailed = errors.New("API query failed")
 
@@ -98,27 +245,74 @@ const (
 	maxDetermineVersionFiles  = 10000
 )
 
+// scanDirOptions controls what scanDir looks for in a directory and how
+type scanDirOptions struct {
+	SkipGit           bool
+	SkipGitSubmodules bool
+	Recursive         bool
+	// MaxDepth is how many directories deep to scan when Recursive, without limit if it is not positive
+	MaxDepth       int
+	Exclude        []string
+	UseGitIgnore   bool
+	CompareOffline bool
+	ScanRuntimes   bool
+	Parallelism    int
+	Profile        *Profile
+}
+
+// newScanDirOptions returns the options for scanning a directory with the given actions,
+// excluding the given patterns (which include those from the config of the directory)
+func newScanDirOptions(actions ScannerActions, exclude []string) scanDirOptions {
+	return scanDirOptions{
+		SkipGit:           actions.SkipGit,
+		SkipGitSubmodules: actions.SkipGitSubmodules,
+		Recursive:         actions.Recursive,
+		MaxDepth:          actions.MaxDepth,
+		Exclude:           exclude,
+		UseGitIgnore:      !actions.NoIgnore,
+		CompareOffline:    actions.CompareOffline,
+		ScanRuntimes:      actions.ScanRuntimes,
+		Parallelism:       actions.Parallelism,
+		Profile:           actions.Profile,
+	}
+}
+
 // scanDir walks through the given directory to try to find any relevant files
 // These include:
 //   - Any lockfiles with scanLockfile
 //   - Any SBOM files with scanSBOMFile
 //   - Any git repositories with scanGit
-func scanDir(r reporter.Reporter, dir string, skipGit bool, recursive bool, useGitIgnore bool, compareOffline bool) ([]scannedPackage, error) {
+//
+// If options.Recursive, subdirectories are also walked up to options.MaxDepth directories deep
+func scanDir(r reporter.Reporter, dir string, options scanDirOptions, scanned scannedFiles, failures *parseFailures) ([]scannedPackage, error) {
+	useGitIgnore := options.UseGitIgnore
 	var ignoreMatcher *gitIgnoreMatcher
 	if useGitIgnore {
 		var err error
-		ignoreMatcher, err = parseGitIgnores(dir, recursive)
+		ignoreMatcher, err = parseGitIgnores(dir, options.Recursive)
 		if err != nil {
-			r.Errorf("Unable to parse git ignores: %v\n", err)
+			r.Warnf("Unable to parse git ignores: %v\n", err)
 			useGitIgnore = false
 		}
 	}
 
 	root := true
 
-	var scannedPackages []scannedPackage
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeMatcher, err := newExcludeMatcher(absDir, options.Exclude)
+	if err != nil {
+		return nil, err
+	}
 
-	return scannedPackages, filepath.WalkDir(dir, func(path string, info os.DirEntry, err error) error {
+	// The packages are extracted from each file in parallel once the walk is done,
+	// so the walk only collects what needs to be scanned
+	var tasks []scanTask
+
+	walkErr := filepath.WalkDir(dir, func(path string, info os.DirEntry, err error) error {
 		if err != nil {
 			r.Infof("Failed to walk %s: %v\n", path, err)
 			return err
@@ -130,6 +324,15 @@ func scanDir(r reporter.Reporter, dir string, skipGit bool, recursive bool, useG
 			return err
 		}
 
+		if pattern, ok := excludeMatcher.match(path); ok {
+			r.Verbosef("Skipping %s as it matches the exclude pattern %s\n", path, pattern)
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
 		if useGitIgnore {
 			match, err := ignoreMatcher.match(path, info.IsDir())
 			if err != nil {
@@ -147,49 +350,129 @@ func scanDir(r reporter.Reporter, dir string, skipGit bool, recursive bool, useG
 			}
 		}
 
-		if !skipGit && info.IsDir() && info.Name() == ".git" {
-			pkgs, err := scanGit(r, filepath.Dir(path)+"/")
-			if err != nil {
-				r.Infof("scan failed for git repository, %s: %v\n", path, err)
-				// Not fatal, so don't return and continue scanning other files
-			}
-			scannedPackages = append(scannedPackages, pkgs...)
+		if !options.SkipGit && info.IsDir() && info.Name() == ".git" {
+			tasks = append(tasks, func(r reporter.Reporter) ([]scannedPackage, error) {
+				pkgs, err := scanGit(r, filepath.Dir(path)+"/", options.SkipGitSubmodules)
+				if err != nil {
+					r.Infof("scan failed for git repository, %s: %v\n", path, err)
+					// Not fatal, so don't return and continue scanning other files
+				}
+
+				return pkgs, nil
+			})
 
 			return filepath.SkipDir
 		}
 
-		if !info.IsDir() {
-			if extractor, _ := lockfile.FindExtractor(path, ""); extractor != nil {
-				pkgs, err := scanLockfile(r, path, "")
-				if err != nil {
-					r.Errorf("Attempted to scan lockfile but failed: %s\n", path)
+		if !info.IsDir() && !scanned.markScanned(path, "") {
+			tasks = append(tasks, skippedFileTask(path))
+		} else if !info.IsDir() {
+			tasks = append(tasks, func(r reporter.Reporter) ([]scannedPackage, error) {
+				var scannedPackages []scannedPackage
+				var parseErr error
+				record := options.Profile.trackExtraction(path)
+				defer func() { record(scannedPackages) }()
+				if hasPreferredLockfile(path) {
+					r.Verbosef("Skipping %s as there is a lockfile next to it\n", path)
+				} else if extractor, _ := lockfile.FindExtractor(path, ""); extractor != nil {
+					pkgs, err := scanLockfile(r, path, "")
+					if err != nil {
+						parseErr = failures.report(r, path, err)
+					}
+					scannedPackages = append(scannedPackages, pkgs...)
+				} else if options.ScanRuntimes && isRuntimeVersionFile(path) {
+					pkgs, err := scanRuntimeVersionFile(r, path)
+					if err != nil {
+						parseErr = failures.report(r, path, err)
+					}
+					scannedPackages = append(scannedPackages, pkgs...)
 				}
+				// No need to check for error
+				// If scan fails, it means it isn't a valid SBOM file,
+				// so just move onto the next file
+				pkgs, _ := scanSBOMFile(r, path, true)
 				scannedPackages = append(scannedPackages, pkgs...)
-			}
-			// No need to check for error
-			// If scan fails, it means it isn't a valid SBOM file,
-			// so just move onto the next file
-			pkgs, _ := scanSBOMFile(r, path, true)
-			scannedPackages = append(scannedPackages, pkgs...)
+
+				return scannedPackages, parseErr
+			})
 		}
 
-		if info.IsDir() && !compareOffline {
+		if info.IsDir() && !options.CompareOffline {
 			if _, ok := vendoredLibNames[strings.ToLower(filepath.Base(path))]; ok {
-				pkgs, err := scanDirWithVendoredLibs(r, path)
-				if err != nil {
-					r.Infof("scan failed for dir containing vendored libs %s: %v\n", path, err)
-				}
-				scannedPackages = append(scannedPackages, pkgs...)
+				tasks = append(tasks, func(r reporter.Reporter) ([]scannedPackage, error) {
+					pkgs, err := scanDirWithVendoredLibs(r, path)
+					if err != nil {
+						r.Infof("scan failed for dir containing vendored libs %s: %v\n", path, err)
+					}
+
+					return pkgs, nil
+				})
 			}
 		}
 
-		if !root && !recursive && info.IsDir() {
+		if !root && !options.Recursive && info.IsDir() {
+			return filepath.SkipDir
+		}
+
+		if !root && options.MaxDepth > 0 && info.IsDir() && pathDepth(absDir, path) > options.MaxDepth {
+			r.Verbosef("Skipping %s as it is more than %d %s deep\n", path, options.MaxDepth, output.Form(options.MaxDepth, "directory", "directories"))
+
 			return filepath.SkipDir
 		}
 		root = false
 
 		return nil
 	})
+
+	var scannedPackages []scannedPackage
+	for _, result := range runScanTasks(options.Parallelism, tasks) {
+		result.replay(r)
+		failures.record(result.err)
+		scannedPackages = append(scannedPackages, result.packages...)
+	}
+
+	return scannedPackages, walkErr
+}
+
+// scannedFiles records the files that packages have been extracted from, keyed on their
+// absolute path and what they were parsed as, so that each file is only extracted once
+// when it is covered by both a --lockfile flag and a directory being scanned
+type scannedFiles map[string]map[string]bool
+
+// markScanned records that the file at path is being parsed as parseAs, returning false if it
+// has already been, or if parseAs is empty (i.e. detected from the file name) and the file has
+// already been parsed as anything, as an explicit parse-as takes precedence
+func (files scannedFiles) markScanned(path string, parseAs string) bool {
+	parsedAs, ok := files[path]
+	if ok && (parseAs == "" || parsedAs[parseAs]) {
+		return false
+	}
+	if !ok {
+		parsedAs = make(map[string]bool)
+		files[path] = parsedAs
+	}
+	parsedAs[parseAs] = true
+
+	return true
+}
+
+// pathDepth returns how many directories deep path is within dir
+func pathDepth(dir string, path string) int {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+
+	return len(strings.Split(rel, string(filepath.Separator)))
+}
+
+// skippedFileTask reports that the file at path has already been scanned
+func skippedFileTask(path string) scanTask {
+	return func(r reporter.Reporter) ([]scannedPackage, error) {
+		r.Verbosef("Skipping %s as it has already been scanned\n", path)
+
+		return nil, nil
+	}
 }
 
 type gitIgnoreMatcher struct {
@@ -322,6 +605,8 @@ func scanImage(r reporter.Reporter, path string) ([]scannedPackage, error) {
 
 	for _, l := range scanResults.Lockfiles {
 		for _, pkgDetail := range l.Packages {
+			layer, _ := scanResults.PackageLayers[l.FilePath].Get(pkgDetail)
+
 			packages = append(packages, scannedPackage{
 				Name:      pkgDetail.Name,
 				Version:   pkgDetail.Version,
@@ -332,6 +617,7 @@ func scanImage(r reporter.Reporter, path string) ([]scannedPackage, error) {
 					Path: path + ":" + l.FilePath,
 					Type: "docker",
 				},
+				ImageLayer: layer,
 			})
 		}
 	}
@@ -348,16 +634,26 @@ func scanLockfile(r reporter.Reporter, path string, parseAs string) ([]scannedPa
 	f, err := lockfile.OpenLocalDepFile(path)
 
 	if err == nil {
-		// special case for the APK and DPKG parsers because they have a very generic name while
-		// living at a specific location, so they are not included in the map of parsers
+		// special case for the APK, DPKG and Python installed parsers because they have a very generic name
+		// (or are a directory) while living at a specific location, so they are not included in the map of parsers
 		// used by lockfile.Parse to avoid false-positives when scanning projects
 		switch parseAs {
 		case "apk-installed":
 			parsedLockfile, err = lockfile.FromApkInstalled(path)
 		case "dpkg-status":
 			parsedLockfile, err = lockfile.FromDpkgStatus(path)
+		case "python-installed":
+			parsedLockfile, err = lockfile.FromPythonInstalled(path)
+		case "node-modules":
+			parsedLockfile, err = lockfile.FromNodeModules(path)
 		case "osv-scanner":
 			parsedLockfile, err = lockfile.FromOSVScannerResults(path)
+		case "maven-dependency-tree":
+			// the output of `mvn dependency:tree` can be written to a file with any name
+			parsedLockfile, err = lockfile.FromMavenDependencyTree(path)
+		case "brew":
+			// either the output of `brew list --versions`, which can have any name, or a Cellar directory
+			parsedLockfile, err = lockfile.FromBrewInstalled(path)
 		default:
 			parsedLockfile, err = lockfile.ExtractDeps(f, parseAs)
 		}
@@ -367,6 +663,12 @@ func scanLockfile(r reporter.Reporter, path string, parseAs string) ([]scannedPa
 		return nil, err
 	}
 
+	return lockfilePackages(r, path, parseAs, parsedLockfile), nil
+}
+
+// lockfilePackages reports how many packages were found in the lockfile at path,
+// and returns them as the packages to be scanned
+func lockfilePackages(r reporter.Reporter, path string, parseAs string, parsedLockfile lockfile.Lockfile) []scannedPackage {
 	parsedAsComment := ""
 
 	if parseAs != "" {
@@ -384,19 +686,24 @@ func scanLockfile(r reporter.Reporter, path string, parseAs string) ([]scannedPa
 	packages := make([]scannedPackage, len(parsedLockfile.Packages))
 	for i, pkgDetail := range parsedLockfile.Packages {
 		packages[i] = scannedPackage{
-			Name:      pkgDetail.Name,
-			Version:   pkgDetail.Version,
-			Commit:    pkgDetail.Commit,
-			Ecosystem: pkgDetail.Ecosystem,
-			DepGroups: pkgDetail.DepGroups,
+			Name:              pkgDetail.Name,
+			Version:           pkgDetail.Version,
+			Commit:            pkgDetail.Commit,
+			Ecosystem:         pkgDetail.Ecosystem,
+			DepGroups:         pkgDetail.DepGroups,
+			Ignores:           pkgDetail.Ignores,
+			DepRelationship:   pkgDetail.Relationship,
+			VersionConstraint: pkgDetail.VersionConstraint,
+			Dependencies:      pkgDetail.Dependencies,
 			Source: models.SourceInfo{
-				Path: path,
-				Type: "lockfile",
+				Path:      path,
+				Type:      "lockfile",
+				Workspace: pkgDetail.Workspace,
 			},
 		}
 	}
 
-	return packages, nil
+	return packages
 }
 
 // scanSBOMFile will load, identify, and parse the SBOM path passed in, and add the dependencies specified
@@ -404,8 +711,19 @@ func scanLockfile(r reporter.Reporter, path string, parseAs string) ([]scannedPa
 func scanSBOMFile(r reporter.Reporter, path string, fromFSScan bool) ([]scannedPackage, error) {
 	var errs []error
 	var packages []scannedPackage
+
+	// Files with names that could be an SBOM of any format (such as "sbom.json")
+	// are identified by the start of their content when scanning a filesystem
+	var header []byte
+	if fromFSScan && sbom.IsAmbiguousFileName(path) {
+		if file, err := os.Open(path); err == nil {
+			header, _ = sbom.ReadHeader(file)
+			file.Close()
+		}
+	}
+
 	for _, provider := range sbom.Providers {
-		if fromFSScan && !provider.MatchesRecognizedFileNames(path) {
+		if fromFSScan && !provider.MatchesRecognizedFileNames(path) && !provider.MatchesContent(header) {
 			// Skip if filename is not usually a sbom file of this format.
 			// Only do this if this is being done in a filesystem scanning context, where we need to be
 			// careful about spending too much time attempting to parse unrelated files.
@@ -423,7 +741,7 @@ func scanSBOMFile(r reporter.Reporter, path string, fromFSScan bool) ([]scannedP
 		defer file.Close()
 
 		ignoredCount := 0
-		err = provider.GetPackages(file, func(id sbom.Identifier) error {
+		format, err := provider.GetPackages(file, func(id sbom.Identifier) error {
 			_, err := models.PURLToPackage(id.PURL)
 			if err != nil {
 				ignoredCount++
@@ -447,16 +765,17 @@ func scanSBOMFile(r reporter.Reporter, path string, fromFSScan bool) ([]scannedP
 				errs = append(errs, sbom.InvalidFormatError{
 					Msg: "no Package URLs found",
 					Errs: []error{
-						fmt.Errorf("scanned %s as %s SBOM, but failed to find any package URLs, this is required to scan SBOMs", path, provider.Name()),
+						fmt.Errorf("scanned %s as %s %s SBOM, but failed to find any package URLs, this is required to scan SBOMs", path, provider.Name(), format),
 					},
 				})
 
 				continue
 			}
 			r.Infof(
-				"Scanned %s as %s SBOM and found %d %s\n",
+				"Scanned %s as %s %s SBOM and found %d %s\n",
 				path,
 				provider.Name(),
+				format,
 				len(packages),
 				output.Form(len(packages), "package", "packages"),
 			)
@@ -528,8 +847,9 @@ func getSubmodules(repoDir string) (submodules []*git.SubmoduleStatus, err error
 	return submodules, nil
 }
 
-// Scan git repository. Expects repoDir to end with /
-func scanGit(r reporter.Reporter, repoDir string) ([]scannedPackage, error) {
+// Scan git repository, including any submodules unless skipSubmodules is set.
+// Expects repoDir to end with /
+func scanGit(r reporter.Reporter, repoDir string, skipSubmodules bool) ([]scannedPackage, error) {
 	commit, err := getCommitSHA(repoDir)
 	if err != nil {
 		return nil, err
@@ -540,6 +860,10 @@ func scanGit(r reporter.Reporter, repoDir string) ([]scannedPackage, error) {
 	var packages []scannedPackage
 	packages = append(packages, createCommitQueryPackage(commit, repoDir))
 
+	if skipSubmodules {
+		return packages, nil
+	}
+
 	submodules, err := getSubmodules(repoDir)
 	if err != nil {
 		return nil, err
@@ -563,6 +887,47 @@ func createCommitQueryPackage(commit string, source string) scannedPackage {
 	}
 }
 
+// imageReferenceParsers are the parsers for files that reference container images to be
+// scanned (rather than listing packages themselves), which are handled before lockfiles
+var imageReferenceParsers = []string{"k8s-manifest", "docker-compose"}
+
+// createHashQueryPackage creates a package to be queried by the version control hash
+// (such as a git commit) it was built from, which must be a full SHA-1 or SHA-256 hash
+func createHashQueryPackage(hash string) (scannedPackage, error) {
+	if !cachedregexp.MustCompile(`^(?:[0-9a-fA-F]{40}|[0-9a-fA-F]{64})$`).MatchString(hash) {
+		return scannedPackage{}, fmt.Errorf("invalid hash %s: must be a full SHA-1 or SHA-256 hash in hexadecimal", hash)
+	}
+
+	return scannedPackage{
+		Commit: strings.ToLower(hash),
+		Source: models.SourceInfo{
+			Path: "hash",
+			Type: "hash",
+		},
+	}, nil
+}
+
+// createPURLQueryPackage creates a package to be queried by its Package URL,
+// which must be valid and include the version of the package
+func createPURLQueryPackage(purl string) (scannedPackage, error) {
+	pkg, err := models.PURLToPackage(purl)
+	if err != nil {
+		return scannedPackage{}, fmt.Errorf("invalid package URL %s: %w", purl, err)
+	}
+
+	if pkg.Version == "" {
+		return scannedPackage{}, fmt.Errorf("invalid package URL %s: a version is required", purl)
+	}
+
+	return scannedPackage{
+		PURL: purl,
+		Source: models.SourceInfo{
+			Path: "purl",
+			Type: "purl",
+		},
+	}, nil
+}
+
 func scanDebianDocker(r reporter.Reporter, dockerImageName string) ([]scannedPackage, error) {
 	cmd := exec.Command("docker", "run", "--rm", "--entrypoint", "/usr/bin/dpkg-query", dockerImageName, "-f", "${Package}###${Version}\\n", "-W")
 	stdout, err := cmd.StdoutPipe()
@@ -612,16 +977,62 @@ func scanDebianDocker(r reporter.Reporter, dockerImageName string) ([]scannedPac
 	return packages, nil
 }
 
-// Filters results according to config, preserving order. Returns total number of vulnerabilities removed.
-func filterResults(r reporter.Reporter, results *models.VulnerabilityResults, configManager *config.ConfigManager, allPackages bool) int {
+// inlineIgnoreKey identifies a package within a source
+type inlineIgnoreKey struct {
+	source    string
+	ecosystem string
+	name      string
+	version   string
+}
+
+// collectInlineIgnores returns the vulnerabilities ignored by comments in lockfiles,
+// keyed by the package they were declared next to
+func collectInlineIgnores(packages []scannedPackage) map[inlineIgnoreKey][]config.IgnoreEntry {
+	inlineIgnores := map[inlineIgnoreKey][]config.IgnoreEntry{}
+
+	for _, pkg := range packages {
+		if len(pkg.Ignores) == 0 {
+			continue
+		}
+
+		key := inlineIgnoreKey{
+			source:    pkg.Source.Path,
+			ecosystem: string(pkg.Ecosystem),
+			name:      pkg.Name,
+			version:   pkg.Version,
+		}
+
+		for _, ignore := range pkg.Ignores {
+			inlineIgnores[key] = append(inlineIgnores[key], config.IgnoreEntry{
+				ID:     ignore.ID,
+				Reason: ignore.Reason,
+			})
+		}
+	}
+
+	return inlineIgnores
+}
+
+// Filters results according to config and inline ignores, preserving order. Returns total number of vulnerabilities removed.
+//
+// If showFiltered is true, the ignored vulnerabilities are kept with their groups marked as filtered instead,
+// though they are still counted as removed.
+func filterResults(r reporter.Reporter, results *models.VulnerabilityResults, configManager *config.ConfigManager, inlineIgnores map[inlineIgnoreKey][]config.IgnoreEntry, allPackages bool, showFiltered bool) int {
 	removedCount := 0
 	newResults := []models.PackageSource{} // Want 0 vulnerabilities to show in JSON as an empty list, not null.
 	for _, pkgSrc := range results.Results {
 		configToUse := configManager.Get(r, pkgSrc.Source.Path)
 		var newPackages []models.PackageVulns
 		for _, pkgVulns := range pkgSrc.Packages {
-			newVulns := filterPackageVulns(r, pkgVulns, configToUse)
-			removedCount += len(pkgVulns.Vulnerabilities) - len(newVulns.Vulnerabilities)
+			key := inlineIgnoreKey{
+				source:    pkgSrc.Source.Path,
+				ecosystem: pkgVulns.Package.Ecosystem,
+				name:      pkgVulns.Package.Name,
+				version:   pkgVulns.Package.Version,
+			}
+
+			newVulns := filterPackageVulns(r, pkgVulns, configToUse, inlineIgnores[key], showFiltered)
+			removedCount += len(pkgVulns.Vulnerabilities) - len(newVulns.Vulnerabilities) + filteredVulnCount(newVulns)
 			if allPackages || len(newVulns.Vulnerabilities) > 0 || len(pkgVulns.LicenseViolations) > 0 {
 				newPackages = append(newPackages, newVulns)
 			}
@@ -637,8 +1048,41 @@ func filterResults(r reporter.Reporter, results *models.VulnerabilityResults, co
 	return removedCount
 }
 
-// Filters package-grouped vulnerabilities according to config, preserving ordering. Returns filtered package vulnerabilities.
-func filterPackageVulns(r reporter.Reporter, pkgVulns models.PackageVulns, configToUse config.Config) models.PackageVulns {
+// filteredVulnCount returns the number of vulnerabilities of the package that are in filtered groups
+func filteredVulnCount(pkgVulns models.PackageVulns) int {
+	count := 0
+	for _, group := range pkgVulns.Groups {
+		if !group.Filtered {
+			continue
+		}
+		for _, vuln := range pkgVulns.Vulnerabilities {
+			if slices.Contains(group.IDs, vuln.ID) {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// shouldIgnore returns if the vulnerability is ignored by the config or an inline ignore comment,
+// along with the matching ignore entry and where it is from
+func shouldIgnore(configToUse config.Config, inlineIgnores []config.IgnoreEntry, vulnID string) (bool, config.IgnoreEntry, string) {
+	if ignore, ignoreLine := configToUse.ShouldIgnore(vulnID); ignore {
+		return true, ignoreLine, configToUse.LoadPath
+	}
+
+	inlineConfig := config.Config{IgnoredVulns: inlineIgnores}
+	if ignore, ignoreLine := inlineConfig.ShouldIgnore(vulnID); ignore {
+		return true, ignoreLine, models.InlineIgnoreFilterSource
+	}
+
+	return false, config.IgnoreEntry{}, ""
+}
+
+// Filters package-grouped vulnerabilities according to config and inline ignores, preserving ordering.
+// Returns filtered package vulnerabilities, which if showFiltered is true keeps the ignored groups marked as filtered.
+func filterPackageVulns(r reporter.Reporter, pkgVulns models.PackageVulns, configToUse config.Config, inlineIgnores []config.IgnoreEntry, showFiltered bool) models.PackageVulns {
 	ignoredVulns := map[string]struct{}{}
 	// Iterate over groups first to remove all aliases of ignored vulnerabilities.
 	var newGroups []models.GroupInfo
@@ -646,9 +1090,18 @@ func filterPackageVulns(r reporter.Reporter, pkgVulns models.PackageVulns, confi
 		ignore := false
 		for _, id := range group.Aliases {
 			var ignoreLine config.IgnoreEntry
-			if ignore, ignoreLine = configToUse.ShouldIgnore(id); ignore {
-				for _, id := range group.Aliases {
-					ignoredVulns[id] = struct{}{}
+			var ignoreSource string
+			if ignore, ignoreLine, ignoreSource = shouldIgnore(configToUse, inlineIgnores, id); ignore {
+				if showFiltered {
+					// Keep the group as a record of what was ignored, and why
+					group.Filtered = true
+					group.FilterReason = ignoreLine.Reason
+					group.FilterSource = ignoreSource
+					newGroups = append(newGroups, group)
+				} else {
+					for _, id := range group.Aliases {
+						ignoredVulns[id] = struct{}{}
+					}
 				}
 				// NB: This only prints the first reason encountered in all the aliases.
 				switch len(group.Aliases) {
@@ -696,6 +1149,78 @@ func filterPackageVulns(r reporter.Reporter, pkgVulns models.PackageVulns, confi
 	return pkgVulns
 }
 
+// Filters out vulnerability groups where none of the vulnerabilities have a fixed version for
+// the affected package, preserving order. Returns the number of groups removed.
+func filterUnfixable(results *models.VulnerabilityResults, allPackages bool) int {
+	return filterVulnGroups(results, allPackages, func(pkg models.PackageInfo, vuln models.Vulnerability) bool {
+		return len(vuln.FixedVersions()[models.Package{Ecosystem: models.Ecosystem(pkg.Ecosystem), Name: pkg.Name}]) > 0
+	})
+}
+
+// Filters out vulnerability groups where none of the vulnerabilities have been modified
+// since the given time, preserving order. Returns the number of groups removed.
+func filterModifiedBefore(results *models.VulnerabilityResults, since time.Time, allPackages bool) int {
+	return filterVulnGroups(results, allPackages, func(_ models.PackageInfo, vuln models.Vulnerability) bool {
+		return !vuln.Modified.Before(since)
+	})
+}
+
+// Filters out vulnerability groups where none of the vulnerabilities are kept by keep,
+// preserving order. Returns the number of groups removed.
+func filterVulnGroups(results *models.VulnerabilityResults, allPackages bool, keep func(models.PackageInfo, models.Vulnerability) bool) int {
+	removedCount := 0
+	newResults := []models.PackageSource{}
+	for _, pkgSrc := range results.Results {
+		var newPackages []models.PackageVulns
+		for _, pkgVulns := range pkgSrc.Packages {
+			newVulns := filterPackageVulnGroups(pkgVulns, keep)
+			removedCount += len(pkgVulns.Groups) - len(newVulns.Groups)
+			if allPackages || len(newVulns.Vulnerabilities) > 0 || len(newVulns.LicenseViolations) > 0 {
+				newPackages = append(newPackages, newVulns)
+			}
+		}
+		if len(newPackages) > 0 {
+			pkgSrc.Packages = newPackages
+			newResults = append(newResults, pkgSrc)
+		}
+	}
+	results.Results = newResults
+
+	return removedCount
+}
+
+func filterPackageVulnGroups(pkgVulns models.PackageVulns, keep func(models.PackageInfo, models.Vulnerability) bool) models.PackageVulns {
+	kept := map[string]bool{}
+	for _, vuln := range pkgVulns.Vulnerabilities {
+		kept[vuln.ID] = keep(pkgVulns.Package, vuln)
+	}
+
+	var newGroups []models.GroupInfo
+	removedVulns := map[string]struct{}{}
+	for _, group := range pkgVulns.Groups {
+		if slices.ContainsFunc(group.IDs, func(id string) bool { return kept[id] }) {
+			newGroups = append(newGroups, group)
+			continue
+		}
+		for _, id := range group.IDs {
+			removedVulns[id] = struct{}{}
+		}
+	}
+
+	var newVulns []models.Vulnerability
+	for _, vuln := range pkgVulns.Vulnerabilities {
+		if _, removed := removedVulns[vuln.ID]; !removed {
+			newVulns = append(newVulns, vuln)
+		}
+	}
+
+	// Passed by value. We don't want to alter the original PackageVulns.
+	pkgVulns.Groups = newGroups
+	pkgVulns.Vulnerabilities = newVulns
+
+	return pkgVulns
+}
+
 // isUnimportant checks if a Debian vulnerability is tagged with an "unimportant" urgency tag
 // Urgency levels are defined here: https://security-team.debian.org/security_tracker.html#severity-levels
 func isUnimportant(ecosystem string, affectedPackages []models.Affected) bool {
@@ -731,6 +1256,17 @@ type scannedPackage struct {
 	Version   string
 	Source    models.SourceInfo
 	DepGroups []string
+	Ignores   []lockfile.IgnoreComment
+	// DepRelationship is whether the package is a direct or transitive dependency, if known
+	DepRelationship lockfile.DependencyRelationship
+	// VersionConstraint is the constraint on the version of the package, if it was found
+	// in a manifest without an exact version
+	VersionConstraint string
+	// ImageLayer is the layer that introduced the package, if it was found in a container image
+	ImageLayer image.LayerInfo
+	// Dependencies are the lockfile.PackageDetails.DependencyKey of each package that this package
+	// directly depends on, if the lockfile records them
+	Dependencies []string
 }
 
 // Perform osv scanner action, with optional reporter to output information
@@ -739,62 +1275,115 @@ func DoScan(actions ScannerActions, r reporter.Reporter) (models.VulnerabilityRe
 		r = &reporter.VoidReporter{}
 	}
 
-	if actions.CompareOffline {
-		actions.CompareLocally = true
+	actions, err := normalizeActions(actions)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
 	}
 
-	if actions.CompareLocally {
-		actions.SkipGit = true
+	configManager, err := newConfigManager(r, actions)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
 
-		if len(actions.ScanLicensesAllowlist) > 0 || actions.ScanLicensesSummary {
-			return models.VulnerabilityResults{}, errors.New("cannot retrieve licenses locally")
+	//nolint:prealloc // Not sure how many there will be in advance.
+	var scannedPackages []scannedPackage
+
+	if actions.ExperimentalScannerActions.ScanOCIImage != "" {
+		r.Infof("Scanning image %s\n", actions.ExperimentalScannerActions.ScanOCIImage)
+		pkgs, err := scanImage(r, actions.ExperimentalScannerActions.ScanOCIImage)
+		if err != nil {
+			return models.VulnerabilityResults{}, err
 		}
-	}
 
-	configManager := config.ConfigManager{
-		DefaultConfig: config.Config{},
-		ConfigMap:     make(map[string]config.Config),
+		scannedPackages = append(scannedPackages, pkgs...)
 	}
 
-	//nolint:prealloc // Not sure how many there will be in advance.
-	var scannedPackages []scannedPackage
+	images := slices.Clone(actions.DockerContainerNames)
+	manifestPaths := slices.Clone(actions.KubernetesManifestPaths)
+	var composePaths []string
+	for _, lockfileElem := range actions.LockfilePaths {
+		switch parseAs, lockfilePath := parseLockfilePath(lockfileElem); parseAs {
+		case "k8s-manifest":
+			manifestPaths = append(manifestPaths, lockfilePath)
+		case "docker-compose":
+			composePaths = append(composePaths, lockfilePath)
+		}
+	}
 
-	if actions.ConfigOverridePath != "" {
-		err := configManager.UseOverride(actions.ConfigOverridePath)
+	for _, manifestPath := range manifestPaths {
+		manifestImages, err := kubernetesManifestImages(manifestPath)
 		if err != nil {
-			r.Errorf("Failed to read config file: %s\n", err)
+			r.Errorf("Failed to read Kubernetes manifest: %s\n", err)
 			return models.VulnerabilityResults{}, err
 		}
+
+		r.Infof(
+			"Found %d container %s in Kubernetes manifest %s\n",
+			len(manifestImages),
+			output.Form(len(manifestImages), "image", "images"),
+			manifestPath,
+		)
+		images = append(images, manifestImages...)
 	}
 
-	if actions.ExperimentalScannerActions.ScanOCIImage != "" {
-		r.Infof("Scanning image %s\n", actions.ExperimentalScannerActions.ScanOCIImage)
-		pkgs, err := scanImage(r, actions.ExperimentalScannerActions.ScanOCIImage)
+	for _, composePath := range composePaths {
+		serviceImages, err := composeImages(r, composePath)
 		if err != nil {
+			r.Errorf("Failed to read Docker Compose file: %s\n", err)
 			return models.VulnerabilityResults{}, err
 		}
 
-		scannedPackages = append(scannedPackages, pkgs...)
+		r.Infof(
+			"Found %d container %s in Docker Compose file %s\n",
+			len(serviceImages),
+			output.Form(len(serviceImages), "image", "images"),
+			composePath,
+		)
+		images = append(images, serviceImages...)
 	}
 
 	// TODO: Deprecated
-	for _, container := range actions.DockerContainerNames {
+	for _, container := range uniqueImages(images) {
 		pkgs, _ := scanDebianDocker(r, container)
 		scannedPackages = append(scannedPackages, pkgs...)
 	}
 
+	scanned := scannedFiles{}
+	failures := &parseFailures{allowed: actions.AllowParseErrors}
+	lockfileTasks := make([]scanTask, 0, len(actions.LockfilePaths))
 	for _, lockfileElem := range actions.LockfilePaths {
 		parseAs, lockfilePath := parseLockfilePath(lockfileElem)
+		if slices.Contains(imageReferenceParsers, parseAs) {
+			// the images referenced by the file have already been scanned
+			continue
+		}
 		lockfilePath, err := filepath.Abs(lockfilePath)
 		if err != nil {
 			r.Errorf("Failed to resolved path with error %s\n", err)
 			return models.VulnerabilityResults{}, err
 		}
-		pkgs, err := scanLockfile(r, lockfilePath, parseAs)
-		if err != nil {
-			return models.VulnerabilityResults{}, err
+		if !scanned.markScanned(lockfilePath, parseAs) {
+			lockfileTasks = append(lockfileTasks, skippedFileTask(lockfilePath))
+			continue
 		}
-		scannedPackages = append(scannedPackages, pkgs...)
+		lockfileTasks = append(lockfileTasks, func(r reporter.Reporter) ([]scannedPackage, error) {
+			record := actions.Profile.trackExtraction(lockfilePath)
+			pkgs, err := scanLockfile(r, lockfilePath, parseAs)
+			record(pkgs)
+			if err != nil && failures.allowed {
+				return pkgs, failures.report(r, lockfilePath, err)
+			}
+
+			return pkgs, err
+		})
+	}
+
+	for _, result := range runScanTasks(actions.Parallelism, lockfileTasks) {
+		result.replay(r)
+		if result.err != nil && !failures.record(result.err) {
+			return models.VulnerabilityResults{}, result.err
+		}
+		scannedPackages = append(scannedPackages, result.packages...)
 	}
 
 	for _, sbomElem := range actions.SBOMPaths {
@@ -802,53 +1391,249 @@ func DoScan(actions ScannerActions, r reporter.Reporter) (models.VulnerabilityRe
 		if err != nil {
 			return models.VulnerabilityResults{}, fmt.Errorf("failed to resolved path with error %w", err)
 		}
+		record := actions.Profile.trackExtraction(sbomElem)
 		pkgs, err := scanSBOMFile(r, sbomElem, false)
 		if err != nil {
 			return models.VulnerabilityResults{}, err
 		}
+		record(pkgs)
 		scannedPackages = append(scannedPackages, pkgs...)
 	}
 
 	for _, commit := range actions.GitCommits {
-		scannedPackages = append(scannedPackages, createCommitQueryPackage(commit, "HASH"))
+		pkg, err := createHashQueryPackage(commit)
+		if err != nil {
+			return models.VulnerabilityResults{}, err
+		}
+		scannedPackages = append(scannedPackages, pkg)
+	}
+
+	for _, purl := range actions.PURLs {
+		pkg, err := createPURLQueryPackage(purl)
+		if err != nil {
+			return models.VulnerabilityResults{}, err
+		}
+		scannedPackages = append(scannedPackages, pkg)
 	}
 
 	for _, dir := range actions.DirectoryPaths {
+		exclude := append(slices.Clone(actions.ExcludePatterns), configManager.Get(r, dir).ExcludePaths...)
+
+		// archives of source code are extracted so that they can be scanned like a directory
+		if format := detectArchiveFormat(dir); format != "" {
+			pkgs, err := scanArchive(r, dir, format, actions, exclude, scanned, failures)
+			if err != nil {
+				return models.VulnerabilityResults{}, err
+			}
+			scannedPackages = append(scannedPackages, resolveLockfileConflicts(r, pkgs, actions.PreferLockfiles)...)
+
+			continue
+		}
+
 		r.Infof("Scanning dir %s\n", dir)
-		pkgs, err := scanDir(r, dir, actions.SkipGit, actions.Recursive, !actions.NoIgnore, actions.CompareOffline)
+		pkgs, err := scanDir(r, dir, newScanDirOptions(actions, exclude), scanned, failures)
 		if err != nil {
 			return models.VulnerabilityResults{}, err
 		}
-		scannedPackages = append(scannedPackages, pkgs...)
+		scannedPackages = append(scannedPackages, resolveLockfileConflicts(r, pkgs, actions.PreferLockfiles)...)
+	}
+
+	return scanCollectedPackages(r, actions, &configManager, scannedPackages, failures)
+}
+
+// normalizeActions applies the actions that are implied by others, returning an error
+// if the actions conflict with one another
+func normalizeActions(actions ScannerActions) (ScannerActions, error) {
+	if actions.CompareOffline {
+		actions.CompareLocally = true
+	}
+
+	if actions.OfflineVulnerabilitiesPath != "" {
+		actions.CompareLocally = true
+		actions.CompareOffline = true
+	}
+
+	if actions.CompareOffline && actions.ResolveConstraints {
+		return actions, errors.New("cannot resolve version constraints offline")
+	}
+
+	if actions.CompareLocally {
+		actions.SkipGit = true
+
+		if actions.checksLicenseViolations() || actions.ScanLicensesSummary {
+			return actions, errors.New("cannot retrieve licenses locally")
+		}
+	}
+
+	return actions, nil
+}
+
+// scanCollectedPackages filters the packages that have been collected from each of the sources
+// being scanned, and then checks them for vulnerabilities according to the actions
+func scanCollectedPackages(r reporter.Reporter, actions ScannerActions, configManager *config.ConfigManager, scannedPackages []scannedPackage, failures *parseFailures) (models.VulnerabilityResults, error) {
+	if missing := missingEcosystems(scannedPackages, actions.RequireEcosystems); len(missing) > 0 {
+		return models.VulnerabilityResults{}, fmt.Errorf(
+			"%w: found no packages in %s",
+			ErrMissingEcosystems,
+			strings.Join(missing, ", "),
+		)
 	}
 
 	if len(scannedPackages) == 0 {
 		return models.VulnerabilityResults{}, NoPackagesFoundErr
 	}
 
+	if actions.ScanRuntimes {
+		separateGoRuntime(scannedPackages)
+		warnEndOfLifeRuntimes(r, scannedPackages, time.Now())
+	}
+
+	if len(scannedPackages) < actions.MinPackages {
+		return models.VulnerabilityResults{}, fmt.Errorf(
+			"%w: found %d %s, but expected at least %d",
+			ErrTooFewPackages,
+			len(scannedPackages),
+			output.Form(len(scannedPackages), "package", "packages"),
+			actions.MinPackages,
+		)
+	}
+
+	if actions.ResolveConstraints {
+		resolveConstraints(r, scannedPackages, packagist.NewClient())
+	} else {
+		reportUnresolvedConstraints(r, scannedPackages)
+	}
+
 	filteredScannedPackages := filterUnscannablePackages(scannedPackages)
 
 	if len(filteredScannedPackages) != len(scannedPackages) {
 		r.Infof("Filtered %d local package/s from the scan.\n", len(scannedPackages)-len(filteredScannedPackages))
 	}
 
-	overrideGoVersion(r, filteredScannedPackages, &configManager)
+	if actions.ExcludeDevDependencies {
+		withoutDev := filterDevDependencies(filteredScannedPackages)
+		skipped := len(filteredScannedPackages) - len(withoutDev)
+		r.Infof(
+			"Skipped %d dev %s from the scan.\n",
+			skipped,
+			output.Form(skipped, "dependency", "dependencies"),
+		)
+		filteredScannedPackages = withoutDev
+	}
+
+	if actions.StrictEcosystems != "" {
+		reportUnsupportedEcosystems(r, filteredScannedPackages, actions.StrictEcosystems == StrictEcosystemsError)
+	}
+
+	if actions.CheckDrift || actions.FailOnDrift {
+		reportDrift(r, scannedPackages, actions.FailOnDrift)
+	}
+
+	overrideGoVersion(r, filteredScannedPackages, configManager)
+
+	var graphs map[string]map[string][]string
+	if actions.IncludeGraph {
+		graphs = dependencyGraphs(filteredScannedPackages)
+	}
+
+	var results models.VulnerabilityResults
+	var err error
+
+	streamer, canStream := r.(reporter.StreamingReporter)
+	// The exported bundle needs the vulnerabilities of every source at once
+	if canStream && actions.ExportOfflineVulnerabilitiesPath == "" {
+		results, err = scanPackagesStreamed(r, streamer, actions, configManager, filteredScannedPackages, graphs)
+	} else {
+		// every package is queried together, so there are no partial results if the OSV API becomes unreachable
+		results, err = scanPackages(r, actions, configManager, filteredScannedPackages, graphs)
+	}
 
-	vulnsResp, err := makeRequest(r, filteredScannedPackages, actions.CompareLocally, actions.CompareOffline, actions.LocalDBPath)
 	if err != nil {
+		// partial results are returned if the OSV API became unreachable part way through a streamed scan
+		var unreachable *osv.UnreachableError
+		if errors.As(err, &unreachable) {
+			return results, err
+		}
+
 		return models.VulnerabilityResults{}, err
 	}
 
+	results.PackageCounts = countPackages(filteredScannedPackages)
+
+	if actions.ReportDuplicateVersions {
+		reportDuplicateVersions(r, filteredScannedPackages, results)
+	}
+
+	// TODO: in the next breaking release of osv-scanner, consider
+	// returning a ScanError instead of an error.
+	if shouldFail(results, actions) {
+		if len(actions.SeverityExitCodes) > 0 {
+			return results, failures.wrap(r, &ResultError{ExitCode: severityExitCode(results, actions)})
+		}
+
+		return results, failures.wrap(r, VulnerabilitiesFoundErr)
+	}
+
+	return results, failures.wrap(r, nil)
+}
+
+// shouldFail returns true if the results include vulnerabilities that fail the scan according to
+// actions.FailOn, or license violations
+func shouldFail(results models.VulnerabilityResults, actions ScannerActions) bool {
+	var vuln bool
+	onlyUncalledVuln := true
+	var licenseViolation bool
+	for _, vf := range results.Flatten() {
+		if vf.Vulnerability.ID != "" && !vf.GroupInfo.Filtered {
+			vuln = true
+			if vf.GroupInfo.IsCalled() || actions.FailOn == FailOnAny {
+				onlyUncalledVuln = false
+			}
+		}
+		if len(vf.LicenseViolations) > 0 {
+			licenseViolation = true
+		}
+	}
+	onlyUncalledVuln = onlyUncalledVuln && vuln
+	licenseViolation = licenseViolation && actions.checksLicenseViolations()
+
+	return (vuln && !onlyUncalledVuln) || licenseViolation
+}
+
+// scanPackages checks the given packages for vulnerabilities (and licenses), and then filters
+// the results according to the config and actions, including the dependency graph of each source that has one
+func scanPackages(r reporter.Reporter, actions ScannerActions, configManager *config.ConfigManager, packages []scannedPackage, graphs map[string]map[string][]string) (models.VulnerabilityResults, error) {
+	recordQuery := actions.Profile.trackQuery()
+	vulnsResp, databases, err := makeRequest(r, packages, actions.CompareLocally, actions.CompareOffline, actions.LocalDBPath, actions.LocalDBEcosystems, actions.LocalDBTimeout, actions.OfflineVulnerabilitiesPath)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+	recordQuery()
+
 	var licensesResp [][]models.License
-	if len(actions.ScanLicensesAllowlist) > 0 || actions.ScanLicensesSummary {
-		licensesResp, err = makeLicensesRequests(filteredScannedPackages)
+	if actions.checksLicenseViolations() || actions.ScanLicensesSummary {
+		recordLicenses := actions.Profile.trackLicenses()
+		licensesResp, err = makeLicensesRequests(packages)
+		recordLicenses()
 		if err != nil {
 			return models.VulnerabilityResults{}, err
 		}
 	}
-	results := buildVulnerabilityResults(r, filteredScannedPackages, vulnsResp, licensesResp, actions)
+	results := buildVulnerabilityResults(r, packages, vulnsResp, licensesResp, actions)
+	results.Databases = databases
+
+	for i := range results.Results {
+		results.Results[i].DependencyGraph = graphs[results.Results[i].Source.Path]
+	}
+
+	// The bundle is written before filtering so that the same config can be applied when scanning with it
+	if actions.ExportOfflineVulnerabilitiesPath != "" {
+		if err := exportOfflineVulnerabilities(r, &results, actions.ExportOfflineVulnerabilitiesPath); err != nil {
+			return models.VulnerabilityResults{}, err
+		}
+	}
 
-	filtered := filterResults(r, &results, &configManager, actions.ShowAllPackages)
+	filtered := filterResults(r, &results, configManager, collectInlineIgnores(packages), actions.ShowAllPackages, actions.ShowFiltered)
 	if filtered > 0 {
 		r.Infof(
 			"Filtered %d %s from output\n",
@@ -857,38 +1642,125 @@ func DoScan(actions ScannerActions, r reporter.Reporter) (models.VulnerabilityRe
 		)
 	}
 
-	if len(results.Results) > 0 {
-		// Determine the correct error to return.
-		// TODO: in the next breaking release of osv-scanner, consider
-		// returning a ScanError instead of an error.
-		var vuln bool
-		onlyUncalledVuln := true
-		var licenseViolation bool
-		for _, vf := range results.Flatten() {
-			if vf.Vulnerability.ID != "" {
-				vuln = true
-				if vf.GroupInfo.IsCalled() {
-					onlyUncalledVuln = false
-				}
+	if actions.StrictMatching {
+		pruned := filterUnaffected(&results, actions.ShowAllPackages)
+		if pruned > 0 {
+			r.Infof(
+				"Pruned %d %s that do not affect the installed version from output\n",
+				pruned,
+				output.Form(pruned, "vulnerability", "vulnerabilities"),
+			)
+		}
+	}
+
+	if actions.OnlyFixable {
+		unfixable := filterUnfixable(&results, actions.ShowAllPackages)
+		if unfixable > 0 {
+			r.Infof(
+				"Filtered %d %s without a fix available from output\n",
+				unfixable,
+				output.Form(unfixable, "vulnerability", "vulnerabilities"),
+			)
+		}
+	}
+
+	if !actions.ModifiedSince.IsZero() {
+		older := filterModifiedBefore(&results, actions.ModifiedSince, actions.ShowAllPackages)
+		if older > 0 {
+			r.Infof(
+				"Filtered %d %s not modified since %s from output\n",
+				older,
+				output.Form(older, "vulnerability", "vulnerabilities"),
+				actions.ModifiedSince.Format(time.DateOnly),
+			)
+		}
+	}
+
+	return results, nil
+}
+
+// scanPackagesStreamed scans the packages of each source separately, so that the results
+// of each source can be printed by the reporter as soon as they are available
+func scanPackagesStreamed(r reporter.Reporter, streamer reporter.StreamingReporter, actions ScannerActions, configManager *config.ConfigManager, packages []scannedPackage, graphs map[string]map[string][]string) (models.VulnerabilityResults, error) {
+	var sources []models.SourceInfo
+	packagesBySource := map[models.SourceInfo][]scannedPackage{}
+
+	for _, pkg := range packages {
+		if _, ok := packagesBySource[pkg.Source]; !ok {
+			sources = append(sources, pkg.Source)
+		}
+		packagesBySource[pkg.Source] = append(packagesBySource[pkg.Source], pkg)
+	}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{},
+	}
+
+	for i, source := range sources {
+		sourceResults, err := scanPackages(r, actions, configManager, packagesBySource[source], graphs)
+		if err != nil {
+			// the sources that were scanned before the OSV API became unreachable have already been printed,
+			// so their results are kept to be reported along with the error
+			var unreachable *osv.UnreachableError
+			if errors.As(err, &unreachable) && i > 0 {
+				r.Warnf(
+					"Only %d of %d %s were scanned before the OSV API became unreachable, so the results are incomplete\n",
+					i,
+					len(sources),
+					output.Form(len(sources), "source", "sources"),
+				)
+
+				return results, err
 			}
-			if len(vf.LicenseViolations) > 0 {
-				licenseViolation = true
+
+			return models.VulnerabilityResults{}, err
+		}
+
+		for _, pkgSrc := range sourceResults.Results {
+			if err := streamer.PrintSourceResult(pkgSrc); err != nil {
+				return models.VulnerabilityResults{}, fmt.Errorf("failed to write output: %w", err)
 			}
 		}
-		onlyUncalledVuln = onlyUncalledVuln && vuln
-		licenseViolation = licenseViolation && len(actions.ScanLicensesAllowlist) > 0
 
-		if (!vuln || onlyUncalledVuln) && !licenseViolation {
-			// There is no error.
-			return results, nil
-		} else {
-			return results, VulnerabilitiesFoundErr
+		results.Results = append(results.Results, sourceResults.Results...)
+		results.ExperimentalAnalysisConfig = sourceResults.ExperimentalAnalysisConfig
+		for _, db := range sourceResults.Databases {
+			if !slices.Contains(results.Databases, db) {
+				results.Databases = append(results.Databases, db)
+			}
 		}
 	}
 
 	return results, nil
 }
 
+// exportOfflineVulnerabilities writes all the vulnerabilities in the results to a bundle at the given path
+func exportOfflineVulnerabilities(r reporter.Reporter, results *models.VulnerabilityResults, path string) error {
+	// the same vulnerability is included once for each package that it affects,
+	// but only needs to be in the bundle once
+	var vulns []models.Vulnerability
+	seen := map[string]bool{}
+	for _, pkgSrc := range results.Results {
+		for _, pkg := range pkgSrc.Packages {
+			for _, vuln := range pkg.Vulnerabilities {
+				if seen[vuln.ID] {
+					continue
+				}
+				seen[vuln.ID] = true
+				vulns = append(vulns, vuln)
+			}
+		}
+	}
+
+	if err := local.WriteBundle(path, vulns); err != nil {
+		return fmt.Errorf("failed to export offline vulnerabilities: %w", err)
+	}
+
+	r.Infof("Exported %d %s to %s\n", len(vulns), output.Form(len(vulns), "vulnerability", "vulnerabilities"), path)
+
+	return nil
+}
+
 // filterUnscannablePackages removes packages that don't have enough information to be scanned
 // e,g, local packages that specified by path
 func filterUnscannablePackages(packages []scannedPackage) []scannedPackage {
@@ -908,6 +1780,136 @@ func filterUnscannablePackages(packages []scannedPackage) []scannedPackage {
 	return out
 }
 
+// unsupportedEcosystems returns the number of packages in each ecosystem that is not supported by OSV,
+// as while these packages are still queried, they will never have any known vulnerabilities
+func unsupportedEcosystems(packages []scannedPackage) map[string]int {
+	counts := map[string]int{}
+	for _, p := range packages {
+		ecosystem := string(p.Ecosystem)
+		if p.PURL != "" {
+			pkg, err := models.PURLToPackage(p.PURL)
+			if err != nil {
+				continue
+			}
+			ecosystem = pkg.Ecosystem
+		}
+
+		// Packages identified by a commit do not have an ecosystem
+		if ecosystem == "" {
+			continue
+		}
+
+		if slices.Contains(models.Ecosystems, models.PackageInfo{Ecosystem: ecosystem}.BaseEcosystem()) {
+			continue
+		}
+
+		counts[ecosystem]++
+	}
+
+	return counts
+}
+
+// missingEcosystems returns the required ecosystems that none of the packages are in,
+// comparing them to the base ecosystem of each package regardless of case
+func missingEcosystems(packages []scannedPackage, required []string) []string {
+	if len(required) == 0 {
+		return nil
+	}
+
+	counts := countPackages(packages).Ecosystems
+
+	var missing []string
+	for _, ecosystem := range required {
+		found := false
+		for scanned := range counts {
+			if strings.EqualFold(ecosystem, scanned) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, ecosystem)
+		}
+	}
+
+	return missing
+}
+
+// countPackages tallies the packages that are being scanned by their base ecosystem
+func countPackages(packages []scannedPackage) *models.PackageCounts {
+	counts := &models.PackageCounts{
+		Total:      len(packages),
+		Ecosystems: map[string]int{},
+	}
+
+	for _, p := range packages {
+		ecosystem := string(p.Ecosystem)
+		if p.PURL != "" {
+			pkg, err := models.PURLToPackage(p.PURL)
+			if err != nil {
+				continue
+			}
+			ecosystem = pkg.Ecosystem
+		}
+
+		// Packages identified by a commit do not have an ecosystem
+		if ecosystem == "" {
+			continue
+		}
+
+		counts.Ecosystems[string(models.PackageInfo{Ecosystem: ecosystem}.BaseEcosystem())]++
+	}
+
+	return counts
+}
+
+// reportUnsupportedEcosystems lists the ecosystems of the packages that are not supported by OSV,
+// so that it is clear which dependencies are not actually being checked for vulnerabilities
+func reportUnsupportedEcosystems(r reporter.Reporter, packages []scannedPackage, asError bool) {
+	counts := unsupportedEcosystems(packages)
+	if len(counts) == 0 {
+		return
+	}
+
+	ecosystems := maps.Keys(counts)
+	slices.Sort(ecosystems)
+
+	total := 0
+	details := make([]string, 0, len(ecosystems))
+	for _, ecosystem := range ecosystems {
+		total += counts[ecosystem]
+		details = append(details, fmt.Sprintf("%s (%d %s)", ecosystem, counts[ecosystem], output.Form(counts[ecosystem], "package", "packages")))
+	}
+
+	report := r.Warnf
+	if asError {
+		report = r.Errorf
+	}
+
+	report(
+		"Found %d %s in %s not supported by OSV, which will not be checked for vulnerabilities: %s\n",
+		total,
+		output.Form(total, "package", "packages"),
+		output.Form(len(ecosystems), "an ecosystem", "ecosystems"),
+		strings.Join(details, ", "),
+	)
+}
+
+// filterDevDependencies removes packages that are only in the development dependency group of their ecosystem
+func filterDevDependencies(packages []scannedPackage) []scannedPackage {
+	out := make([]scannedPackage, 0, len(packages))
+	for _, p := range packages {
+		if p.Ecosystem.IsDevGroup(p.DepGroups) {
+			continue
+		}
+		out = append(out, p)
+	}
+
+	return out
+}
+
 // patchPackageForRequest modifies packages before they are sent to osv.dev to
 // account for edge cases.
 func patchPackageForRequest(pkg scannedPackage) scannedPackage {
@@ -931,6 +1933,12 @@ func patchPackageForRequest(pkg scannedPackage) scannedPackage {
 		}
 	}
 
+	// Runtimes are usually only pinned to a major or minor version, for the latest
+	// release of it to be installed, so the latest release is assumed in the same way
+	if pkg.Source.Type == runtimeSourceType && pkg.Ecosystem != lockfile.GoEcosystem {
+		pkg.Version = latestRelease(pkg.Version)
+	}
+
 	return pkg
 }
 
@@ -939,7 +1947,10 @@ func makeRequest(
 	packages []scannedPackage,
 	compareLocally bool,
 	compareOffline bool,
-	localDBPath string) (*osv.HydratedBatchedResponse, error) {
+	localDBPath string,
+	localDBEcosystems []string,
+	localDBTimeout time.Duration,
+	offlineVulnerabilitiesPath string) (*osv.HydratedBatchedResponse, []models.LocalDatabase, error) {
 	// Make OSV queries from the packages.
 	var query osv.BatchedQuery
 	for _, p := range packages {
@@ -957,17 +1968,26 @@ func makeRequest(
 		case p.PURL != "":
 			query.Queries = append(query.Queries, osv.MakePURLRequest(p.PURL))
 		default:
-			return nil, fmt.Errorf("package %v does not have a commit, PURL or ecosystem/name/version identifier", p)
+			return nil, nil, fmt.Errorf("package %v does not have a commit, PURL or ecosystem/name/version identifier", p)
+		}
+	}
+
+	if offlineVulnerabilitiesPath != "" {
+		hydratedResp, databases, err := local.MakeBundleRequest(r, query, offlineVulnerabilitiesPath)
+		if err != nil {
+			return &osv.HydratedBatchedResponse{}, nil, fmt.Errorf("local comparison failed %w", err)
 		}
+
+		return hydratedResp, databases, nil
 	}
 
 	if compareLocally {
-		hydratedResp, err := local.MakeRequest(r, query, compareOffline, localDBPath)
+		hydratedResp, databases, err := local.MakeRequest(r, query, compareOffline, localDBPath, localDBEcosystems, localDBTimeout)
 		if err != nil {
-			return &osv.HydratedBatchedResponse{}, fmt.Errorf("local comparison failed %w", err)
+			return &osv.HydratedBatchedResponse{}, nil, fmt.Errorf("local comparison failed %w", err)
 		}
 
-		return hydratedResp, nil
+		return hydratedResp, databases, nil
 	}
 
 	if osv.RequestUserAgent == "" {
@@ -976,15 +1996,15 @@ func makeRequest(
 
 	resp, err := osv.MakeRequest(query)
 	if err != nil {
-		return &osv.HydratedBatchedResponse{}, fmt.Errorf("%w: osv.dev query failed: %w", ErrAPIFailed, err)
+		return &osv.HydratedBatchedResponse{}, nil, fmt.Errorf("%w: osv.dev query failed: %w", ErrAPIFailed, err)
 	}
 
 	hydratedResp, err := osv.Hydrate(resp)
 	if err != nil {
-		return &osv.HydratedBatchedResponse{}, fmt.Errorf("%w: failed to hydrate OSV response: %w", ErrAPIFailed, err)
+		return &osv.HydratedBatchedResponse{}, nil, fmt.Errorf("%w: failed to hydrate OSV response: %w", ErrAPIFailed, err)
 	}
 
-	return hydratedResp, nil
+	return hydratedResp, nil, nil
 }
 
 func makeLicensesRequests(packages []scannedPackage) ([][]models.License, error) {
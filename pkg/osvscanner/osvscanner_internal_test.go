@@ -1,13 +1,20 @@
 package osvscanner
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-git/go-git/v5"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/osv-scanner/internal/testutility"
 	"github.com/google/osv-scanner/pkg/config"
+	"github.com/google/osv-scanner/pkg/lockfile"
 	"github.com/google/osv-scanner/pkg/models"
 	"github.com/google/osv-scanner/pkg/reporter"
 )
@@ -49,7 +56,7 @@ func Test_filterResults(t *testing.T) {
 			}
 
 			got := testutility.LoadJSONFixture[models.VulnerabilityResults](t, filepath.Join(tt.path, "input.json"))
-			filtered := filterResults(r, &got, &configManager, false)
+			filtered := filterResults(r, &got, &configManager, nil, false, false)
 
 			testutility.NewSnapshot().MatchJSON(t, got)
 
@@ -60,12 +67,565 @@ func Test_filterResults(t *testing.T) {
 	}
 }
 
+func Test_filterResults_InlineIgnores(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{
+			Name:      "mine1",
+			Version:   "1.0.0",
+			Ecosystem: "npm",
+			Source:    models.SourceInfo{Path: "/path/to/requirements.txt", Type: "lockfile"},
+			Ignores:   []lockfile.IgnoreComment{{ID: "GHSA-1", Reason: "not exploitable"}},
+		},
+		{
+			Name:      "mine2",
+			Version:   "1.0.0",
+			Ecosystem: "npm",
+			Source:    models.SourceInfo{Path: "/path/to/requirements.txt", Type: "lockfile"},
+		},
+	}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/requirements.txt", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GHSA-1"}, {ID: "CVE-1"}, {ID: "OSV-1"}},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"CVE-1", "GHSA-1"}, Aliases: []string{"CVE-1", "GHSA-1"}},
+							{IDs: []string{"OSV-1"}, Aliases: []string{"OSV-1"}},
+						},
+					},
+					{
+						// the ignore comment is only next to mine1
+						Package:         models.PackageInfo{Name: "mine2", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GHSA-1"}},
+						Groups:          []models.GroupInfo{{IDs: []string{"GHSA-1"}, Aliases: []string{"GHSA-1"}}},
+					},
+				},
+			},
+		},
+	}
+
+	configManager := config.ConfigManager{
+		DefaultConfig: config.Config{},
+		ConfigMap:     make(map[string]config.Config),
+	}
+
+	filtered := filterResults(&reporter.VoidReporter{}, &results, &configManager, collectInlineIgnores(packages), false, false)
+
+	if filtered != 2 {
+		t.Errorf("filterResults() = %v, want %v", filtered, 2)
+	}
+
+	var got []string
+	for _, pkg := range results.Results[0].Packages {
+		for _, vuln := range pkg.Vulnerabilities {
+			got = append(got, pkg.Package.Name+"/"+vuln.ID)
+		}
+	}
+
+	want := []string{"mine1/OSV-1", "mine2/GHSA-1"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("filterResults() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_filterResults_ShowFiltered(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{
+			Name:      "mine1",
+			Version:   "1.0.0",
+			Ecosystem: "npm",
+			Source:    models.SourceInfo{Path: "/path/to/requirements.txt", Type: "lockfile"},
+			Ignores:   []lockfile.IgnoreComment{{ID: "GHSA-1", Reason: "not exploitable"}},
+		},
+	}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/requirements.txt", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GHSA-1"}, {ID: "CVE-1"}, {ID: "OSV-1"}},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"CVE-1", "GHSA-1"}, Aliases: []string{"CVE-1", "GHSA-1"}},
+							{IDs: []string{"OSV-1"}, Aliases: []string{"OSV-1"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	configManager := config.ConfigManager{
+		DefaultConfig: config.Config{},
+		ConfigMap:     make(map[string]config.Config),
+	}
+
+	filtered := filterResults(&reporter.VoidReporter{}, &results, &configManager, collectInlineIgnores(packages), false, true)
+
+	if filtered != 2 {
+		t.Errorf("filterResults() = %v, want %v", filtered, 2)
+	}
+
+	want := models.PackageVulns{
+		Package:         models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+		Vulnerabilities: []models.Vulnerability{{ID: "GHSA-1"}, {ID: "CVE-1"}, {ID: "OSV-1"}},
+		Groups: []models.GroupInfo{
+			{
+				IDs:          []string{"CVE-1", "GHSA-1"},
+				Aliases:      []string{"CVE-1", "GHSA-1"},
+				Filtered:     true,
+				FilterReason: "not exploitable",
+				FilterSource: models.InlineIgnoreFilterSource,
+			},
+			{IDs: []string{"OSV-1"}, Aliases: []string{"OSV-1"}},
+		},
+	}
+
+	if diff := cmp.Diff(want, results.Results[0].Packages[0]); diff != "" {
+		t.Errorf("filterResults() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_filterUnfixable(t *testing.T) {
+	t.Parallel()
+
+	fixed := func(id string, pkg string) models.Vulnerability {
+		return models.Vulnerability{
+			ID: id,
+			Affected: []models.Affected{{
+				Package: models.Package{Ecosystem: "npm", Name: pkg},
+				Ranges: []models.Range{{
+					Type:   models.RangeSemVer,
+					Events: []models.Event{{Introduced: "0"}, {Fixed: "2.0.0"}},
+				}},
+			}},
+		}
+	}
+	unfixed := func(id string, pkg string) models.Vulnerability {
+		return models.Vulnerability{
+			ID: id,
+			Affected: []models.Affected{{
+				Package: models.Package{Ecosystem: "npm", Name: pkg},
+				Ranges: []models.Range{{
+					Type:   models.RangeSemVer,
+					Events: []models.Event{{Introduced: "0"}},
+				}},
+			}},
+		}
+	}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{
+							fixed("GHSA-1", "mine1"),
+							unfixed("OSV-1", "mine1"),
+							unfixed("OSV-2", "mine1"),
+							// fixed, but not for this package
+							fixed("OSV-3", "mine2"),
+						},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"GHSA-1", "OSV-1"}},
+							{IDs: []string{"OSV-2"}},
+							{IDs: []string{"OSV-3"}},
+						},
+					},
+				},
+			},
+			{
+				Source: models.SourceInfo{Path: "/path/to/other/package-lock.json", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "mine2", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{unfixed("OSV-4", "mine2")},
+						Groups:          []models.GroupInfo{{IDs: []string{"OSV-4"}}},
+					},
+				},
+			},
+		},
+	}
+
+	removed := filterUnfixable(&results, false)
+
+	if removed != 3 {
+		t.Errorf("filterUnfixable() = %v, want %v", removed, 3)
+	}
+
+	want := []models.PackageSource{
+		{
+			Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"},
+			Packages: []models.PackageVulns{
+				{
+					Package:         models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+					Vulnerabilities: []models.Vulnerability{fixed("GHSA-1", "mine1"), unfixed("OSV-1", "mine1")},
+					Groups:          []models.GroupInfo{{IDs: []string{"GHSA-1", "OSV-1"}}},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, results.Results); diff != "" {
+		t.Errorf("filterUnfixable() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_filterModifiedBefore(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	modified := func(id string, year int, month time.Month, day int) models.Vulnerability {
+		return models.Vulnerability{ID: id, Modified: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+	}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{
+							modified("GHSA-1", 2024, time.March, 1),
+							modified("OSV-1", 2023, time.January, 1),
+							modified("OSV-2", 2024, time.February, 28),
+						},
+						Groups: []models.GroupInfo{{IDs: []string{"GHSA-1", "OSV-1"}}, {IDs: []string{"OSV-2"}}},
+					},
+					{
+						Package:         models.PackageInfo{Name: "mine2", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{modified("OSV-3", 2020, time.June, 1)},
+						Groups:          []models.GroupInfo{{IDs: []string{"OSV-3"}}},
+					},
+				},
+			},
+		},
+	}
+
+	removed := filterModifiedBefore(&results, since, false)
+
+	if removed != 2 {
+		t.Errorf("filterModifiedBefore() = %v, want %v", removed, 2)
+	}
+
+	want := []models.PackageSource{
+		{
+			Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+					Vulnerabilities: []models.Vulnerability{
+						modified("GHSA-1", 2024, time.March, 1),
+						modified("OSV-1", 2023, time.January, 1),
+					},
+					Groups: []models.GroupInfo{{IDs: []string{"GHSA-1", "OSV-1"}}},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, results.Results); diff != "" {
+		t.Errorf("filterModifiedBefore() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_filterDevDependencies(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{Name: "mine1", Version: "1.0.0", Ecosystem: lockfile.NpmEcosystem},
+		{Name: "mine2", Version: "1.0.0", Ecosystem: lockfile.NpmEcosystem, DepGroups: []string{"dev"}},
+		{Name: "mine3", Version: "1.0.0", Ecosystem: lockfile.NpmEcosystem, DepGroups: []string{"dev", "optional"}},
+		{Name: "mine4", Version: "1.0.0", Ecosystem: lockfile.PipEcosystem, DepGroups: []string{"dev"}},
+		{Name: "mine5", Version: "1.0.0", Ecosystem: lockfile.MavenEcosystem, DepGroups: []string{"test"}},
+		{Name: "mine6", Version: "1.0.0", Ecosystem: lockfile.MavenEcosystem, DepGroups: []string{"compile"}},
+		// dev dependencies cannot be determined for Go
+		{Name: "mine7", Version: "1.0.0", Ecosystem: lockfile.GoEcosystem, DepGroups: []string{"dev"}},
+		{Commit: "abc123"},
+	}
+
+	got := filterDevDependencies(packages)
+
+	want := []scannedPackage{
+		{Name: "mine1", Version: "1.0.0", Ecosystem: lockfile.NpmEcosystem},
+		{Name: "mine6", Version: "1.0.0", Ecosystem: lockfile.MavenEcosystem, DepGroups: []string{"compile"}},
+		{Name: "mine7", Version: "1.0.0", Ecosystem: lockfile.GoEcosystem, DepGroups: []string{"dev"}},
+		{Commit: "abc123"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("filterDevDependencies() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_unsupportedEcosystems(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{Name: "mine1", Version: "1.0.0", Ecosystem: lockfile.NpmEcosystem},
+		{Name: "mine2", Version: "1.0.0", Ecosystem: "Debian:11"},
+		{Name: "mine3", Version: "1.0.0", Ecosystem: "Unknown"},
+		{Name: "mine4", Version: "1.0.0", Ecosystem: "Unknown"},
+		{Name: "mine5", Version: "1.0.0", Ecosystem: "Other:1"},
+		{PURL: "pkg:npm/mine6@1.0.0"},
+		{PURL: "pkg:rpm/redhat/mine7@1.0.0"},
+		{Commit: "abc123"},
+	}
+
+	got := unsupportedEcosystems(packages)
+
+	want := map[string]int{
+		"Unknown":    2,
+		"Other:1":    1,
+		"rpm:redhat": 1,
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unsupportedEcosystems() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_countPackages(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{Name: "mine1", Version: "1.0.0", Ecosystem: lockfile.NpmEcosystem},
+		{Name: "mine2", Version: "1.0.0", Ecosystem: "Debian:11"},
+		{Name: "mine3", Version: "1.0.0", Ecosystem: "Debian:12"},
+		{PURL: "pkg:npm/mine4@1.0.0"},
+		{Commit: "abc123"},
+	}
+
+	got := countPackages(packages)
+
+	want := &models.PackageCounts{
+		Total: 5,
+		Ecosystems: map[string]int{
+			"npm":    2,
+			"Debian": 2,
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("countPackages() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_shouldFail(t *testing.T) {
+	t.Parallel()
+
+	resultsWith := func(analysis map[string]models.AnalysisInfo) models.VulnerabilityResults {
+		return models.VulnerabilityResults{
+			Results: []models.PackageSource{{
+				Packages: []models.PackageVulns{{
+					Package:         models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "Go"},
+					Vulnerabilities: []models.Vulnerability{{ID: "GO-2023-1234"}},
+					Groups: []models.GroupInfo{{
+						IDs:                  []string{"GO-2023-1234"},
+						ExperimentalAnalysis: analysis,
+					}},
+				}},
+			}},
+		}
+	}
+
+	called := resultsWith(map[string]models.AnalysisInfo{"GO-2023-1234": {Called: true}})
+	uncalled := resultsWith(map[string]models.AnalysisInfo{"GO-2023-1234": {Called: false}})
+	notAnalyzed := resultsWith(nil)
+
+	tests := []struct {
+		name    string
+		results models.VulnerabilityResults
+		failOn  string
+		want    bool
+	}{
+		{name: "no results", results: models.VulnerabilityResults{}, failOn: FailOnAny, want: false},
+		{name: "called, failing on called", results: called, failOn: FailOnCalled, want: true},
+		{name: "uncalled, failing on called", results: uncalled, failOn: FailOnCalled, want: false},
+		{name: "not analyzed, failing on called", results: notAnalyzed, failOn: FailOnCalled, want: true},
+		{name: "uncalled, failing on any", results: uncalled, failOn: FailOnAny, want: true},
+		{name: "uncalled, default", results: uncalled, failOn: "", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := shouldFail(tt.results, ScannerActions{FailOn: tt.failOn})
+			if got != tt.want {
+				t.Errorf("shouldFail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportUnsupportedEcosystems(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{Name: "mine1", Version: "1.0.0", Ecosystem: lockfile.NpmEcosystem},
+		{Name: "mine2", Version: "1.0.0", Ecosystem: "Unknown"},
+		{Name: "mine3", Version: "1.0.0", Ecosystem: "Unknown"},
+		{Name: "mine4", Version: "1.0.0", Ecosystem: "Other"},
+	}
+
+	want := "Found 3 packages in ecosystems not supported by OSV, which will not be checked for vulnerabilities: Other (1 package), Unknown (2 packages)\n"
+
+	for _, asError := range []bool{false, true} {
+		// warnings and errors are printed to different writers by the table reporter
+		out := &bytes.Buffer{}
+		r := reporter.NewTableReporter(out, out, reporter.InfoLevel, false, 0)
+
+		reportUnsupportedEcosystems(r, packages, asError)
+
+		if got := out.String(); got != want {
+			t.Errorf("reportUnsupportedEcosystems() printed %q, want %q", got, want)
+		}
+
+		if r.HasErrored() != asError {
+			t.Errorf("reportUnsupportedEcosystems() HasErrored() = %v, want %v", r.HasErrored(), asError)
+		}
+	}
+}
+
+func Test_createHashQueryPackage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		hash    string
+		want    scannedPackage
+		wantErr bool
+	}{
+		{
+			name: "sha-1",
+			hash: "b1e0b1b9a3fc3ba4d0e2a1ce0e3e0ef1c7d2c9f4",
+			want: scannedPackage{
+				Commit: "b1e0b1b9a3fc3ba4d0e2a1ce0e3e0ef1c7d2c9f4",
+				Source: models.SourceInfo{Path: "hash", Type: "hash"},
+			},
+		},
+		{
+			name: "sha-256",
+			hash: "5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef",
+			want: scannedPackage{
+				Commit: "5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef",
+				Source: models.SourceInfo{Path: "hash", Type: "hash"},
+			},
+		},
+		{
+			name: "uppercase",
+			hash: "B1E0B1B9A3FC3BA4D0E2A1CE0E3E0EF1C7D2C9F4",
+			want: scannedPackage{
+				Commit: "b1e0b1b9a3fc3ba4d0e2a1ce0e3e0ef1c7d2c9f4",
+				Source: models.SourceInfo{Path: "hash", Type: "hash"},
+			},
+		},
+		{
+			name:    "abbreviated",
+			hash:    "b1e0b1b",
+			wantErr: true,
+		},
+		{
+			name:    "not hexadecimal",
+			hash:    "g1e0b1b9a3fc3ba4d0e2a1ce0e3e0ef1c7d2c9f4",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			hash:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := createHashQueryPackage(tt.hash)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("createHashQueryPackage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("createHashQueryPackage() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_createPURLQueryPackage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		purl    string
+		want    scannedPackage
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			purl: "pkg:npm/foo@1.2.3",
+			want: scannedPackage{
+				PURL:   "pkg:npm/foo@1.2.3",
+				Source: models.SourceInfo{Path: "purl", Type: "purl"},
+			},
+		},
+		{
+			name: "with namespace",
+			purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.16.0",
+			want: scannedPackage{
+				PURL:   "pkg:maven/org.apache.logging.log4j/log4j-core@2.16.0",
+				Source: models.SourceInfo{Path: "purl", Type: "purl"},
+			},
+		},
+		{
+			name:    "without a version",
+			purl:    "pkg:npm/foo",
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			purl:    "npm/foo@1.2.3",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := createPURLQueryPackage(tt.purl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("createPURLQueryPackage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("createPURLQueryPackage() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func Test_scanGit(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		r       reporter.Reporter
-		repoDir string
+		r              reporter.Reporter
+		repoDir        string
+		skipSubmodules bool
 	}
 	tests := []struct {
 		name    string
@@ -90,25 +650,461 @@ func Test_scanGit(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Example Git repo with a submodule",
+			args: args{
+				r:       &reporter.VoidReporter{},
+				repoDir: "fixtures/example-git-submodule",
+			},
+			wantErr: false,
+			wantPkg: []scannedPackage{
+				{
+					Commit: "c6d2989766e78e2edfc0ece2c1e676dc79843d5a",
+					Source: models.SourceInfo{
+						Path: "fixtures/example-git-submodule",
+						Type: "git",
+					},
+				},
+				{
+					Commit: "1f0e0ad2bbd3cb2ecbd5cc8a2cdcd8d0e2b1a8f7",
+					Source: models.SourceInfo{
+						Path: "fixtures/example-git-submodule/vendor/lib",
+						Type: "git",
+					},
+				},
+			},
+		},
+		{
+			name: "Example Git repo with a submodule that is skipped",
+			args: args{
+				r:              &reporter.VoidReporter{},
+				repoDir:        "fixtures/example-git-submodule",
+				skipSubmodules: true,
+			},
+			wantErr: false,
+			wantPkg: []scannedPackage{
+				{
+					Commit: "c6d2989766e78e2edfc0ece2c1e676dc79843d5a",
+					Source: models.SourceInfo{
+						Path: "fixtures/example-git-submodule",
+						Type: "git",
+					},
+				},
+			},
+		},
 	}
 
-	err := os.Rename("fixtures/example-git/git-hidden", "fixtures/example-git/.git")
-	if err != nil {
-		t.Errorf("can't find git-hidden folder")
+	repos := []string{"fixtures/example-git", "fixtures/example-git-submodule"}
+
+	for _, repo := range repos {
+		err := os.Rename(filepath.Join(repo, "git-hidden"), filepath.Join(repo, ".git"))
+		if err != nil {
+			t.Errorf("can't find git-hidden folder in %s", repo)
+		}
 	}
 
 	for _, tt := range tests {
-		pkg, err := scanGit(tt.args.r, tt.args.repoDir)
+		pkg, err := scanGit(tt.args.r, tt.args.repoDir, tt.args.skipSubmodules)
 		if (err != nil) != tt.wantErr {
-			t.Errorf("scanGit() error = %v, wantErr %v", err, tt.wantErr)
+			t.Errorf("%s: scanGit() error = %v, wantErr %v", tt.name, err, tt.wantErr)
 		}
 		if diff := cmp.Diff(tt.wantPkg, pkg); diff != "" {
-			t.Errorf("scanGit() package = %v, wantPackage %v", pkg, tt.wantPkg)
+			t.Errorf("%s: scanGit() package = %v, wantPackage %v", tt.name, pkg, tt.wantPkg)
+		}
+	}
+
+	for _, repo := range repos {
+		err := os.Rename(filepath.Join(repo, ".git"), filepath.Join(repo, "git-hidden"))
+		if err != nil {
+			t.Errorf("can't find .git folder in %s", repo)
+		}
+	}
+}
+
+func Test_exportOfflineVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	shared := models.Vulnerability{ID: "GHSA-1"}
+	results := &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Packages: []models.PackageVulns{
+					{Package: models.PackageInfo{Name: "a"}, Vulnerabilities: []models.Vulnerability{shared}},
+					{Package: models.PackageInfo{Name: "b"}, Vulnerabilities: []models.Vulnerability{shared, {ID: "GHSA-2"}}},
+				},
+			},
+		},
+	}
+
+	stdout := &bytes.Buffer{}
+	r := reporter.NewTableReporter(stdout, stdout, reporter.InfoLevel, false, 0)
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+
+	if err := exportOfflineVulnerabilities(r, results, path); err != nil {
+		t.Fatalf("exportOfflineVulnerabilities() error = %v", err)
+	}
+
+	// vulnerabilities affecting more than one package are only counted once
+	if want := "Exported 2 vulnerabilities"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("Expected %q to be reported, but got:\n%s", want, stdout.String())
+	}
+}
+
+func Test_scannedFiles_markScanned(t *testing.T) {
+	t.Parallel()
+
+	scanned := scannedFiles{}
+
+	steps := []struct {
+		path    string
+		parseAs string
+		want    bool
+	}{
+		{path: "/a/requirements.txt", parseAs: "", want: true},
+		{path: "/a/requirements.txt", parseAs: "", want: false},
+		{path: "/b/deps.txt", parseAs: "requirements.txt", want: true},
+		{path: "/b/deps.txt", parseAs: "requirements.txt", want: false},
+		// an explicit parse-as takes precedence over detecting the parser
+		{path: "/b/deps.txt", parseAs: "", want: false},
+		{path: "/b/deps.txt", parseAs: "Pipfile.lock", want: true},
+	}
+
+	for _, step := range steps {
+		if got := scanned.markScanned(step.path, step.parseAs); got != step.want {
+			t.Errorf("markScanned(%q, %q) = %v, want %v", step.path, step.parseAs, got, step.want)
 		}
 	}
+}
+
+func Test_scanDir_SkipsScannedFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "requirements.txt")
+	if err := os.WriteFile(path, []byte("flask==1.0.0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
 
-	err = os.Rename("fixtures/example-git/.git", "fixtures/example-git/git-hidden")
+	scanned := scannedFiles{}
+	pkgs, err := scanDir(&reporter.VoidReporter{}, dir, scanDirOptions{SkipGit: true, SkipGitSubmodules: true, CompareOffline: true, Parallelism: 1}, scanned, &parseFailures{})
 	if err != nil {
-		t.Errorf("can't find .git folder")
+		t.Fatalf("scanDir() error = %v", err)
+	}
+	if len(pkgs) != 1 {
+		t.Errorf("scanDir() found %d packages, want 1", len(pkgs))
+	}
+
+	pkgs, err = scanDir(&reporter.VoidReporter{}, dir, scanDirOptions{SkipGit: true, SkipGitSubmodules: true, CompareOffline: true, Parallelism: 1}, scanned, &parseFailures{})
+	if err != nil {
+		t.Fatalf("scanDir() error = %v", err)
+	}
+	if len(pkgs) != 0 {
+		t.Errorf("scanDir() found %d packages when scanning again, want 0", len(pkgs))
+	}
+}
+
+func Test_scanDir_MaxDepth(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	// .gitignore files are only used within a repository
+	if _, err := git.PlainInit(dir, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, sub := range []string{"", "a", "a/b", "a/b/c", "ignored"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0750); err != nil {
+			t.Fatal(err)
+		}
+		name := strings.ReplaceAll("pkg-"+sub, "/", "-")
+		if err := os.WriteFile(filepath.Join(dir, sub, "requirements.txt"), []byte(name+"==1.0.0\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("ignored\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		recursive    bool
+		maxDepth     int
+		useGitIgnore bool
+		want         []string
+	}{
+		{
+			name:         "not recursive",
+			recursive:    false,
+			useGitIgnore: true,
+			want:         []string{"pkg-"},
+		},
+		{
+			name:         "no limit",
+			recursive:    true,
+			maxDepth:     0,
+			useGitIgnore: true,
+			want:         []string{"pkg-", "pkg-a", "pkg-a-b", "pkg-a-b-c"},
+		},
+		{
+			name:         "one directory deep",
+			recursive:    true,
+			maxDepth:     1,
+			useGitIgnore: true,
+			want:         []string{"pkg-", "pkg-a"},
+		},
+		{
+			name:         "two directories deep",
+			recursive:    true,
+			maxDepth:     2,
+			useGitIgnore: true,
+			want:         []string{"pkg-", "pkg-a", "pkg-a-b"},
+		},
+		{
+			// the depth limit still applies to directories that would otherwise be ignored
+			name:         "one directory deep without gitignore",
+			recursive:    true,
+			maxDepth:     1,
+			useGitIgnore: false,
+			want:         []string{"pkg-", "pkg-a", "pkg-ignored"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkgs, err := scanDir(&reporter.VoidReporter{}, dir, scanDirOptions{
+				SkipGit:           true,
+				SkipGitSubmodules: true,
+				Recursive:         tt.recursive,
+				MaxDepth:          tt.maxDepth,
+				UseGitIgnore:      tt.useGitIgnore,
+				CompareOffline:    true,
+				Parallelism:       1,
+			}, scannedFiles{}, &parseFailures{})
+			if err != nil {
+				t.Fatalf("scanDir() error = %v", err)
+			}
+
+			var got []string
+			for _, pkg := range pkgs {
+				got = append(got, pkg.Name)
+			}
+			slices.Sort(got)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("scanDir() packages (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_scanDir_Exclude(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, sub := range []string{"", "a", "a/testdata", "a/b", "node_modules", "ignored"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0750); err != nil {
+			t.Fatal(err)
+		}
+		name := strings.ReplaceAll("pkg-"+sub, "/", "-")
+		if err := os.WriteFile(filepath.Join(dir, sub, "requirements.txt"), []byte(name+"==1.0.0\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		exclude []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "no patterns",
+			exclude: nil,
+			want:    []string{"pkg-", "pkg-a", "pkg-a-b", "pkg-a-testdata", "pkg-ignored", "pkg-node-modules"},
+		},
+		{
+			name:    "nested directories",
+			exclude: []string{"**/testdata/**", "**/node_modules/**"},
+			want:    []string{"pkg-", "pkg-a", "pkg-a-b", "pkg-ignored"},
+		},
+		{
+			name:    "relative to the scanned directory",
+			exclude: []string{"ignored", "a/b"},
+			want:    []string{"pkg-", "pkg-a", "pkg-a-testdata", "pkg-node-modules"},
+		},
+		{
+			name:    "files",
+			exclude: []string{"**/requirements.txt"},
+			want:    nil,
+		},
+		{
+			name:    "invalid pattern",
+			exclude: []string{"[a-"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkgs, err := scanDir(&reporter.VoidReporter{}, dir, scanDirOptions{
+				SkipGit:           true,
+				SkipGitSubmodules: true,
+				Recursive:         true,
+				Exclude:           tt.exclude,
+				CompareOffline:    true,
+				Parallelism:       1,
+			}, scannedFiles{}, &parseFailures{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("scanDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []string
+			for _, pkg := range pkgs {
+				got = append(got, pkg.Name)
+			}
+			slices.Sort(got)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("scanDir() packages (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_hasPreferredLockfile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"composer.json", "composer.lock"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "manifest-only"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest-only", "composer.json"), []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: filepath.Join(dir, "composer.json"), want: true},
+		{path: filepath.Join(dir, "composer.lock"), want: false},
+		{path: filepath.Join(dir, "manifest-only", "composer.json"), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := hasPreferredLockfile(tt.path); got != tt.want {
+			t.Errorf("hasPreferredLockfile(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func Test_reportUnresolvedConstraints(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{Name: "mine1", Version: "1.0.0", Ecosystem: lockfile.ComposerEcosystem},
+		{Name: "mine2", Ecosystem: lockfile.ComposerEcosystem, VersionConstraint: "^1.0"},
+		{Name: "mine3", Ecosystem: lockfile.ComposerEcosystem, VersionConstraint: "~2.0"},
+	}
+
+	out := &bytes.Buffer{}
+	r := reporter.NewTableReporter(out, out, reporter.InfoLevel, false, 0)
+
+	reportUnresolvedConstraints(r, packages)
+
+	want := "Found 2 packages with a version constraint rather than an exact version, which will not be checked for vulnerabilities unless --resolve-constraints is set\n"
+	if got := out.String(); got != want {
+		t.Errorf("reportUnresolvedConstraints() printed %q, want %q", got, want)
+	}
+}
+
+func TestDoScan_MinPackages(t *testing.T) {
+	t.Parallel()
+
+	lockfilePath := filepath.Join(t.TempDir(), "requirements.txt")
+	if err := os.WriteFile(lockfilePath, []byte("flask==2.0.0\nrequests==2.31.0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := DoScan(ScannerActions{LockfilePaths: []string{lockfilePath}, MinPackages: 3}, nil)
+	if !errors.Is(err, ErrTooFewPackages) {
+		t.Fatalf("DoScan() error = %v, want %v", err, ErrTooFewPackages)
+	}
+
+	want := "too few packages found in scan: found 2 packages, but expected at least 3"
+	if err.Error() != want {
+		t.Errorf("DoScan() error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestDoScan_RequireEcosystems(t *testing.T) {
+	t.Parallel()
+
+	lockfilePath := filepath.Join(t.TempDir(), "requirements.txt")
+	if err := os.WriteFile(lockfilePath, []byte("flask==2.0.0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := DoScan(ScannerActions{LockfilePaths: []string{lockfilePath}, RequireEcosystems: []string{"npm", "pypi", "go"}}, nil)
+	if !errors.Is(err, ErrMissingEcosystems) {
+		t.Fatalf("DoScan() error = %v, want %v", err, ErrMissingEcosystems)
+	}
+
+	want := "required ecosystems were not scanned: found no packages in npm, go"
+	if err.Error() != want {
+		t.Errorf("DoScan() error = %q, want %q", err.Error(), want)
+	}
+}
+
+func Test_missingEcosystems(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{Name: "flask", Version: "2.0.0", Ecosystem: lockfile.PipEcosystem},
+		{Name: "nginx", Version: "1.18.0", Ecosystem: "Debian:11"},
+		{PURL: "pkg:npm/lodash@4.17.20"},
+		{Commit: "9a6bd55c9d0722cb101fe85a3b22d89e4ff4fe52"},
+	}
+
+	tests := []struct {
+		name     string
+		required []string
+		want     []string
+	}{
+		{
+			name:     "nothing required",
+			required: nil,
+			want:     nil,
+		},
+		{
+			name:     "all found regardless of case and release",
+			required: []string{"PyPI", "npm", "debian"},
+			want:     nil,
+		},
+		{
+			name:     "some missing",
+			required: []string{"go", "pypi", "Maven"},
+			want:     []string{"go", "Maven"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Reinitialize for t.Parallel()
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := missingEcosystems(packages, tt.required)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("missingEcosystems() (-want +got):\n%s", diff)
+			}
+		})
 	}
 }
@@ -0,0 +1,99 @@
+package osvscanner
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// scanTask extracts packages from a single source, such as a lockfile,
+// reporting any messages to the given reporter
+type scanTask func(r reporter.Reporter) ([]scannedPackage, error)
+
+type scanTaskResult struct {
+	packages []scannedPackage
+	err      error
+	messages *recordingReporter
+}
+
+// runScanTasks runs the tasks using up to parallelism workers (or the number of CPUs if it is not positive),
+// returning their results in the same order as the tasks.
+//
+// The messages reported by each task are only passed on to r once its results are consumed
+// with replay, so that the output is the same as if the tasks were run sequentially.
+func runScanTasks(parallelism int, tasks []scanTask) []scanTaskResult {
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+
+	results := make([]scanTaskResult, len(tasks))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism && i < len(tasks); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				messages := &recordingReporter{}
+				pkgs, err := tasks[i](messages)
+				results[i] = scanTaskResult{packages: pkgs, err: err, messages: messages}
+			}
+		}()
+	}
+
+	for i := range tasks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// replay passes on the messages that were reported by the task to r
+func (result scanTaskResult) replay(r reporter.Reporter) {
+	for _, call := range result.messages.calls {
+		call(r)
+	}
+}
+
+// recordingReporter records the messages it is given so that they can be
+// reported in order once the scan task that reported them has finished
+type recordingReporter struct {
+	calls      []func(r reporter.Reporter)
+	hasErrored bool
+}
+
+func (r *recordingReporter) Errorf(format string, a ...any) {
+	r.hasErrored = true
+	r.calls = append(r.calls, func(r reporter.Reporter) { r.Errorf(format, a...) })
+}
+
+func (r *recordingReporter) HasErrored() bool {
+	return r.hasErrored
+}
+
+func (r *recordingReporter) Warnf(format string, a ...any) {
+	r.calls = append(r.calls, func(r reporter.Reporter) { r.Warnf(format, a...) })
+}
+
+func (r *recordingReporter) Infof(format string, a ...any) {
+	r.calls = append(r.calls, func(r reporter.Reporter) { r.Infof(format, a...) })
+}
+
+func (r *recordingReporter) Verbosef(format string, a ...any) {
+	r.calls = append(r.calls, func(r reporter.Reporter) { r.Verbosef(format, a...) })
+}
+
+func (r *recordingReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	r.calls = append(r.calls, func(r reporter.Reporter) { _ = r.PrintResult(vulnResult) })
+
+	return nil
+}
+
+func (r *recordingReporter) SetFooter(footer string) {
+	r.calls = append(r.calls, func(r reporter.Reporter) { r.SetFooter(footer) })
+}
@@ -0,0 +1,66 @@
+package osvscanner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func Test_runScanTasks(t *testing.T) {
+	t.Parallel()
+
+	var tasks []scanTask
+	for i := 0; i < 10; i++ {
+		i := i
+		tasks = append(tasks, func(r reporter.Reporter) ([]scannedPackage, error) {
+			// finish the earlier tasks last to make sure the order is not
+			// determined by when each task finishes
+			time.Sleep(time.Duration(10-i) * time.Millisecond)
+			r.Infof("scanned %d\n", i)
+			if i == 5 {
+				return nil, errors.New("failed")
+			}
+
+			return []scannedPackage{{Name: fmt.Sprintf("pkg-%d", i)}}, nil
+		})
+	}
+
+	for _, parallelism := range []int{0, 1, 4, 20} {
+		out := &bytes.Buffer{}
+		r := reporter.NewTableReporter(out, out, reporter.InfoLevel, false, 0)
+
+		var names []string
+		var errs int
+		for _, result := range runScanTasks(parallelism, tasks) {
+			result.replay(r)
+			for _, pkg := range result.packages {
+				names = append(names, pkg.Name)
+			}
+			if result.err != nil {
+				errs++
+			}
+		}
+
+		wantNames := []string{"pkg-0", "pkg-1", "pkg-2", "pkg-3", "pkg-4", "pkg-6", "pkg-7", "pkg-8", "pkg-9"}
+		if diff := cmp.Diff(wantNames, names); diff != "" {
+			t.Errorf("runScanTasks(%d) packages (-want +got):\n%s", parallelism, diff)
+		}
+
+		wantOut := ""
+		for i := 0; i < 10; i++ {
+			wantOut += fmt.Sprintf("scanned %d\n", i)
+		}
+		if diff := cmp.Diff(wantOut, out.String()); diff != "" {
+			t.Errorf("runScanTasks(%d) output (-want +got):\n%s", parallelism, diff)
+		}
+
+		if errs != 1 {
+			t.Errorf("runScanTasks(%d) returned %d errors, want 1", parallelism, errs)
+		}
+	}
+}
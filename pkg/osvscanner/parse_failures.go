@@ -0,0 +1,78 @@
+package osvscanner
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// ErrParseFailures is returned along with the results when parse errors are allowed
+// and some lockfiles could not be parsed, as the results may be incomplete
+var ErrParseFailures = errors.New("some lockfiles could not be parsed")
+
+// lockfileParseError is returned by scan tasks when a lockfile could not be parsed,
+// so that the failure can be recorded once the task has finished
+type lockfileParseError struct {
+	path string
+	err  error
+}
+
+func (e *lockfileParseError) Error() string {
+	return fmt.Sprintf("failed to parse %s: %v", e.path, e.err)
+}
+
+func (e *lockfileParseError) Unwrap() error {
+	return e.err
+}
+
+// parseFailures records the lockfiles that could not be parsed during a scan
+type parseFailures struct {
+	// whether the scan should continue with the other files when a lockfile cannot be parsed
+	allowed bool
+	paths   []string
+}
+
+// report reports that the lockfile at path could not be parsed (as a warning if parse errors
+// are allowed), returning an error for the scan task to be recorded once it has finished.
+//
+// This does not modify the failures, so it is safe to call from tasks that run in parallel
+func (pf *parseFailures) report(r reporter.Reporter, path string, err error) error {
+	if pf.allowed {
+		r.Warnf("Attempted to scan lockfile but failed: %s - %v\n", path, err)
+	} else {
+		r.Errorf("Attempted to scan lockfile but failed: %s\n", path)
+	}
+
+	return &lockfileParseError{path: path, err: err}
+}
+
+// record records the lockfile that failed to parse if err was returned by report,
+// returning false if err is some other error
+func (pf *parseFailures) record(err error) bool {
+	var parseErr *lockfileParseError
+	if !errors.As(err, &parseErr) {
+		return false
+	}
+
+	pf.paths = append(pf.paths, parseErr.path)
+
+	return true
+}
+
+// wrap adds ErrParseFailures to the error of a scan if parse errors are allowed
+// and any lockfiles could not be parsed
+func (pf *parseFailures) wrap(r reporter.Reporter, err error) error {
+	if !pf.allowed || len(pf.paths) == 0 {
+		return err
+	}
+
+	r.Warnf(
+		"Failed to parse %d %s, so the results may be incomplete\n",
+		len(pf.paths),
+		output.Form(len(pf.paths), "lockfile", "lockfiles"),
+	)
+
+	return errors.Join(err, ErrParseFailures)
+}
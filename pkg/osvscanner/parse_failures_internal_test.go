@@ -0,0 +1,74 @@
+package osvscanner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func Test_parseFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		allowed      bool
+		scanErr      error
+		wantErrored  bool
+		wantVulns    bool
+		wantParseErr bool
+	}{
+		{
+			name:        "not allowed",
+			allowed:     false,
+			scanErr:     nil,
+			wantErrored: true,
+		},
+		{
+			name:         "allowed",
+			allowed:      true,
+			scanErr:      nil,
+			wantParseErr: true,
+		},
+		{
+			name:         "allowed with vulnerabilities",
+			allowed:      true,
+			scanErr:      VulnerabilitiesFoundErr,
+			wantVulns:    true,
+			wantParseErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &reporter.VoidReporter{}
+			failures := &parseFailures{allowed: tt.allowed}
+
+			err := failures.report(r, "/path/to/composer.lock", errors.New("invalid json"))
+
+			if r.HasErrored() != tt.wantErrored {
+				t.Errorf("report() HasErrored() = %v, want %v", r.HasErrored(), tt.wantErrored)
+			}
+
+			if failures.record(errors.New("some other error")) {
+				t.Errorf("record() recorded an error that was not returned by report()")
+			}
+
+			if !failures.record(err) {
+				t.Errorf("record() did not record the error returned by report()")
+			}
+
+			err = failures.wrap(r, tt.scanErr)
+
+			if got := errors.Is(err, VulnerabilitiesFoundErr); got != tt.wantVulns {
+				t.Errorf("wrap() is VulnerabilitiesFoundErr = %v, want %v", got, tt.wantVulns)
+			}
+
+			if got := errors.Is(err, ErrParseFailures); got != tt.wantParseErr {
+				t.Errorf("wrap() is ErrParseFailures = %v, want %v", got, tt.wantParseErr)
+			}
+		})
+	}
+}
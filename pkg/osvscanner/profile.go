@@ -0,0 +1,155 @@
+package osvscanner
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// Profile records the wall-clock time spent in each phase of a scan, to help find
+// what makes a scan slow. The zero value is ready to use, and a nil *Profile records nothing.
+type Profile struct {
+	mu sync.Mutex
+
+	// Extraction is the time taken to extract the packages from each of the files that had any
+	Extraction []FileTiming
+	// Query is the total time taken to query OSV (or the local databases) for vulnerabilities
+	Query time.Duration
+	// QueryCount is the number of times OSV was queried, as the packages of each source are queried
+	// separately when the results are streamed
+	QueryCount int
+	// Licenses is the total time taken to fetch the licenses of the packages, if they were needed
+	Licenses time.Duration
+}
+
+// FileTiming is the time taken to extract the packages from a file
+type FileTiming struct {
+	Path     string
+	Duration time.Duration
+}
+
+func (t FileTiming) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Path    string  `json:"path"`
+		Seconds float64 `json:"seconds"`
+	}{t.Path, t.Duration.Seconds()})
+}
+
+func (p *Profile) MarshalJSON() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return json.Marshal(struct {
+		Extraction      []FileTiming `json:"extraction"`
+		QuerySeconds    float64      `json:"querySeconds"`
+		QueryCount      int          `json:"queryCount"`
+		LicensesSeconds float64      `json:"licensesSeconds"`
+	}{p.sortedExtraction(), p.Query.Seconds(), p.QueryCount, p.Licenses.Seconds()})
+}
+
+// trackExtraction starts timing the extraction of packages from the file at path,
+// returning a function that records it if the file turned out to have any packages
+func (p *Profile) trackExtraction(path string) func(pkgs []scannedPackage) {
+	start := time.Now()
+
+	return func(pkgs []scannedPackage) {
+		if p == nil || len(pkgs) == 0 {
+			return
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.Extraction = append(p.Extraction, FileTiming{Path: path, Duration: time.Since(start)})
+	}
+}
+
+// trackQuery starts timing a query for vulnerabilities, returning a function that records it
+func (p *Profile) trackQuery() func() {
+	start := time.Now()
+
+	return func() {
+		if p == nil {
+			return
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.Query += time.Since(start)
+		p.QueryCount++
+	}
+}
+
+// trackLicenses starts timing the fetching of licenses, returning a function that records it
+func (p *Profile) trackLicenses() func() {
+	start := time.Now()
+
+	return func() {
+		if p == nil {
+			return
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.Licenses += time.Since(start)
+	}
+}
+
+// sortedExtraction returns the extraction timings from slowest to fastest,
+// which must be called while holding the lock
+func (p *Profile) sortedExtraction() []FileTiming {
+	extraction := slices.Clone(p.Extraction)
+	slices.SortStableFunc(extraction, func(a, b FileTiming) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+
+	return extraction
+}
+
+// Print reports the breakdown of the time spent in each phase of the scan,
+// with the files that took the longest to extract listed first
+func (p *Profile) Print(r reporter.Reporter) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	extraction := p.sortedExtraction()
+	var total time.Duration
+	for _, timing := range extraction {
+		total += timing.Duration
+	}
+
+	r.Infof("\nProfile:\n")
+	r.Infof(
+		"  Extracting packages from %d %s: %s\n",
+		len(extraction),
+		output.Form(len(extraction), "file", "files"),
+		formatDuration(total),
+	)
+	for _, timing := range extraction {
+		r.Infof("    %-10s %s\n", formatDuration(timing.Duration), timing.Path)
+	}
+	r.Infof(
+		"  Querying for vulnerabilities (%d %s): %s\n",
+		p.QueryCount,
+		output.Form(p.QueryCount, "query", "queries"),
+		formatDuration(p.Query),
+	)
+	r.Infof("  Fetching licenses: %s\n", formatDuration(p.Licenses))
+}
+
+// WriteJSON writes the profile to w as JSON, with the times in seconds
+func (p *Profile) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(p)
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
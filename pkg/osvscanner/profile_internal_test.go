@@ -0,0 +1,104 @@
+package osvscanner
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func TestProfile_trackExtraction(t *testing.T) {
+	t.Parallel()
+
+	profile := &Profile{}
+
+	profile.trackExtraction("/path/to/package-lock.json")([]scannedPackage{{Name: "mine", Version: "1.0.0"}})
+	profile.trackExtraction("/path/to/README.md")(nil)
+
+	if len(profile.Extraction) != 1 || profile.Extraction[0].Path != "/path/to/package-lock.json" {
+		t.Errorf("Expected only the file with packages to be recorded, but got %v", profile.Extraction)
+	}
+
+	// a nil profile records nothing, so that callers do not need to check if profiling is enabled
+	var none *Profile
+	none.trackExtraction("/path/to/package-lock.json")([]scannedPackage{{Name: "mine", Version: "1.0.0"}})
+	none.trackQuery()()
+	none.trackLicenses()()
+}
+
+func TestProfile_trackQuery(t *testing.T) {
+	t.Parallel()
+
+	profile := &Profile{}
+
+	profile.trackQuery()()
+	profile.trackQuery()()
+
+	if profile.QueryCount != 2 {
+		t.Errorf("Expected 2 queries to be recorded, but got %d", profile.QueryCount)
+	}
+}
+
+func testProfile() *Profile {
+	return &Profile{
+		Extraction: []FileTiming{
+			{Path: "/path/to/go.mod", Duration: 12 * time.Millisecond},
+			{Path: "/path/to/package-lock.json", Duration: 1500 * time.Millisecond},
+		},
+		Query:      2 * time.Second,
+		QueryCount: 1,
+		Licenses:   250 * time.Millisecond,
+	}
+}
+
+func TestProfile_Print(t *testing.T) {
+	t.Parallel()
+
+	stdout := &bytes.Buffer{}
+	testProfile().Print(reporter.NewTableReporter(stdout, io.Discard, reporter.InfoLevel, false, 0))
+
+	want := `
+Profile:
+  Extracting packages from 2 files: 1.512s
+    1.5s       /path/to/package-lock.json
+    12ms       /path/to/go.mod
+  Querying for vulnerabilities (1 query): 2s
+  Fetching licenses: 250ms
+`
+
+	if got := stdout.String(); got != want {
+		t.Errorf("Print() = %q, want %q", got, want)
+	}
+}
+
+func TestProfile_WriteJSON(t *testing.T) {
+	t.Parallel()
+
+	w := &bytes.Buffer{}
+	if err := testProfile().WriteJSON(w); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	want := `{
+  "extraction": [
+    {
+      "path": "/path/to/package-lock.json",
+      "seconds": 1.5
+    },
+    {
+      "path": "/path/to/go.mod",
+      "seconds": 0.012
+    }
+  ],
+  "querySeconds": 2,
+  "queryCount": 1,
+  "licensesSeconds": 0.25
+}
+`
+
+	if got := w.String(); got != want {
+		t.Errorf("WriteJSON() = %s, want %s", got, want)
+	}
+}
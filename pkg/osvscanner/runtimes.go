@@ -0,0 +1,203 @@
+package osvscanner
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// runtimeSourceType is the type of the sources of runtimes, so that vulnerabilities in the runtime
+// that a project is pinned to are reported separately from those in its dependencies
+const runtimeSourceType = "runtime"
+
+// runtimePackage is how a runtime is identified in OSV
+type runtimePackage struct {
+	// Name is the name the runtime is shown with
+	Name string
+	// Package and Ecosystem are the package that the vulnerabilities of the runtime are recorded against
+	Package   string
+	Ecosystem lockfile.Ecosystem
+}
+
+// runtimeVersionFiles are the files that pin the version of a runtime, by their name.
+//
+// The vulnerabilities of Node.js and Python are checked against the Bitnami ecosystem, as it is
+// the ecosystem in OSV that has entries for those runtimes themselves.
+var runtimeVersionFiles = map[string]runtimePackage{
+	".nvmrc":          {Name: "Node.js", Package: "node", Ecosystem: lockfile.Ecosystem(models.EcosystemBitnami)},
+	".node-version":   {Name: "Node.js", Package: "node", Ecosystem: lockfile.Ecosystem(models.EcosystemBitnami)},
+	".python-version": {Name: "Python", Package: "python", Ecosystem: lockfile.Ecosystem(models.EcosystemBitnami)},
+}
+
+// runtimeVersionPattern matches the versions that can be checked, rather than
+// aliases (such as "lts/*" in an .nvmrc) or other implementations (such as "pypy3.10")
+var runtimeVersionPattern = regexp.MustCompile(`^v?(\d+(?:\.\d+){0,2})$`)
+
+// isRuntimeVersionFile returns true if the file at path pins the version of a runtime
+func isRuntimeVersionFile(path string) bool {
+	_, ok := runtimeVersionFiles[filepath.Base(path)]
+
+	return ok
+}
+
+// scanRuntimeVersionFile returns the runtime pinned by the version file at path, which is the first
+// version in the file (as pyenv allows listing several)
+func scanRuntimeVersionFile(r reporter.Reporter, path string) ([]scannedPackage, error) {
+	runtime := runtimeVersionFiles[filepath.Base(path)]
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	version := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			version = line
+
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	match := runtimeVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		r.Warnf("Could not check the %s runtime in %s as %q is not a version\n", runtime.Name, path, version)
+
+		return nil, nil
+	}
+
+	r.Infof("Scanned %s file and found the %s %s runtime\n", path, runtime.Name, match[1])
+
+	return []scannedPackage{{
+		Name:      runtime.Package,
+		Version:   match[1],
+		Ecosystem: runtime.Ecosystem,
+		Source: models.SourceInfo{
+			Path: path,
+			Type: runtimeSourceType,
+		},
+	}}, nil
+}
+
+// latestRelease fills in the minor and patch components of a version if they are missing, so that
+// only pinning the major or minor version is not treated as being on the very first release of it,
+// the same as is done for the Go standard library
+func latestRelease(version string) string {
+	for strings.Count(version, ".") < 2 {
+		version += ".9999"
+	}
+
+	return version
+}
+
+// separateGoRuntime moves the Go standard library found in each go.mod file to a runtime source of its own,
+// as the go directive pins the version of Go that the module is built with rather than being a dependency
+func separateGoRuntime(packages []scannedPackage) {
+	for i, pkg := range packages {
+		if pkg.Name == "stdlib" && pkg.Ecosystem == lockfile.GoEcosystem &&
+			pkg.Source.Type == "lockfile" && filepath.Base(pkg.Source.Path) == "go.mod" {
+			packages[i].Source.Type = runtimeSourceType
+		}
+	}
+}
+
+// runtimeEndOfLife is when each release line of a runtime stopped (or will stop) receiving security fixes
+type runtimeEndOfLife struct {
+	// Name is the name the runtime is shown with
+	Name string
+	// Components is how many components of a version make up its release line
+	Components int
+	// Dates are the end of life dates of the release lines, as YYYY-MM-DD
+	Dates map[string]string
+}
+
+// runtimeEndOfLifeDates are the end of life dates of the runtimes, keyed on the package that they are checked as.
+//
+// These are built in rather than looked up, so release lines that are newer than this list are never
+// reported as having reached their end of life until it is updated
+var runtimeEndOfLifeDates = map[string]runtimeEndOfLife{
+	"node": {
+		Name:       "Node.js",
+		Components: 1,
+		Dates: map[string]string{
+			"4": "2018-04-30", "5": "2016-06-30", "6": "2019-04-30", "7": "2017-06-30",
+			"8": "2019-12-31", "9": "2018-06-30", "10": "2021-04-30", "11": "2019-06-01",
+			"12": "2022-04-30", "13": "2020-06-01", "14": "2023-04-30", "15": "2021-06-01",
+			"16": "2023-09-11", "17": "2022-06-01", "18": "2025-04-30", "19": "2023-06-01",
+			"20": "2026-04-30", "21": "2024-06-01", "22": "2027-04-30", "23": "2025-06-01",
+		},
+	},
+	"python": {
+		Name:       "Python",
+		Components: 2,
+		Dates: map[string]string{
+			"2.7": "2020-01-01", "3.5": "2020-09-13", "3.6": "2021-12-23", "3.7": "2023-06-27",
+			"3.8": "2024-10-07", "3.9": "2025-10-31", "3.10": "2026-10-31", "3.11": "2027-10-31",
+			"3.12": "2028-10-31", "3.13": "2029-10-31",
+		},
+	},
+	// each release of Go is supported until there are two newer major releases
+	"stdlib": {
+		Name:       "Go",
+		Components: 2,
+		Dates: map[string]string{
+			"1.16": "2022-03-15", "1.17": "2022-08-02", "1.18": "2023-02-01", "1.19": "2023-08-08",
+			"1.20": "2024-02-06", "1.21": "2024-08-13", "1.22": "2025-02-11", "1.23": "2025-08-12",
+		},
+	},
+}
+
+// endOfLife returns when the release line of the runtime at version reached its end of life,
+// if it is known and has been reached by now
+func endOfLife(pkg scannedPackage, now time.Time) (string, time.Time, bool) {
+	eol, ok := runtimeEndOfLifeDates[pkg.Name]
+	if !ok {
+		return "", time.Time{}, false
+	}
+
+	parts := strings.Split(pkg.Version, ".")
+	if len(parts) > eol.Components {
+		parts = parts[:eol.Components]
+	}
+
+	line := strings.Join(parts, ".")
+	date, err := time.Parse(time.DateOnly, eol.Dates[line])
+	if err != nil || now.Before(date) {
+		return "", time.Time{}, false
+	}
+
+	return line, date, true
+}
+
+// warnEndOfLifeRuntimes warns about each runtime that is pinned to a release line which no longer
+// receives security fixes, as it may have vulnerabilities that are never fixed in it
+func warnEndOfLifeRuntimes(r reporter.Reporter, packages []scannedPackage, now time.Time) {
+	for _, pkg := range packages {
+		if pkg.Source.Type != runtimeSourceType {
+			continue
+		}
+
+		if line, date, ok := endOfLife(pkg, now); ok {
+			r.Warnf(
+				"%s %s in %s reached its end of life on %s, and no longer receives security fixes\n",
+				runtimeEndOfLifeDates[pkg.Name].Name,
+				line,
+				pkg.Source.Path,
+				date.Format(time.DateOnly),
+			)
+		}
+	}
+}
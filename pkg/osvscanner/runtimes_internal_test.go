@@ -0,0 +1,193 @@
+package osvscanner
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func Test_scanRuntimeVersionFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		path     string
+		want     []scannedPackage
+		wantWarn bool
+	}{
+		{
+			name: "nvmrc",
+			path: "fixtures/runtimes/node/.nvmrc",
+			want: []scannedPackage{{
+				Name:      "node",
+				Version:   "18",
+				Ecosystem: lockfile.Ecosystem(models.EcosystemBitnami),
+				Source:    models.SourceInfo{Path: "fixtures/runtimes/node/.nvmrc", Type: "runtime"},
+			}},
+		},
+		{
+			name: "python version with several versions",
+			path: "fixtures/runtimes/python/.python-version",
+			want: []scannedPackage{{
+				Name:      "python",
+				Version:   "3.8.10",
+				Ecosystem: lockfile.Ecosystem(models.EcosystemBitnami),
+				Source:    models.SourceInfo{Path: "fixtures/runtimes/python/.python-version", Type: "runtime"},
+			}},
+		},
+		{
+			name:     "alias",
+			path:     "fixtures/runtimes/alias/.nvmrc",
+			want:     nil,
+			wantWarn: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Reinitialize for t.Parallel()
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &bytes.Buffer{}
+			r := reporter.NewTableReporter(out, out, reporter.InfoLevel, false, 0)
+
+			got, err := scanRuntimeVersionFile(r, tt.path)
+			if err != nil {
+				t.Fatalf("scanRuntimeVersionFile() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("scanRuntimeVersionFile() (-want +got):\n%s", diff)
+			}
+
+			if gotWarn := strings.Contains(out.String(), "Could not check"); gotWarn != tt.wantWarn {
+				t.Errorf("scanRuntimeVersionFile() warned = %v, want %v:\n%s", gotWarn, tt.wantWarn, out.String())
+			}
+		})
+	}
+}
+
+func Test_scanDir_Runtimes(t *testing.T) {
+	t.Parallel()
+
+	for _, scanRuntimes := range []bool{false, true} {
+		pkgs, err := scanDir(&reporter.VoidReporter{}, "fixtures/runtimes", scanDirOptions{
+			SkipGit:           true,
+			SkipGitSubmodules: true,
+			Recursive:         true,
+			CompareOffline:    true,
+			ScanRuntimes:      scanRuntimes,
+			Parallelism:       1,
+		}, scannedFiles{}, &parseFailures{})
+		if err != nil {
+			t.Fatalf("scanDir() error = %v", err)
+		}
+
+		var got []string
+		for _, pkg := range pkgs {
+			got = append(got, pkg.Name+"@"+pkg.Version+" "+filepath.Base(pkg.Source.Path))
+		}
+
+		var want []string
+		if scanRuntimes {
+			want = []string{"node@18 .nvmrc", "python@3.8.10 .python-version"}
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("scanDir() with scanRuntimes = %v (-want +got):\n%s", scanRuntimes, diff)
+		}
+	}
+}
+
+func Test_separateGoRuntime(t *testing.T) {
+	t.Parallel()
+
+	packages := []scannedPackage{
+		{Name: "stdlib", Version: "1.21", Ecosystem: lockfile.GoEcosystem, Source: models.SourceInfo{Path: "/a/go.mod", Type: "lockfile"}},
+		{Name: "golang.org/x/net", Version: "0.1.0", Ecosystem: lockfile.GoEcosystem, Source: models.SourceInfo{Path: "/a/go.mod", Type: "lockfile"}},
+		{Name: "stdlib", Version: "1.21.5", Ecosystem: lockfile.GoEcosystem, Source: models.SourceInfo{Path: "/a/app", Type: "lockfile"}},
+	}
+
+	separateGoRuntime(packages)
+
+	want := []string{"runtime", "lockfile", "lockfile"}
+	for i, pkg := range packages {
+		if pkg.Source.Type != want[i] {
+			t.Errorf("separateGoRuntime() gave %s from %s the source type %q, want %q", pkg.Name, pkg.Source.Path, pkg.Source.Type, want[i])
+		}
+	}
+}
+
+func Test_patchPackageForRequest_Runtimes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pkg  scannedPackage
+		want string
+	}{
+		{
+			pkg:  scannedPackage{Name: "node", Version: "18", Ecosystem: "Bitnami", Source: models.SourceInfo{Type: "runtime"}},
+			want: "18.9999.9999",
+		},
+		{
+			pkg:  scannedPackage{Name: "python", Version: "3.11", Ecosystem: "Bitnami", Source: models.SourceInfo{Type: "runtime"}},
+			want: "3.11.9999",
+		},
+		{
+			pkg:  scannedPackage{Name: "python", Version: "3.11.4", Ecosystem: "Bitnami", Source: models.SourceInfo{Type: "runtime"}},
+			want: "3.11.4",
+		},
+		{
+			pkg:  scannedPackage{Name: "stdlib", Version: "1.21", Ecosystem: lockfile.GoEcosystem, Source: models.SourceInfo{Type: "runtime"}},
+			want: "1.21.9999",
+		},
+		{
+			pkg:  scannedPackage{Name: "node", Version: "18", Ecosystem: "Bitnami", Source: models.SourceInfo{Type: "sbom"}},
+			want: "18",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := patchPackageForRequest(tt.pkg).Version; got != tt.want {
+			t.Errorf("patchPackageForRequest(%s@%s from a %s) = %s, want %s", tt.pkg.Name, tt.pkg.Version, tt.pkg.Source.Type, got, tt.want)
+		}
+	}
+}
+
+func Test_warnEndOfLifeRuntimes(t *testing.T) {
+	t.Parallel()
+
+	runtime := func(name string, version string, ecosystem lockfile.Ecosystem, path string) scannedPackage {
+		return scannedPackage{Name: name, Version: version, Ecosystem: ecosystem, Source: models.SourceInfo{Path: path, Type: runtimeSourceType}}
+	}
+
+	packages := []scannedPackage{
+		runtime("node", "16", lockfile.Ecosystem(models.EcosystemBitnami), "/a/.nvmrc"),
+		runtime("node", "22.1.0", lockfile.Ecosystem(models.EcosystemBitnami), "/b/.nvmrc"),
+		runtime("python", "3.8.10", lockfile.Ecosystem(models.EcosystemBitnami), "/a/.python-version"),
+		runtime("stdlib", "1.21.5", lockfile.GoEcosystem, "/a/go.mod"),
+		runtime("stdlib", "1.99", lockfile.GoEcosystem, "/b/go.mod"),
+		// only runtimes are checked, rather than the same packages as dependencies
+		{Name: "stdlib", Version: "1.18", Ecosystem: lockfile.GoEcosystem, Source: models.SourceInfo{Path: "/c/app", Type: "lockfile"}},
+	}
+
+	out := &bytes.Buffer{}
+	r := reporter.NewTableReporter(out, out, reporter.WarnLevel, false, 0)
+
+	warnEndOfLifeRuntimes(r, packages, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	want := "Node.js 16 in /a/.nvmrc reached its end of life on 2023-09-11, and no longer receives security fixes\n" +
+		"Python 3.8 in /a/.python-version reached its end of life on 2024-10-07, and no longer receives security fixes\n" +
+		"Go 1.21 in /a/go.mod reached its end of life on 2024-08-13, and no longer receives security fixes\n"
+
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("warnEndOfLifeRuntimes() warnings (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,127 @@
+package osvscanner
+
+import (
+	"fmt"
+	"io/fs"
+	"testing/fstest"
+
+	"github.com/google/osv-scanner/pkg/config"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// File is a lockfile whose contents are already in memory, such as after being fetched from the API of a git host
+type File struct {
+	// Name is the path of the file, which determines how it is parsed unless ParseAs is set
+	Name string
+	// ParseAs is the name of the parser to use for the file, as would be given to --lockfile
+	ParseAs string
+	Content []byte
+}
+
+// fsExtractors are the extractors for the parsers that lockfile.ExtractDeps does not use
+// because of their generic names, which can be used when the parser is requested
+var fsExtractors = map[string]lockfile.Extractor{
+	"apk-installed":         lockfile.ApkInstalledExtractor{},
+	"dpkg-status":           lockfile.DpkgStatusExtractor{},
+	"osv-scanner":           lockfile.OSVScannerResultsExtractor{},
+	"maven-dependency-tree": lockfile.MavenDependencyTreeExtractor{},
+	"brew":                  lockfile.BrewListExtractor{},
+}
+
+// ScanFiles scans the given in-memory lockfiles for vulnerabilities without touching the disk.
+//
+// See ScanFS for which of the actions are used.
+func ScanFiles(actions ScannerActions, r reporter.Reporter, files []File) (models.VulnerabilityResults, error) {
+	fsys := make(fstest.MapFS, len(files))
+	lockfilePaths := make([]string, 0, len(files))
+	for _, file := range files {
+		fsys[file.Name] = &fstest.MapFile{Data: file.Content}
+
+		if file.ParseAs != "" {
+			lockfilePaths = append(lockfilePaths, file.ParseAs+":"+file.Name)
+		} else {
+			lockfilePaths = append(lockfilePaths, file.Name)
+		}
+	}
+
+	return ScanFS(actions, r, fsys, lockfilePaths)
+}
+
+// ScanFS scans the lockfiles at the given paths within fsys for vulnerabilities, where each path can be
+// prefixed with the parser to use in the same way as ScannerActions.LockfilePaths.
+//
+// The sources of the other actions (such as the directories and SBOMs) are not scanned, and configs are
+// never loaded from fsys or the disk, so only the config at ScannerActions.ConfigOverridePath is used.
+func ScanFS(actions ScannerActions, r reporter.Reporter, fsys fs.FS, lockfilePaths []string) (models.VulnerabilityResults, error) {
+	if r == nil {
+		r = &reporter.VoidReporter{}
+	}
+
+	actions, err := normalizeActions(actions)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
+	// the manifests alongside the lockfiles are on the disk rather than in fsys
+	actions.CheckDrift = false
+	actions.FailOnDrift = false
+
+	configManager, err := newConfigManager(r, actions)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
+	if configManager.OverrideConfig == nil {
+		configManager.OverrideConfig = &config.Config{}
+	}
+
+	//nolint:prealloc // Not sure how many there will be in advance.
+	var scannedPackages []scannedPackage
+
+	failures := &parseFailures{allowed: actions.AllowParseErrors}
+	for _, lockfileElem := range lockfilePaths {
+		parseAs, lockfilePath := parseLockfilePath(lockfileElem)
+
+		pkgs, err := scanFSLockfile(r, fsys, lockfilePath, parseAs)
+		if err != nil {
+			if !failures.allowed {
+				return models.VulnerabilityResults{}, err
+			}
+
+			failures.record(failures.report(r, lockfilePath, err))
+		}
+
+		scannedPackages = append(scannedPackages, pkgs...)
+	}
+
+	return scanCollectedPackages(r, actions, &configManager, scannedPackages, failures)
+}
+
+// scanFSLockfile extracts the packages from the lockfile at path within fsys
+func scanFSLockfile(r reporter.Reporter, fsys fs.FS, path string, parseAs string) ([]scannedPackage, error) {
+	f, err := lockfile.OpenFSDepFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var parsedLockfile lockfile.Lockfile
+
+	if extractor, ok := fsExtractors[parseAs]; ok {
+		packages, err := extractor.Extract(f)
+		if err != nil {
+			return nil, fmt.Errorf("(extracting as %s) %w", parseAs, err)
+		}
+
+		parsedLockfile = lockfile.Lockfile{FilePath: path, ParsedAs: parseAs, Packages: packages}
+	} else {
+		parsedLockfile, err = lockfile.ExtractDeps(f, parseAs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lockfilePackages(r, path, parseAs, parsedLockfile), nil
+}
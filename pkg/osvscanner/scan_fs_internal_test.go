@@ -0,0 +1,95 @@
+package osvscanner
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func Test_scanFSLockfile(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"app/package-lock.json": &fstest.MapFile{Data: []byte(`{
+			"lockfileVersion": 3,
+			"packages": {
+				"": {"dependencies": {"lodash": "^4.17.0"}},
+				"node_modules/lodash": {"version": "4.17.21"}
+			}
+		}`)},
+		"app/deps.txt": &fstest.MapFile{Data: []byte("flask==2.0.0\n")},
+	}
+
+	pkgs, err := scanFSLockfile(&reporter.VoidReporter{}, fsys, "app/package-lock.json", "")
+	if err != nil {
+		t.Fatalf("scanFSLockfile() error = %v", err)
+	}
+	if len(pkgs) != 1 || pkgs[0].Name != "lodash" || pkgs[0].Version != "4.17.21" {
+		t.Errorf("scanFSLockfile() = %v, want lodash@4.17.21", pkgs)
+	}
+	if pkgs[0].Source.Path != "app/package-lock.json" {
+		t.Errorf("scanFSLockfile() source = %s, want app/package-lock.json", pkgs[0].Source.Path)
+	}
+
+	pkgs, err = scanFSLockfile(&reporter.VoidReporter{}, fsys, "app/deps.txt", "requirements.txt")
+	if err != nil {
+		t.Fatalf("scanFSLockfile() error = %v", err)
+	}
+	if len(pkgs) != 1 || pkgs[0].Name != "flask" {
+		t.Errorf("scanFSLockfile() = %v, want flask", pkgs)
+	}
+
+	if _, err := scanFSLockfile(&reporter.VoidReporter{}, fsys, "app/missing.json", ""); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("scanFSLockfile() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func Test_scanFSLockfile_NestedFiles(t *testing.T) {
+	t.Parallel()
+
+	// the parent pom is opened from the same fs.FS as the module
+	pkgs, err := scanFSLockfile(&reporter.VoidReporter{}, os.DirFS("../lockfile/fixtures/maven"), "multi-module/module-a/pom.xml", "")
+	if err != nil {
+		t.Fatalf("scanFSLockfile() error = %v", err)
+	}
+
+	for _, pkg := range pkgs {
+		if pkg.Name == "io.netty:netty-all" {
+			if pkg.Version == "" {
+				t.Errorf("expected the version of %s to be inherited from the parent pom", pkg.Name)
+			}
+
+			return
+		}
+	}
+
+	t.Errorf("scanFSLockfile() = %v, want io.netty:netty-all", pkgs)
+}
+
+func TestScanFiles(t *testing.T) {
+	t.Parallel()
+
+	_, err := ScanFiles(ScannerActions{}, nil, []File{
+		{Name: "package-lock.json", Content: []byte(`{"lockfileVersion": 3, "packages": {}}`)},
+	})
+	if !errors.Is(err, NoPackagesFoundErr) {
+		t.Errorf("ScanFiles() error = %v, want %v", err, NoPackagesFoundErr)
+	}
+
+	_, err = ScanFiles(ScannerActions{}, nil, []File{
+		{Name: "deps", Content: []byte(`{}`)},
+	})
+	if err == nil {
+		t.Errorf("ScanFiles() expected an error for a file that cannot be parsed")
+	}
+
+	_, err = ScanFiles(ScannerActions{AllowParseErrors: true}, nil, []File{
+		{Name: "deps", ParseAs: "package-lock.json", Content: []byte(`not json`)},
+	})
+	if !errors.Is(err, NoPackagesFoundErr) {
+		t.Errorf("ScanFiles() error = %v, want %v", err, NoPackagesFoundErr)
+	}
+}
@@ -0,0 +1,92 @@
+package osvscanner
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// The severity buckets that exit codes can be set for, based on the max CVSS score of each
+// vulnerability, which are the same as the CVSS v3 qualitative severity ratings
+const (
+	SeverityBucketCritical = "critical"
+	SeverityBucketHigh     = "high"
+	SeverityBucketMedium   = "medium"
+	SeverityBucketLow      = "low"
+	SeverityBucketNone     = "none"
+	// SeverityBucketUnknown is for vulnerabilities that do not have a CVSS score
+	SeverityBucketUnknown = "unknown"
+)
+
+// SeverityBuckets are all the buckets that exit codes can be set for, from the most to least severe
+var SeverityBuckets = []string{
+	SeverityBucketCritical,
+	SeverityBucketHigh,
+	SeverityBucketMedium,
+	SeverityBucketLow,
+	SeverityBucketNone,
+	SeverityBucketUnknown,
+}
+
+// ResultError is returned instead of VulnerabilitiesFoundErr when exit codes are set for severity
+// buckets, with the exit code that the results of the scan should cause.
+//
+// It wraps VulnerabilitiesFoundErr, so it can still be checked for with errors.Is.
+type ResultError struct {
+	// ExitCode is the highest exit code of the severity buckets of the vulnerabilities that failed the scan
+	ExitCode int
+}
+
+func (e *ResultError) Error() string {
+	return fmt.Sprintf("%s (exit code %d)", VulnerabilitiesFoundErr, e.ExitCode)
+}
+
+func (e *ResultError) Unwrap() error {
+	return VulnerabilitiesFoundErr
+}
+
+// severityBucket returns the bucket of the max severity of a group of vulnerabilities
+func severityBucket(maxSeverity string) string {
+	score, err := strconv.ParseFloat(maxSeverity, 64)
+
+	switch {
+	case err != nil:
+		return SeverityBucketUnknown
+	case score >= 9:
+		return SeverityBucketCritical
+	case score >= 7:
+		return SeverityBucketHigh
+	case score >= 4:
+		return SeverityBucketMedium
+	case score > 0:
+		return SeverityBucketLow
+	default:
+		return SeverityBucketNone
+	}
+}
+
+// severityExitCode returns the exit code that the results should cause based on actions.SeverityExitCodes,
+// which is the highest of the exit codes of the vulnerabilities that fail the scan, with vulnerabilities in
+// buckets without an exit code (and license violations) using the usual exit code of 1
+func severityExitCode(results models.VulnerabilityResults, actions ScannerActions) int {
+	exitCode := 0
+	for _, vf := range results.Flatten() {
+		code := 0
+
+		if vf.Vulnerability.ID != "" && !vf.GroupInfo.Filtered && (vf.GroupInfo.IsCalled() || actions.FailOn == FailOnAny) {
+			var ok bool
+			if code, ok = actions.SeverityExitCodes[severityBucket(vf.GroupInfo.MaxSeverity)]; !ok {
+				code = 1
+			}
+		}
+
+		if len(vf.LicenseViolations) > 0 && actions.checksLicenseViolations() {
+			code = max(code, 1)
+		}
+
+		exitCode = max(exitCode, code)
+	}
+
+	return exitCode
+}
@@ -0,0 +1,105 @@
+package osvscanner
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func Test_severityExitCode(t *testing.T) {
+	t.Parallel()
+
+	group := func(id, maxSeverity string, called bool) models.GroupInfo {
+		return models.GroupInfo{
+			IDs:                  []string{id},
+			MaxSeverity:          maxSeverity,
+			ExperimentalAnalysis: map[string]models.AnalysisInfo{id: {Called: called}},
+		}
+	}
+
+	resultsWith := func(groups ...models.GroupInfo) models.VulnerabilityResults {
+		pkg := models.PackageVulns{
+			Package: models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "Go"},
+			Groups:  groups,
+		}
+		for _, g := range groups {
+			pkg.Vulnerabilities = append(pkg.Vulnerabilities, models.Vulnerability{ID: g.IDs[0]})
+		}
+
+		return models.VulnerabilityResults{
+			Results: []models.PackageSource{{Packages: []models.PackageVulns{pkg}}},
+		}
+	}
+
+	exitCodes := map[string]int{SeverityBucketCritical: 3, SeverityBucketHigh: 2}
+
+	tests := []struct {
+		name    string
+		results models.VulnerabilityResults
+		failOn  string
+		want    int
+	}{
+		{
+			name:    "no results",
+			results: models.VulnerabilityResults{},
+			want:    0,
+		},
+		{
+			name:    "bucket without an exit code",
+			results: resultsWith(group("GO-1", "5.0", true)),
+			want:    1,
+		},
+		{
+			name:    "highest exit code wins",
+			results: resultsWith(group("GO-1", "5.0", true), group("GO-2", "9.8", true), group("GO-3", "7.5", true)),
+			want:    3,
+		},
+		{
+			name:    "uncalled vulnerabilities are skipped",
+			results: resultsWith(group("GO-1", "7.5", true), group("GO-2", "9.8", false)),
+			want:    2,
+		},
+		{
+			name:    "uncalled vulnerabilities when failing on any",
+			results: resultsWith(group("GO-1", "7.5", true), group("GO-2", "9.8", false)),
+			failOn:  FailOnAny,
+			want:    3,
+		},
+		{
+			name:    "unknown severity",
+			results: resultsWith(group("GO-1", "", true)),
+			want:    1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := severityExitCode(tt.results, ScannerActions{FailOn: tt.failOn, SeverityExitCodes: exitCodes})
+			if got != tt.want {
+				t.Errorf("severityExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_severityBucket(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":     SeverityBucketUnknown,
+		"0.0":  SeverityBucketNone,
+		"3.9":  SeverityBucketLow,
+		"4.0":  SeverityBucketMedium,
+		"7.0":  SeverityBucketHigh,
+		"8.9":  SeverityBucketHigh,
+		"9.0":  SeverityBucketCritical,
+		"10.0": SeverityBucketCritical,
+	}
+	for maxSeverity, want := range tests {
+		if got := severityBucket(maxSeverity); got != want {
+			t.Errorf("severityBucket(%q) = %s, want %s", maxSeverity, got, want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package osvscanner
+
+import (
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/vulns"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// normalizeMatchingName loosely normalizes the name of a package so that it can be matched
+// against the names in advisories, which can differ in case and separators (such as for PyPI)
+func normalizeMatchingName(name string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+}
+
+// affectsInstalledVersion re-evaluates if the vulnerability affects the installed version of the package,
+// using the affected versions and ranges of the vulnerability and the versioning rules of the ecosystem.
+//
+// Matches that cannot be checked, such as for packages without a version, ecosystems whose versions
+// cannot be compared, or advisories that only describe git ranges, are assumed to hold.
+func affectsInstalledVersion(pkg models.PackageInfo, vuln models.Vulnerability) bool {
+	if pkg.Version == "" || pkg.Ecosystem == "" {
+		return true
+	}
+
+	ecosystem := lockfile.Ecosystem(pkg.Ecosystem)
+	if _, err := lockfile.CompareVersions(ecosystem, pkg.Version, pkg.Version); err != nil {
+		return true
+	}
+
+	// only the affected packages that match the package are checked, using its name so that
+	// differences in how the name is written do not prevent the versions from being compared
+	checked := vuln
+	checked.Affected = nil
+	for _, affected := range vuln.Affected {
+		if normalizeMatchingName(affected.Package.Name) != normalizeMatchingName(pkg.Name) {
+			continue
+		}
+
+		for _, r := range affected.Ranges {
+			if r.Type != models.RangeEcosystem && r.Type != models.RangeSemVer {
+				return true
+			}
+		}
+
+		affected.Package.Name = pkg.Name
+		checked.Affected = append(checked.Affected, affected)
+	}
+
+	if len(checked.Affected) == 0 {
+		return true
+	}
+
+	return vulns.IsAffected(checked, lockfile.PackageDetails{
+		Name:      pkg.Name,
+		Version:   pkg.Version,
+		Ecosystem: ecosystem,
+		CompareAs: lockfile.Ecosystem(pkg.BaseEcosystem()),
+	})
+}
+
+// filterUnaffected removes the vulnerability groups where none of the vulnerabilities actually
+// affect the installed version of the package, preserving order. Returns the number of groups removed.
+func filterUnaffected(results *models.VulnerabilityResults, allPackages bool) int {
+	return filterVulnGroups(results, allPackages, affectsInstalledVersion)
+}
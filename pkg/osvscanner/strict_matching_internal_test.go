@@ -0,0 +1,127 @@
+package osvscanner
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func Test_affectsInstalledVersion(t *testing.T) {
+	t.Parallel()
+
+	semverRange := func(introduced, fixed string) []models.Range {
+		return []models.Range{{
+			Type:   models.RangeSemVer,
+			Events: []models.Event{{Introduced: introduced}, {Fixed: fixed}},
+		}}
+	}
+
+	tests := []struct {
+		name string
+		pkg  models.PackageInfo
+		vuln models.Vulnerability
+		want bool
+	}{
+		{
+			name: "version within the range",
+			pkg:  models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+			vuln: models.Vulnerability{ID: "GHSA-1", Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: models.EcosystemNPM, Name: "lodash"}, Ranges: semverRange("4.0.0", "4.17.21")},
+			}},
+			want: true,
+		},
+		{
+			name: "version after the fix",
+			pkg:  models.PackageInfo{Name: "lodash", Version: "4.17.21", Ecosystem: "npm"},
+			vuln: models.Vulnerability{ID: "GHSA-1", Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: models.EcosystemNPM, Name: "lodash"}, Ranges: semverRange("4.0.0", "4.17.21")},
+			}},
+			want: false,
+		},
+		{
+			name: "version listed explicitly",
+			pkg:  models.PackageInfo{Name: "Flask", Version: "0.12", Ecosystem: "PyPI"},
+			vuln: models.Vulnerability{ID: "PYSEC-1", Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: models.EcosystemPyPI, Name: "flask"}, Versions: []string{"0.12"}},
+			}},
+			want: true,
+		},
+		{
+			name: "version not listed",
+			pkg:  models.PackageInfo{Name: "Flask", Version: "1.0", Ecosystem: "PyPI"},
+			vuln: models.Vulnerability{ID: "PYSEC-1", Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: models.EcosystemPyPI, Name: "flask"}, Versions: []string{"0.12"}},
+			}},
+			want: false,
+		},
+		{
+			name: "git ranges cannot be checked",
+			pkg:  models.PackageInfo{Name: "lodash", Version: "4.17.21", Ecosystem: "npm"},
+			vuln: models.Vulnerability{ID: "GHSA-1", Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: models.EcosystemNPM, Name: "lodash"}, Ranges: []models.Range{{Type: models.RangeGit}}},
+			}},
+			want: true,
+		},
+		{
+			name: "ecosystem versions cannot be compared",
+			pkg:  models.PackageInfo{Name: "mine", Version: "1.0.0", Ecosystem: "SwiftURL"},
+			vuln: models.Vulnerability{ID: "OSV-1", Affected: []models.Affected{
+				{Package: models.Package{Ecosystem: "SwiftURL", Name: "mine"}, Ranges: semverRange("0", "0.1.0")},
+			}},
+			want: true,
+		},
+		{
+			name: "commit",
+			pkg:  models.PackageInfo{Commit: "abc123"},
+			vuln: models.Vulnerability{ID: "OSV-1"},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := affectsInstalledVersion(tt.pkg, tt.vuln); got != tt.want {
+				t.Errorf("affectsInstalledVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_filterUnaffected(t *testing.T) {
+	t.Parallel()
+
+	affected := []models.Affected{{
+		Package: models.Package{Ecosystem: models.EcosystemNPM, Name: "lodash"},
+		Ranges:  []models.Range{{Type: models.RangeSemVer, Events: []models.Event{{Introduced: "0"}, {Fixed: "4.17.21"}}}},
+	}}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GHSA-1", Affected: affected}},
+						Groups:          []models.GroupInfo{{IDs: []string{"GHSA-1"}}},
+					},
+					{
+						Package:         models.PackageInfo{Name: "lodash", Version: "4.17.21", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GHSA-1", Affected: affected}},
+						Groups:          []models.GroupInfo{{IDs: []string{"GHSA-1"}}},
+					},
+				},
+			},
+		},
+	}
+
+	if pruned := filterUnaffected(&results, false); pruned != 1 {
+		t.Errorf("filterUnaffected() = %d, want 1", pruned)
+	}
+
+	packages := results.Results[0].Packages
+	if len(packages) != 1 || packages[0].Package.Version != "4.17.20" {
+		t.Errorf("filterUnaffected() kept %v, want only the affected version", packages)
+	}
+}
@@ -39,27 +39,42 @@ func buildVulnerabilityResults(
 			pkg.Package, err = models.PURLToPackage(rawPkg.PURL)
 
 			if err != nil {
-				r.Errorf("Failed to parse purl: %s, with error: %s", rawPkg.PURL, err)
+				r.Warnf("Failed to parse purl: %s, with error: %s\n", rawPkg.PURL, err)
 				continue
 			}
 		}
 
 		if rawPkg.Version != "" && rawPkg.Ecosystem != "" {
 			pkg.Package = models.PackageInfo{
-				Name:      rawPkg.Name,
-				Version:   rawPkg.Version,
-				Ecosystem: string(rawPkg.Ecosystem),
+				Name:              rawPkg.Name,
+				Version:           rawPkg.Version,
+				Ecosystem:         string(rawPkg.Ecosystem),
+				ImageLayerDigest:  rawPkg.ImageLayer.Digest,
+				ImageLayerCommand: rawPkg.ImageLayer.Command,
 			}
 		}
 
 		pkg.DepGroups = rawPkg.DepGroups
 
+		if actions.ShowDependencyRelationship {
+			pkg.DependencyRelationship = string(rawPkg.DepRelationship)
+		}
+
 		if len(vulnsResp.Results[i].Vulns) > 0 {
 			includePackage = true
 			pkg.Vulnerabilities = vulnsResp.Results[i].Vulns
 			pkg.Groups = grouper.Group(grouper.ConvertVulnerabilityToIDAliases(pkg.Vulnerabilities))
 			for i, group := range pkg.Groups {
-				pkg.Groups[i].MaxSeverity = output.MaxSeverity(group, pkg)
+				pkg.Groups[i].MaxSeverity = output.MaxSeverity(group, pkg, cvssVersionSeverityTypes[actions.CVSSVersion])
+				if actions.ShowAffectedRanges {
+					pkg.Groups[i].AffectedRanges = output.AffectedRanges(group, pkg)
+				}
+				if actions.ShowSummary {
+					pkg.Groups[i].Summary = output.GroupSummary(group, pkg)
+				}
+				if actions.ShowRelated {
+					pkg.Groups[i].Related = output.GroupRelated(group, pkg)
+				}
 			}
 		}
 		if len(actions.ScanLicensesAllowlist) > 0 {
@@ -77,6 +92,21 @@ func buildVulnerabilityResults(
 				includePackage = true
 			}
 		}
+		if len(actions.ScanLicensesDenylist) > 0 {
+			pkg.Licenses = licensesResp[i]
+			denylist := make(map[string]bool)
+			for _, license := range actions.ScanLicensesDenylist {
+				denylist[strings.ToLower(license)] = true
+			}
+			for _, license := range pkg.Licenses {
+				if denylist[strings.ToLower(string(license))] {
+					pkg.LicenseViolations = append(pkg.LicenseViolations, license)
+				}
+			}
+			if len(pkg.LicenseViolations) > 0 {
+				includePackage = true
+			}
+		}
 		if actions.ScanLicensesSummary {
 			pkg.Licenses = licensesResp[i]
 		}
@@ -86,7 +116,7 @@ func buildVulnerabilityResults(
 	}
 
 	for source, packages := range groupedBySource {
-		sourceanalysis.Run(r, source, packages, actions.CallAnalysisStates)
+		sourceanalysis.Run(r, source, packages, actions.CallAnalysisStates, actions.ShowCallPaths)
 		results.Results = append(results.Results, models.PackageSource{
 			Source:   source,
 			Packages: packages,
@@ -101,13 +131,20 @@ func buildVulnerabilityResults(
 		return results.Results[i].Source.Path < results.Results[j].Source.Path
 	})
 
-	if len(actions.ScanLicensesAllowlist) > 0 || actions.ScanLicensesSummary {
+	if actions.checksLicenseViolations() || actions.ScanLicensesSummary {
 		results.ExperimentalAnalysisConfig.Licenses.Summary = actions.ScanLicensesSummary
 		allowlist := make([]models.License, len(actions.ScanLicensesAllowlist))
 		for i, l := range actions.ScanLicensesAllowlist {
 			allowlist[i] = models.License(l)
 		}
 		results.ExperimentalAnalysisConfig.Licenses.Allowlist = allowlist
+		if len(actions.ScanLicensesDenylist) > 0 {
+			denylist := make([]models.License, len(actions.ScanLicensesDenylist))
+			for i, l := range actions.ScanLicensesDenylist {
+				denylist[i] = models.License(l)
+			}
+			results.ExperimentalAnalysisConfig.Licenses.Denylist = denylist
+		}
 	}
 
 	return results
@@ -488,6 +488,88 @@ func Test_assembleResult(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "group vulnerabilities with license denylist",
+		args: args{
+			r:            &reporter.VoidReporter{},
+			packages:     packages,
+			vulnsResp:    vulnsResp,
+			licensesResp: licensesResp,
+			actions: ScannerActions{
+				ExperimentalScannerActions: ExperimentalScannerActions{
+					ShowAllPackages:      false,
+					ScanLicensesDenylist: []string{"0bsd"},
+				},
+				CallAnalysisStates: callAnalysisStates,
+			},
+		},
+		want: models.VulnerabilityResults{
+			ExperimentalAnalysisConfig: models.ExperimentalAnalysisConfig{
+				Licenses: models.ExperimentalLicenseConfig{
+					Allowlist: []models.License{},
+					Denylist:  []models.License{models.License("0bsd")},
+				},
+			},
+			Results: []models.PackageSource{
+				{
+					Source: models.SourceInfo{
+						Path: "dir/package-lock.json",
+						Type: "lockfile",
+					},
+					Packages: []models.PackageVulns{
+						{
+							Package: models.PackageInfo{
+								Name:      "pkg-1",
+								Ecosystem: "npm",
+								Version:   "1.0.0",
+							},
+							Vulnerabilities: []models.Vulnerability{
+								{
+									ID:      "GHSA-123",
+									Aliases: []string{"CVE-123"},
+								},
+								{
+									ID: "CVE-123",
+								},
+							},
+							Groups: []models.GroupInfo{
+								{
+									IDs:     []string{"CVE-123", "GHSA-123"},
+									Aliases: []string{"CVE-123", "GHSA-123"},
+								},
+							},
+							Licenses:          makeLicenses([]string{"MIT", "0BSD"}),
+							LicenseViolations: makeLicenses([]string{"0BSD"}),
+						},
+					},
+				},
+				{
+					Source: models.SourceInfo{
+						Path: "other-dir/package-lock.json",
+						Type: "lockfile",
+					},
+					Packages: []models.PackageVulns{
+						{
+							Package: models.PackageInfo{
+								Name:      "pkg-3",
+								Ecosystem: "npm",
+								Version:   "1.0.0",
+							},
+							Vulnerabilities: []models.Vulnerability{
+								{ID: "GHSA-456"},
+							},
+							Groups: []models.GroupInfo{
+								{
+									IDs:     []string{"GHSA-456"},
+									Aliases: []string{"GHSA-456"},
+								},
+							},
+							Licenses: makeLicenses([]string{"UNKNOWN"}),
+						},
+					},
+				},
+			},
+		},
 	}}
 	for _, tt := range tests {
 		tt := tt // Reinitialize for t.Parallel()
@@ -0,0 +1,121 @@
+package reporter
+
+import (
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// CallFilterReporter only prints the vulnerabilities that call analysis determined are
+// being called (or not called), while printing everything else with the reporter it wraps.
+//
+// Vulnerabilities that were not analysed count as being called, so when call analysis has
+// not been performed on any of the results, every vulnerability is treated as called.
+type CallFilterReporter struct {
+	Reporter
+
+	// whether the called vulnerabilities are printed rather than the uncalled ones
+	called bool
+}
+
+func NewCallFilterReporter(r Reporter, called bool) *CallFilterReporter {
+	return &CallFilterReporter{Reporter: r, called: called}
+}
+
+func (r *CallFilterReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	if !r.called && !hasCallAnalysis(vulnResult) {
+		r.Infof("Call analysis was not performed, so all vulnerabilities count as called and none will be shown as uncalled\n")
+	}
+
+	filtered := *vulnResult
+	filtered.Results = nil
+
+	for _, source := range vulnResult.Results {
+		if source, ok := r.filterSource(source); ok {
+			filtered.Results = append(filtered.Results, source)
+		}
+	}
+
+	return r.Reporter.PrintResult(&filtered)
+}
+
+// filterSource returns the source with only the vulnerabilities that should be printed,
+// returning false if none of its packages have anything left to be printed
+func (r *CallFilterReporter) filterSource(source models.PackageSource) (models.PackageSource, bool) {
+	var packages []models.PackageVulns
+
+	for _, pkg := range source.Packages {
+		if len(pkg.Groups) == 0 {
+			// there are no vulnerabilities to filter, such as for license violations
+			packages = append(packages, pkg)
+
+			continue
+		}
+
+		var groups []models.GroupInfo
+		ids := map[string]struct{}{}
+		for _, group := range pkg.Groups {
+			if group.IsCalled() == r.called {
+				groups = append(groups, group)
+				for _, id := range group.IDs {
+					ids[id] = struct{}{}
+				}
+			}
+		}
+
+		if len(groups) == 0 && len(pkg.LicenseViolations) == 0 {
+			continue
+		}
+
+		var vulns []models.Vulnerability
+		for _, vuln := range pkg.Vulnerabilities {
+			if _, ok := ids[vuln.ID]; ok {
+				vulns = append(vulns, vuln)
+			}
+		}
+
+		pkg.Groups = groups
+		pkg.Vulnerabilities = vulns
+		packages = append(packages, pkg)
+	}
+
+	source.Packages = packages
+
+	return source, len(packages) > 0
+}
+
+// hasCallAnalysis returns true if call analysis was performed on any of the vulnerabilities in the results
+func hasCallAnalysis(vulnResult *models.VulnerabilityResults) bool {
+	for _, source := range vulnResult.Results {
+		for _, pkg := range source.Packages {
+			for _, group := range pkg.Groups {
+				if len(group.ExperimentalAnalysis) > 0 {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// StreamingCallFilterReporter is a CallFilterReporter wrapping a StreamingReporter
+type StreamingCallFilterReporter struct {
+	*CallFilterReporter
+
+	streamer StreamingReporter
+}
+
+func NewStreamingCallFilterReporter(r StreamingReporter, called bool) *StreamingCallFilterReporter {
+	return &StreamingCallFilterReporter{
+		CallFilterReporter: NewCallFilterReporter(r, called),
+		streamer:           r,
+	}
+}
+
+func (r *StreamingCallFilterReporter) PrintSourceResult(source models.PackageSource) error {
+	source, ok := r.filterSource(source)
+	if !ok {
+		return nil
+	}
+
+	return r.streamer.PrintSourceResult(source)
+}
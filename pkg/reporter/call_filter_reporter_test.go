@@ -0,0 +1,164 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func callFilterResults(analysed bool) *models.VulnerabilityResults {
+	analysis := func(called bool) map[string]models.AnalysisInfo {
+		if !analysed {
+			return nil
+		}
+
+		return map[string]models.AnalysisInfo{"GHSA-1": {Called: called}}
+	}
+
+	return &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/go.mod", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "Go"},
+						Vulnerabilities: []models.Vulnerability{
+							{ID: "GHSA-1"},
+							{ID: "GHSA-2"},
+							{ID: "CVE-2"},
+						},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"GHSA-1"}, ExperimentalAnalysis: analysis(true)},
+							{IDs: []string{"CVE-2", "GHSA-2"}, ExperimentalAnalysis: analysis(false)},
+						},
+					},
+					{
+						Package: models.PackageInfo{Name: "mine2", Version: "1.0.0", Ecosystem: "Go"},
+						Vulnerabilities: []models.Vulnerability{
+							{ID: "GHSA-3"},
+						},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"GHSA-3"}, ExperimentalAnalysis: analysis(false)},
+						},
+					},
+					{
+						Package:           models.PackageInfo{Name: "mine3", Version: "1.0.0", Ecosystem: "Go"},
+						Licenses:          []models.License{"UNKNOWN"},
+						LicenseViolations: []models.License{"UNKNOWN"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCallFilterReporter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		analysed   bool
+		options    reporter.Options
+		wantIDs    map[string][]string
+		wantStderr string
+	}{
+		{
+			name:     "only called",
+			analysed: true,
+			options:  reporter.Options{OnlyCalled: true},
+			wantIDs: map[string][]string{
+				"mine1": {"GHSA-1"},
+				"mine3": nil,
+			},
+		},
+		{
+			name:     "only uncalled",
+			analysed: true,
+			options:  reporter.Options{OnlyUncalled: true},
+			wantIDs: map[string][]string{
+				"mine1": {"GHSA-2", "CVE-2"},
+				"mine2": {"GHSA-3"},
+				"mine3": nil,
+			},
+		},
+		{
+			name:     "only called without analysis",
+			analysed: false,
+			options:  reporter.Options{OnlyCalled: true},
+			wantIDs: map[string][]string{
+				"mine1": {"GHSA-1", "GHSA-2", "CVE-2"},
+				"mine2": {"GHSA-3"},
+				"mine3": nil,
+			},
+		},
+		{
+			name:     "only uncalled without analysis",
+			analysed: false,
+			options:  reporter.Options{OnlyUncalled: true},
+			wantIDs: map[string][]string{
+				"mine3": nil,
+			},
+			wantStderr: "Call analysis was not performed",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			r, err := reporter.NewWithOptions("json", stdout, stderr, reporter.InfoLevel, 0, tt.options)
+
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+
+			if err := r.PrintResult(callFilterResults(tt.analysed)); err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+
+			var got models.VulnerabilityResults
+			if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+				t.Fatalf("Output is not valid JSON: %v", err)
+			}
+
+			gotIDs := map[string][]string{}
+			for _, source := range got.Results {
+				for _, pkg := range source.Packages {
+					var ids []string
+					for _, vuln := range pkg.Vulnerabilities {
+						ids = append(ids, vuln.ID)
+					}
+					gotIDs[pkg.Package.Name] = ids
+				}
+			}
+
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("PrintResult() printed unexpected vulnerabilities (-want +got):\n%s", diff)
+			}
+
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Expected stderr to contain %q, but got:\n%s", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestNewWithOptions_OnlyCalledAndUncalled(t *testing.T) {
+	t.Parallel()
+
+	_, err := reporter.NewWithOptions("table", &bytes.Buffer{}, &bytes.Buffer{}, reporter.InfoLevel, 0, reporter.Options{
+		OnlyCalled:   true,
+		OnlyUncalled: true,
+	})
+
+	if err == nil {
+		t.Errorf("Expected an error when both OnlyCalled and OnlyUncalled are set")
+	}
+}
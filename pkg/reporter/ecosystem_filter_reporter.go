@@ -0,0 +1,104 @@
+package reporter
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// EcosystemFilterReporter only prints the packages that are from one of the given ecosystems,
+// while printing everything else with the reporter it wraps.
+//
+// This only changes what is shown, so every package is still scanned and counts towards the exit code.
+type EcosystemFilterReporter struct {
+	Reporter
+
+	ecosystems []string
+}
+
+func NewEcosystemFilterReporter(r Reporter, ecosystems []string) *EcosystemFilterReporter {
+	return &EcosystemFilterReporter{Reporter: r, ecosystems: ecosystems}
+}
+
+func (r *EcosystemFilterReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	filtered := *vulnResult
+	filtered.Results = nil
+
+	hiddenPackages, hiddenVulns := 0, 0
+	for _, source := range vulnResult.Results {
+		for _, pkg := range source.Packages {
+			if !r.includes(pkg.Package) {
+				hiddenPackages++
+				hiddenVulns += len(pkg.Vulnerabilities)
+			}
+		}
+
+		if source, ok := r.filterSource(source); ok {
+			filtered.Results = append(filtered.Results, source)
+		}
+	}
+
+	if hiddenPackages > 0 {
+		r.Infof(
+			"Not showing %d %s with %d %s from ecosystems other than %s\n",
+			hiddenPackages,
+			output.Form(hiddenPackages, "package", "packages"),
+			hiddenVulns,
+			output.Form(hiddenVulns, "vulnerability", "vulnerabilities"),
+			strings.Join(r.ecosystems, ", "),
+		)
+	}
+
+	return r.Reporter.PrintResult(&filtered)
+}
+
+// includes checks if the package is from one of the ecosystems to be printed,
+// ignoring case and any release suffix (e.g. "Debian:11")
+func (r *EcosystemFilterReporter) includes(pkg models.PackageInfo) bool {
+	base := string(pkg.BaseEcosystem())
+
+	return slices.ContainsFunc(r.ecosystems, func(e string) bool {
+		return strings.EqualFold(e, pkg.Ecosystem) || strings.EqualFold(e, base)
+	})
+}
+
+// filterSource returns the source with only the packages that should be printed,
+// returning false if none of its packages are left to be printed
+func (r *EcosystemFilterReporter) filterSource(source models.PackageSource) (models.PackageSource, bool) {
+	var packages []models.PackageVulns
+
+	for _, pkg := range source.Packages {
+		if r.includes(pkg.Package) {
+			packages = append(packages, pkg)
+		}
+	}
+
+	source.Packages = packages
+
+	return source, len(packages) > 0
+}
+
+// StreamingEcosystemFilterReporter is an EcosystemFilterReporter wrapping a StreamingReporter
+type StreamingEcosystemFilterReporter struct {
+	*EcosystemFilterReporter
+
+	streamer StreamingReporter
+}
+
+func NewStreamingEcosystemFilterReporter(r StreamingReporter, ecosystems []string) *StreamingEcosystemFilterReporter {
+	return &StreamingEcosystemFilterReporter{
+		EcosystemFilterReporter: NewEcosystemFilterReporter(r, ecosystems),
+		streamer:                r,
+	}
+}
+
+func (r *StreamingEcosystemFilterReporter) PrintSourceResult(source models.PackageSource) error {
+	source, ok := r.filterSource(source)
+	if !ok {
+		return nil
+	}
+
+	return r.streamer.PrintSourceResult(source)
+}
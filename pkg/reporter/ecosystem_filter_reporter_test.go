@@ -0,0 +1,135 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func ecosystemFilterResults() *models.VulnerabilityResults {
+	return &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/go.mod", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "Go"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GHSA-1"}},
+						Groups:          []models.GroupInfo{{IDs: []string{"GHSA-1"}}},
+					},
+				},
+			},
+			{
+				Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "mine2", Version: "1.0.0", Ecosystem: "npm"},
+						Vulnerabilities: []models.Vulnerability{{ID: "GHSA-2"}, {ID: "GHSA-3"}},
+						Groups:          []models.GroupInfo{{IDs: []string{"GHSA-2"}}, {IDs: []string{"GHSA-3"}}},
+					},
+				},
+			},
+			{
+				Source: models.SourceInfo{Path: "/path/to/status", Type: "os"},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "mine3", Version: "1.0.0", Ecosystem: "Debian:11"},
+						Vulnerabilities: []models.Vulnerability{{ID: "DSA-1"}},
+						Groups:          []models.GroupInfo{{IDs: []string{"DSA-1"}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEcosystemFilterReporter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ecosystems []string
+		wantIDs    map[string][]string
+		wantStderr string
+	}{
+		{
+			name:       "single ecosystem",
+			ecosystems: []string{"go"},
+			wantIDs: map[string][]string{
+				"mine1": {"GHSA-1"},
+			},
+			wantStderr: "Not showing 2 packages with 3 vulnerabilities from ecosystems other than go",
+		},
+		{
+			name:       "ecosystem with a release suffix",
+			ecosystems: []string{"npm", "Debian"},
+			wantIDs: map[string][]string{
+				"mine2": {"GHSA-2", "GHSA-3"},
+				"mine3": {"DSA-1"},
+			},
+			wantStderr: "Not showing 1 package with 1 vulnerability from ecosystems other than npm, Debian",
+		},
+		{
+			name:       "every ecosystem",
+			ecosystems: []string{"Go", "npm", "Debian:11"},
+			wantIDs: map[string][]string{
+				"mine1": {"GHSA-1"},
+				"mine2": {"GHSA-2", "GHSA-3"},
+				"mine3": {"DSA-1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			r, err := reporter.NewWithOptions("json", stdout, stderr, reporter.InfoLevel, 0, reporter.Options{
+				OnlyEcosystems: tt.ecosystems,
+			})
+
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+
+			if err := r.PrintResult(ecosystemFilterResults()); err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+
+			var got models.VulnerabilityResults
+			if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+				t.Fatalf("Output is not valid JSON: %v", err)
+			}
+
+			gotIDs := map[string][]string{}
+			for _, source := range got.Results {
+				for _, pkg := range source.Packages {
+					var ids []string
+					for _, vuln := range pkg.Vulnerabilities {
+						ids = append(ids, vuln.ID)
+					}
+					gotIDs[pkg.Package.Name] = ids
+				}
+			}
+
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("PrintResult() printed unexpected vulnerabilities (-want +got):\n%s", diff)
+			}
+
+			if tt.wantStderr == "" && strings.Contains(stderr.String(), "Not showing") {
+				t.Errorf("Expected no filtered counts, but got:\n%s", stderr.String())
+			}
+
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Expected stderr to contain %q, but got:\n%s", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
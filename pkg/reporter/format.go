@@ -1,8 +1,12 @@
 package reporter
 
 import (
+	"errors"
 	"fmt"
 	"io"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/pkg/models"
 )
 
 var format = []string{"table", "json", "markdown", "sarif", "gh-annotations"}
@@ -11,20 +15,187 @@ func Format() []string {
 	return format
 }
 
+// Options controls optional parts of the output, which are ignored by reporters they do not apply to
+type Options struct {
+	// ShowAliases includes the aliases of each vulnerability in the table and markdown outputs
+	ShowAliases bool
+	// BasePath is the path that source paths are shown relative to, rather than the working directory
+	BasePath string
+	// Stream prints the results of each source as soon as it has been scanned, for the table, markdown and json formats
+	Stream bool
+	// ShowDependencyRelationship indicates if each package is a direct or transitive dependency in the table and markdown outputs
+	ShowDependencyRelationship bool
+	// ShowAffectedRanges includes the affected version ranges of each vulnerable package in the table and markdown outputs
+	ShowAffectedRanges bool
+	// ShowSummary includes the summary of each vulnerability in the table and markdown outputs
+	ShowSummary bool
+	// ShowRelated includes the IDs of related vulnerabilities as a note in the table and markdown outputs
+	ShowRelated bool
+	// ShowCallPaths includes the call path to each called vulnerable symbol as a note in the table and markdown outputs
+	ShowCallPaths bool
+	// LogFormat is the format runtime information is printed in, which can be "text" (the default) or "json"
+	LogFormat string
+	// MaxVulns limits the number of vulnerabilities that are shown in the table and markdown outputs, with 0 meaning no limit
+	MaxVulns int
+	// OnlyCalled only shows the vulnerabilities that call analysis determined are being called
+	OnlyCalled bool
+	// OnlyUncalled only shows the vulnerabilities that call analysis determined are not being called,
+	// and cannot be used together with OnlyCalled
+	OnlyUncalled bool
+	// OnlyEcosystems only shows the packages from these ecosystems, without changing what is scanned
+	OnlyEcosystems []string
+	// JSONCompact prints the json output on a single line without indentation
+	JSONCompact bool
+	// WebhookURL is where a summary of the results is posted to after they have been printed, if set
+	WebhookURL string
+	// WebhookOn is when the summary is posted to the webhook, which is WebhookOnAlways (the default) or WebhookOnFindings
+	WebhookOn string
+	// Metadata describes the build of osv-scanner and how it was run, which is included in the json and sarif outputs
+	Metadata *models.ScanMetadata
+}
+
+func (o Options) tableOptions() output.TableOptions {
+	return output.TableOptions{
+		ShowAliases:                o.ShowAliases,
+		BasePath:                   o.BasePath,
+		ShowDependencyRelationship: o.ShowDependencyRelationship,
+		ShowAffectedRanges:         o.ShowAffectedRanges,
+		ShowSummary:                o.ShowSummary,
+		ShowRelated:                o.ShowRelated,
+		ShowCallPaths:              o.ShowCallPaths,
+		MaxVulns:                   o.MaxVulns,
+	}
+}
+
+func (o Options) jsonOptions() output.JSONOptions {
+	return output.JSONOptions{
+		Compact:  o.JSONCompact,
+		Metadata: o.Metadata,
+	}
+}
+
+func (o Options) webhookOptions() output.WebhookOptions {
+	return output.WebhookOptions{
+		BasePath: o.BasePath,
+	}
+}
+
+func (o Options) sarifOptions() output.SARIFOptions {
+	return output.SARIFOptions{
+		BasePath: o.BasePath,
+		Metadata: o.Metadata,
+	}
+}
+
+func (o Options) ghAnnotationOptions() output.GHAnnotationOptions {
+	return output.GHAnnotationOptions{
+		BasePath: o.BasePath,
+	}
+}
+
 // New returns an implementation of the reporter interface depending on the format passed in
 // set terminalWidth as 0 to indicate the output is not a terminal
 func New(format string, stdout, stderr io.Writer, level VerbosityLevel, terminalWidth int) (Reporter, error) {
+	return NewWithOptions(format, stdout, stderr, level, terminalWidth, Options{})
+}
+
+// NewWithOptions is like New, but allows configuring optional parts of the output
+func NewWithOptions(format string, stdout, stderr io.Writer, level VerbosityLevel, terminalWidth int, options Options) (Reporter, error) {
+	if options.OnlyCalled && options.OnlyUncalled {
+		return nil, errors.New("only called and only uncalled vulnerabilities cannot both be shown")
+	}
+
+	r, err := newReporter(format, stdout, stderr, level, terminalWidth, options)
+	if err != nil {
+		return nil, err
+	}
+
+	switch options.LogFormat {
+	case "", "text":
+	case "json":
+		if streamer, ok := r.(StreamingReporter); ok {
+			r = NewStreamingJSONLogReporter(streamer, stderr, level)
+		} else {
+			r = NewJSONLogReporter(r, stderr, level)
+		}
+	default:
+		return nil, fmt.Errorf("%v is not a valid log format", options.LogFormat)
+	}
+
+	if len(options.OnlyEcosystems) > 0 {
+		if streamer, ok := r.(StreamingReporter); ok {
+			r = NewStreamingEcosystemFilterReporter(streamer, options.OnlyEcosystems)
+		} else {
+			r = NewEcosystemFilterReporter(r, options.OnlyEcosystems)
+		}
+	}
+
+	if options.OnlyCalled || options.OnlyUncalled {
+		if streamer, ok := r.(StreamingReporter); ok {
+			r = NewStreamingCallFilterReporter(streamer, options.OnlyCalled)
+		} else {
+			r = NewCallFilterReporter(r, options.OnlyCalled)
+		}
+	}
+
+	// the webhook is given all of the results, as what is shown does not change what was found
+	if options.WebhookURL != "" {
+		if streamer, ok := r.(StreamingReporter); ok {
+			webhook, err := NewStreamingWebhookReporter(streamer, options.WebhookURL, options.WebhookOn, options.webhookOptions())
+			if err != nil {
+				return nil, err
+			}
+
+			return webhook, nil
+		}
+
+		webhook, err := NewWebhookReporter(r, options.WebhookURL, options.WebhookOn, options.webhookOptions())
+		if err != nil {
+			return nil, err
+		}
+
+		return webhook, nil
+	}
+
+	return r, nil
+}
+
+func newReporter(format string, stdout, stderr io.Writer, level VerbosityLevel, terminalWidth int, options Options) (Reporter, error) {
 	switch format {
 	case "json":
-		return NewJSONReporter(stdout, stderr, level), nil
+		r := NewJSONReporter(stdout, stderr, level)
+		r.options = options
+		if options.Stream {
+			return NewStreamingJSONReporter(r), nil
+		}
+
+		return r, nil
 	case "table":
-		return NewTableReporter(stdout, stderr, level, false, terminalWidth), nil
+		r := NewTableReporter(stdout, stderr, level, false, terminalWidth)
+		r.options = options
+		if options.Stream {
+			return NewStreamingTableReporter(r), nil
+		}
+
+		return r, nil
 	case "markdown":
-		return NewTableReporter(stdout, stderr, level, true, terminalWidth), nil
+		r := NewTableReporter(stdout, stderr, level, true, terminalWidth)
+		r.options = options
+		if options.Stream {
+			return NewStreamingTableReporter(r), nil
+		}
+
+		return r, nil
 	case "sarif":
-		return NewSarifReporter(stdout, stderr, level), nil
+		r := NewSarifReporter(stdout, stderr, level)
+		r.options = options
+
+		return r, nil
 	case "gh-annotations":
-		return NewGHAnnotationsReporter(stdout, stderr, level), nil
+		r := NewGHAnnotationsReporter(stdout, stderr, level)
+		r.options = options
+
+		return r, nil
 	default:
 		return nil, fmt.Errorf("%v is not a valid format", format)
 	}
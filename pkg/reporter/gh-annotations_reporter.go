@@ -13,6 +13,7 @@ type GHAnnotationsReporter struct {
 	stdout     io.Writer
 	stderr     io.Writer
 	level      VerbosityLevel
+	options    Options
 }
 
 func NewGHAnnotationsReporter(stdout io.Writer, stderr io.Writer, level VerbosityLevel) *GHAnnotationsReporter {
@@ -52,5 +53,12 @@ func (r *GHAnnotationsReporter) Verbosef(format string, a ...any) {
 }
 
 func (r *GHAnnotationsReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
-	return output.PrintGHAnnotationReport(vulnResult, r.stderr)
+	// Annotations are for what needs fixing, so filtered vulnerabilities are left out
+	unfiltered := vulnResult.WithoutFiltered()
+
+	return output.PrintGHAnnotationReport(&unfiltered, r.stderr, r.options.ghAnnotationOptions())
+}
+
+// SetFooter does nothing, as the output is meant to be read by GitHub as workflow commands
+func (r *GHAnnotationsReporter) SetFooter(footer string) {
 }
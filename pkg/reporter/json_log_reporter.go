@@ -0,0 +1,114 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+var logFormats = []string{"text", "json"}
+
+func LogFormats() []string {
+	return logFormats
+}
+
+// jsonLogRecord is a single diagnostic message printed by a JSONLogReporter
+type jsonLogRecord struct {
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+}
+
+// erroredMarker is implemented by reporters that print their results differently if
+// there have been any errors, so they can be told about errors printed on their behalf
+type erroredMarker interface {
+	markErrored()
+}
+
+// JSONLogReporter prints runtime information as JSON lines to stderr (with "level", "message" and "timestamp"
+// fields), while printing vulnerability results with the reporter it wraps.
+type JSONLogReporter struct {
+	Reporter
+
+	hasErrored bool
+	stderr     io.Writer
+	level      VerbosityLevel
+}
+
+func NewJSONLogReporter(r Reporter, stderr io.Writer, level VerbosityLevel) *JSONLogReporter {
+	return &JSONLogReporter{
+		Reporter: r,
+		stderr:   stderr,
+		level:    level,
+	}
+}
+
+func (r *JSONLogReporter) log(level string, format string, a ...any) {
+	record := jsonLogRecord{
+		Level:     level,
+		Message:   strings.TrimRight(fmt.Sprintf(format, a...), "\n"),
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		// this should never happen, as the record only has string fields
+		fmt.Fprintf(r.stderr, format, a...)
+		return
+	}
+
+	fmt.Fprintf(r.stderr, "%s\n", b)
+}
+
+func (r *JSONLogReporter) Errorf(format string, a ...any) {
+	r.log("error", format, a...)
+	r.hasErrored = true
+
+	if m, ok := r.Reporter.(erroredMarker); ok {
+		m.markErrored()
+	}
+}
+
+func (r *JSONLogReporter) HasErrored() bool {
+	return r.hasErrored || r.Reporter.HasErrored()
+}
+
+func (r *JSONLogReporter) Warnf(format string, a ...any) {
+	if WarnLevel <= r.level {
+		r.log("warn", format, a...)
+	}
+}
+
+func (r *JSONLogReporter) Infof(format string, a ...any) {
+	if InfoLevel <= r.level {
+		r.log("info", format, a...)
+	}
+}
+
+func (r *JSONLogReporter) Verbosef(format string, a ...any) {
+	if VerboseLevel <= r.level {
+		r.log("verbose", format, a...)
+	}
+}
+
+// StreamingJSONLogReporter is a JSONLogReporter wrapping a StreamingReporter
+type StreamingJSONLogReporter struct {
+	*JSONLogReporter
+
+	streamer StreamingReporter
+}
+
+func NewStreamingJSONLogReporter(r StreamingReporter, stderr io.Writer, level VerbosityLevel) *StreamingJSONLogReporter {
+	return &StreamingJSONLogReporter{
+		JSONLogReporter: NewJSONLogReporter(r, stderr, level),
+		streamer:        r,
+	}
+}
+
+func (r *StreamingJSONLogReporter) PrintSourceResult(source models.PackageSource) error {
+	return r.streamer.PrintSourceResult(source)
+}
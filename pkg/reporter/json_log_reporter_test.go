@@ -0,0 +1,103 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func TestJSONLogReporter(t *testing.T) {
+	t.Parallel()
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	r, err := reporter.NewWithOptions("table", stdout, stderr, reporter.InfoLevel, 0, reporter.Options{LogFormat: "json"})
+
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	r.Infof("Scanned %s file and found %d packages\n", "/path/to/lockfile", 2)
+	r.Warnf("this is a warning\n")
+	r.Verbosef("this should not be printed\n")
+	r.Errorf("this is an error\n")
+
+	if !r.HasErrored() {
+		t.Errorf("Expected reporter to have errored")
+	}
+
+	if err := r.PrintResult(&models.VulnerabilityResults{}); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	// the results are still printed in the table format, which should not say there
+	// are no issues as an error was logged
+	if stdout.String() != "" {
+		t.Errorf("Expected nothing to be printed to stdout, but got:\n%s", stdout.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+
+	want := []struct {
+		level   string
+		message string
+	}{
+		{level: "info", message: "Scanned /path/to/lockfile file and found 2 packages"},
+		{level: "warn", message: "this is a warning"},
+		{level: "error", message: "this is an error"},
+	}
+
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d lines of JSON, but got %d:\n%s", len(want), len(lines), stderr.String())
+	}
+
+	for i, w := range want {
+		var record struct {
+			Level     string `json:"level"`
+			Message   string `json:"message"`
+			Timestamp string `json:"timestamp"`
+		}
+
+		if err := json.Unmarshal([]byte(lines[i]), &record); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", i, err)
+		}
+
+		if record.Level != w.level || record.Message != w.message {
+			t.Errorf("Expected line %d to be a %q message of %q, but got %s", i, w.level, w.message, lines[i])
+		}
+
+		if _, err := time.Parse(time.RFC3339Nano, record.Timestamp); err != nil {
+			t.Errorf("Expected line %d to have a valid timestamp, but got %s", i, lines[i])
+		}
+	}
+}
+
+func TestJSONLogReporter_Stream(t *testing.T) {
+	t.Parallel()
+
+	r, err := reporter.NewWithOptions("json", io.Discard, io.Discard, reporter.InfoLevel, 0, reporter.Options{LogFormat: "json", Stream: true})
+
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if _, ok := r.(reporter.StreamingReporter); !ok {
+		t.Errorf("Expected reporter to still support streaming")
+	}
+}
+
+func TestNewWithOptions_InvalidLogFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := reporter.NewWithOptions("table", io.Discard, io.Discard, reporter.InfoLevel, 0, reporter.Options{LogFormat: "xml"})
+
+	if err == nil {
+		t.Errorf("Expected an error for an invalid log format")
+	}
+}
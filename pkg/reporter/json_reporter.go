@@ -15,6 +15,7 @@ type JSONReporter struct {
 	stdout     io.Writer
 	stderr     io.Writer
 	level      VerbosityLevel
+	options    Options
 }
 
 func NewJSONReporter(stdout io.Writer, stderr io.Writer, level VerbosityLevel) *JSONReporter {
@@ -54,5 +55,9 @@ func (r *JSONReporter) Verbosef(format string, a ...any) {
 }
 
 func (r *JSONReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
-	return output.PrintJSONResults(vulnResult, r.stdout)
+	return output.PrintJSONResults(vulnResult, r.stdout, r.options.jsonOptions())
+}
+
+// SetFooter does nothing, as anything printed after the results would make them invalid JSON
+func (r *JSONReporter) SetFooter(footer string) {
 }
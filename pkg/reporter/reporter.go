@@ -24,6 +24,9 @@ type Reporter interface {
 	// since what happens to the error message is up to the actual reporter.
 	HasErrored() bool
 	// Warnf prints text indicating potential issues or something that should be brought to the attention of users.
+	//
+	// Unlike Errorf, this does not affect HasErrored, so it should be used for recoverable problems
+	// (such as a single file that could not be parsed) that do not stop the scan from succeeding.
 	Warnf(format string, a ...any)
 	// Infof prints text providing general information about what OSV-Scanner is doing during its runtime.
 	Infof(format string, a ...any)
@@ -32,4 +35,21 @@ type Reporter interface {
 	// PrintResult prints the models.VulnerabilityResults per the logic of the
 	// actual reporter
 	PrintResult(vulnResult *models.VulnerabilityResults) error
+	// SetFooter sets a message (such as where to get help with the results) to be printed
+	// after the results and any summary by PrintResult.
+	//
+	// The footer is only printed by reporters for human formats (such as the table and markdown
+	// formats), and is ignored by "machine format" reporters so their output remains valid.
+	SetFooter(footer string)
+}
+
+// StreamingReporter is a Reporter that can print the results of each source as soon as
+// that source has been scanned, rather than waiting for the whole scan to finish.
+//
+// PrintResult is still called with all the results once the scan has finished, and
+// should only print what has not already been printed by PrintSourceResult.
+type StreamingReporter interface {
+	Reporter
+	// PrintSourceResult prints the results of a single source per the logic of the actual reporter
+	PrintSourceResult(source models.PackageSource) error
 }
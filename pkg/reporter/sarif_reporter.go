@@ -13,6 +13,7 @@ type SARIFReporter struct {
 	stdout     io.Writer
 	stderr     io.Writer
 	level      VerbosityLevel
+	options    Options
 }
 
 func NewSarifReporter(stdout io.Writer, stderr io.Writer, level VerbosityLevel) *SARIFReporter {
@@ -52,5 +53,9 @@ func (r *SARIFReporter) Verbosef(format string, a ...any) {
 }
 
 func (r *SARIFReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
-	return output.PrintSARIFReport(vulnResult, r.stdout)
+	return output.PrintSARIFReport(vulnResult, r.stdout, r.options.sarifOptions())
+}
+
+// SetFooter does nothing, as a SARIF report has no place for free-form text after the results
+func (r *SARIFReporter) SetFooter(footer string) {
 }
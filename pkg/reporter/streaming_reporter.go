@@ -0,0 +1,134 @@
+package reporter
+
+import (
+	"fmt"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// streamedSources tracks the sources that have already been printed by a StreamingReporter
+type streamedSources map[models.SourceInfo]struct{}
+
+// remaining returns the sources in the results that have not been streamed yet
+func (s streamedSources) remaining(vulnResult *models.VulnerabilityResults) []models.PackageSource {
+	var sources []models.PackageSource
+
+	for _, source := range vulnResult.Results {
+		if _, ok := s[source.Source]; !ok {
+			sources = append(sources, source)
+		}
+	}
+
+	return sources
+}
+
+// StreamingTableReporter prints the vulnerabilities of each source in its own table as soon as
+// it has been scanned, followed by any license information once the scan has finished.
+//
+// MaxVulns limits the number of vulnerabilities shown across all of the tables, rather than in each one.
+type StreamingTableReporter struct {
+	*TableReporter
+
+	streamed streamedSources
+	// shownVulns is the number of vulnerability rows that have been printed so far
+	shownVulns int
+	// hiddenVulns is the number of vulnerability rows of sources that were not printed
+	// at all as MaxVulns had already been reached
+	hiddenVulns int
+}
+
+func NewStreamingTableReporter(r *TableReporter) *StreamingTableReporter {
+	return &StreamingTableReporter{TableReporter: r, streamed: streamedSources{}}
+}
+
+func (r *StreamingTableReporter) PrintSourceResult(source models.PackageSource) error {
+	r.streamed[source.Source] = struct{}{}
+
+	results := &models.VulnerabilityResults{Results: []models.PackageSource{source}}
+	unfiltered := results.WithoutFiltered()
+	results = &unfiltered
+
+	options := r.options.tableOptions()
+
+	if options.MaxVulns > 0 {
+		rows := output.CountTableRows(results, options)
+		remaining := options.MaxVulns - r.shownVulns
+
+		if remaining <= 0 {
+			r.hiddenVulns += rows
+
+			return nil
+		}
+
+		options.MaxVulns = remaining
+		r.shownVulns += min(rows, remaining)
+	}
+
+	if r.markdown {
+		output.PrintMarkdownTableResults(results, r.stdout, options)
+	} else {
+		output.PrintTableResults(results, r.stdout, r.terminalWidth, options)
+	}
+
+	return nil
+}
+
+func (r *StreamingTableReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	if unfiltered := vulnResult.WithoutFiltered(); len(unfiltered.Results) == 0 && !r.hasErrored {
+		fmt.Fprintf(r.stdout, "No issues found\n")
+		r.printFooter()
+
+		return nil
+	}
+
+	for _, source := range r.streamed.remaining(vulnResult) {
+		if err := r.PrintSourceResult(source); err != nil {
+			return err
+		}
+	}
+
+	if r.hiddenVulns > 0 {
+		fmt.Fprintf(r.stdout, "... and %d more in other sources\n", r.hiddenVulns)
+	}
+
+	if r.markdown {
+		output.PrintMarkdownLicenseTableResults(vulnResult, r.stdout, r.options.tableOptions())
+	} else {
+		output.PrintLicenseTableResults(vulnResult, r.stdout, r.terminalWidth, r.options.tableOptions())
+	}
+	r.printFooter()
+
+	return nil
+}
+
+// StreamingJSONReporter prints the results of each source as a line of JSON as soon as it
+// has been scanned, followed by a final line with the metadata of the results once the scan has finished.
+type StreamingJSONReporter struct {
+	*JSONReporter
+
+	streamed streamedSources
+}
+
+func NewStreamingJSONReporter(r *JSONReporter) *StreamingJSONReporter {
+	return &StreamingJSONReporter{JSONReporter: r, streamed: streamedSources{}}
+}
+
+func (r *StreamingJSONReporter) PrintSourceResult(source models.PackageSource) error {
+	r.streamed[source.Source] = struct{}{}
+
+	return output.PrintJSONSourceResult(source, r.stdout)
+}
+
+func (r *StreamingJSONReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	for _, source := range r.streamed.remaining(vulnResult) {
+		if err := r.PrintSourceResult(source); err != nil {
+			return err
+		}
+	}
+
+	return output.PrintJSONStreamSummary(vulnResult, r.stdout, r.options.jsonOptions())
+}
+
+var _ StreamingReporter = &StreamingTableReporter{}
+var _ StreamingReporter = &StreamingJSONReporter{}
@@ -0,0 +1,166 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+func streamingTestResults() *models.VulnerabilityResults {
+	source := func(path string) models.PackageSource {
+		return models.PackageSource{
+			Source: models.SourceInfo{Path: path, Type: "lockfile"},
+			Packages: []models.PackageVulns{{
+				Package:         models.PackageInfo{Name: "mine1", Version: "1.0.0", Ecosystem: "npm"},
+				Vulnerabilities: []models.Vulnerability{{ID: "OSV-1"}},
+				Groups:          []models.GroupInfo{{IDs: []string{"OSV-1"}}},
+			}},
+		}
+	}
+
+	return &models.VulnerabilityResults{
+		Results: []models.PackageSource{source("/path/to/first/lockfile"), source("/path/to/second/lockfile")},
+	}
+}
+
+func TestNewWithOptions_Stream(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{"table", "markdown", "json"} {
+		r, err := reporter.NewWithOptions(format, io.Discard, io.Discard, reporter.InfoLevel, 0, reporter.Options{Stream: true})
+
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+
+		if _, ok := r.(reporter.StreamingReporter); !ok {
+			t.Errorf("Expected %s reporter to support streaming", format)
+		}
+	}
+
+	for _, format := range []string{"sarif", "gh-annotations"} {
+		r, err := reporter.NewWithOptions(format, io.Discard, io.Discard, reporter.InfoLevel, 0, reporter.Options{Stream: true})
+
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+
+		if _, ok := r.(reporter.StreamingReporter); ok {
+			t.Errorf("Expected %s reporter to not support streaming", format)
+		}
+	}
+}
+
+func TestStreamingTableReporter_PrintResult(t *testing.T) {
+	t.Parallel()
+
+	results := streamingTestResults()
+
+	writer := &bytes.Buffer{}
+	r := reporter.NewStreamingTableReporter(reporter.NewTableReporter(writer, io.Discard, reporter.InfoLevel, false, 0))
+
+	if err := r.PrintSourceResult(results.Results[0]); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if !strings.Contains(writer.String(), "first") || strings.Contains(writer.String(), "second") {
+		t.Errorf("Expected only the first source to have been printed, but got:\n%s", writer.String())
+	}
+
+	writer.Reset()
+
+	if err := r.PrintResult(results); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	// only the source that was not streamed should be printed
+	if strings.Contains(writer.String(), "first") || !strings.Contains(writer.String(), "second") {
+		t.Errorf("Expected only the second source to have been printed, but got:\n%s", writer.String())
+	}
+}
+
+func TestStreamingJSONReporter_PrintResult(t *testing.T) {
+	t.Parallel()
+
+	results := streamingTestResults()
+
+	writer := &bytes.Buffer{}
+	r := reporter.NewStreamingJSONReporter(reporter.NewJSONReporter(writer, io.Discard, reporter.InfoLevel))
+
+	if err := r.PrintSourceResult(results.Results[0]); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if err := r.PrintResult(results); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(writer.String()), "\n")
+
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines of JSON, but got %d:\n%s", len(lines), writer.String())
+	}
+
+	for i, want := range []string{"/path/to/first/lockfile", "/path/to/second/lockfile"} {
+		var source models.PackageSource
+		if err := json.Unmarshal([]byte(lines[i]), &source); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", i, err)
+		}
+
+		if source.Source.Path != want {
+			t.Errorf("Expected line %d to be for %s, but was for %s", i, want, source.Source.Path)
+		}
+	}
+
+	var summary map[string]any
+	if err := json.Unmarshal([]byte(lines[2]), &summary); err != nil {
+		t.Fatalf("Last line is not valid JSON: %v", err)
+	}
+
+	if _, ok := summary["schema_version"]; !ok {
+		t.Errorf("Expected the last line to include the schema version, but got %s", lines[2])
+	}
+}
+
+func TestStreamingTableReporter_MaxVulns(t *testing.T) {
+	t.Parallel()
+
+	results := streamingTestResults()
+
+	writer := &bytes.Buffer{}
+	r, err := reporter.NewWithOptions("table", writer, io.Discard, reporter.InfoLevel, 0, reporter.Options{Stream: true, MaxVulns: 1})
+
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	streamer, ok := r.(reporter.StreamingReporter)
+
+	if !ok {
+		t.Fatalf("Expected table reporter to support streaming")
+	}
+
+	for _, source := range results.Results {
+		if err := streamer.PrintSourceResult(source); err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+	}
+
+	if err := r.PrintResult(results); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	// the limit applies to all of the sources together, rather than to each one
+	if !strings.Contains(writer.String(), "first") || strings.Contains(writer.String(), "second") {
+		t.Errorf("Expected only the vulnerability of the first source to have been printed, but got:\n%s", writer.String())
+	}
+
+	if !strings.Contains(writer.String(), "... and 1 more in other sources") {
+		t.Errorf("Expected the vulnerability of the second source to be counted as not shown, but got:\n%s", writer.String())
+	}
+}
@@ -3,6 +3,7 @@ package reporter
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/google/osv-scanner/internal/output"
 	"github.com/google/osv-scanner/pkg/models"
@@ -16,6 +17,8 @@ type TableReporter struct {
 	markdown   bool
 	// 0 indicates not a terminal output
 	terminalWidth int
+	options       Options
+	footer        string
 }
 
 func NewTableReporter(stdout io.Writer, stderr io.Writer, level VerbosityLevel, markdown bool, terminalWidth int) *TableReporter {
@@ -38,6 +41,10 @@ func (r *TableReporter) HasErrored() bool {
 	return r.hasErrored
 }
 
+func (r *TableReporter) markErrored() {
+	r.hasErrored = true
+}
+
 func (r *TableReporter) Warnf(format string, a ...any) {
 	if WarnLevel <= r.level {
 		fmt.Fprintf(r.stdout, format, a...)
@@ -57,16 +64,36 @@ func (r *TableReporter) Verbosef(format string, a ...any) {
 }
 
 func (r *TableReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	// Filtered vulnerabilities are only recorded by the machine readable formats
+	unfiltered := vulnResult.WithoutFiltered()
+	vulnResult = &unfiltered
+
 	if len(vulnResult.Results) == 0 && !r.hasErrored {
 		fmt.Fprintf(r.stdout, "No issues found\n")
+		r.printFooter()
+
 		return nil
 	}
 
 	if r.markdown {
-		output.PrintMarkdownTableResults(vulnResult, r.stdout)
+		output.PrintMarkdownTableResults(vulnResult, r.stdout, r.options.tableOptions())
 	} else {
-		output.PrintTableResults(vulnResult, r.stdout, r.terminalWidth)
+		output.PrintTableResults(vulnResult, r.stdout, r.terminalWidth, r.options.tableOptions())
 	}
+	r.printFooter()
 
 	return nil
 }
+
+func (r *TableReporter) SetFooter(footer string) {
+	r.footer = footer
+}
+
+// printFooter prints the footer (if one has been set) on its own line after the results
+func (r *TableReporter) printFooter() {
+	if r.footer == "" {
+		return
+	}
+
+	fmt.Fprintf(r.stdout, "\n%s\n", strings.TrimRight(r.footer, "\n"))
+}
@@ -3,8 +3,10 @@ package reporter_test
 import (
 	"bytes"
 	"io"
+	"strings"
 	"testing"
 
+	"github.com/google/osv-scanner/pkg/models"
 	"github.com/google/osv-scanner/pkg/reporter"
 )
 
@@ -96,3 +98,30 @@ func TestTableReporter_Verbosef(t *testing.T) {
 		}
 	}
 }
+
+func TestTableReporter_SetFooter(t *testing.T) {
+	t.Parallel()
+
+	footer := "File a ticket at https://example.com/security"
+
+	for _, format := range []string{"table", "markdown", "json", "sarif"} {
+		for _, stream := range []bool{false, true} {
+			writer := &bytes.Buffer{}
+			r, err := reporter.NewWithOptions(format, writer, io.Discard, reporter.InfoLevel, 0, reporter.Options{Stream: stream})
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+
+			r.SetFooter(footer + "\n")
+
+			if err := r.PrintResult(&models.VulnerabilityResults{}); err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+
+			wantFooter := format == "table" || format == "markdown"
+			if got := strings.HasSuffix(writer.String(), "\n"+footer+"\n"); got != wantFooter {
+				t.Errorf("%s (stream: %v): expected footer to be printed last = %v, but got:\n%s", format, stream, wantFooter, writer.String())
+			}
+		}
+	}
+}
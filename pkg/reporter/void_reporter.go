@@ -28,3 +28,6 @@ func (r *VoidReporter) Verbosef(msg string, a ...any) {
 func (r *VoidReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
 	return nil
 }
+
+func (r *VoidReporter) SetFooter(footer string) {
+}
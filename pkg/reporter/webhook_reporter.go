@@ -0,0 +1,182 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/osv"
+)
+
+// The conditions that results can be posted to a webhook on
+const (
+	// WebhookOnAlways posts the results after every scan
+	WebhookOnAlways = "always"
+	// WebhookOnFindings only posts the results if vulnerabilities or license violations were found
+	WebhookOnFindings = "findings"
+)
+
+// maxWebhookRetries is how many times posting to a webhook is retried before giving up
+const maxWebhookRetries = 3
+
+// webhookRetryDelay is how long to wait before the first retry of posting to a webhook,
+// which is doubled for each retry after that
+const webhookRetryDelay = 500 * time.Millisecond
+
+// webhookTimeout is how long each attempt to post to a webhook can take
+const webhookTimeout = 30 * time.Second
+
+// WebhookReporter posts a summary of the results to a webhook once they have been printed
+// by the reporter it wraps, such as to notify a chat channel.
+//
+// Failing to post the summary is only a warning, so that an unavailable webhook does not fail
+// the scan, and the URL is never printed as it often includes a secret token.
+type WebhookReporter struct {
+	Reporter
+
+	webhookURL string
+	on         string
+	options    output.WebhookOptions
+	client     *http.Client
+}
+
+func NewWebhookReporter(r Reporter, webhookURL string, on string, options output.WebhookOptions) (*WebhookReporter, error) {
+	switch on {
+	case "":
+		on = WebhookOnAlways
+	case WebhookOnAlways, WebhookOnFindings:
+	default:
+		return nil, fmt.Errorf("%v is not a valid webhook trigger, must be %s or %s", on, WebhookOnAlways, WebhookOnFindings)
+	}
+
+	// the url is not included in the error, as it may include a secret token
+	if u, err := url.Parse(webhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("the webhook url must be an absolute http or https url")
+	}
+
+	return &WebhookReporter{
+		Reporter:   r,
+		webhookURL: webhookURL,
+		on:         on,
+		options:    options,
+		client:     &http.Client{Timeout: webhookTimeout},
+	}, nil
+}
+
+func (r *WebhookReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	if err := r.Reporter.PrintResult(vulnResult); err != nil {
+		return err
+	}
+
+	summary := output.NewWebhookSummary(vulnResult, r.options)
+	if r.on == WebhookOnFindings && !summary.HasFindings() {
+		r.Verbosef("Not posting the results to the webhook as nothing was found\n")
+
+		return nil
+	}
+
+	if err := r.post(summary); err != nil {
+		r.Warnf("Failed to post the results to the webhook: %v\n", err)
+
+		return nil
+	}
+
+	r.Verbosef("Posted the results to the webhook\n")
+
+	return nil
+}
+
+// webhookStatusError is an unsuccessful response from a webhook
+type webhookStatusError struct {
+	status     string
+	statusCode int
+}
+
+func (e webhookStatusError) Error() string {
+	return "webhook returned " + e.status
+}
+
+// retryable returns true if the webhook may accept the summary if it is posted again
+func (e webhookStatusError) retryable() bool {
+	return e.statusCode == http.StatusTooManyRequests || e.statusCode >= http.StatusInternalServerError
+}
+
+// post sends the summary to the webhook, retrying with increasing delays if it could not be
+// connected to or responded with a server error
+func (r *WebhookReporter) post(summary output.WebhookSummary) error {
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	delay := webhookRetryDelay
+	for retry := 0; ; retry++ {
+		err = r.postOnce(body)
+
+		var statusErr webhookStatusError
+		if err == nil || retry >= maxWebhookRetries || (errors.As(err, &statusErr) && !statusErr.retryable()) {
+			return err
+		}
+
+		r.Verbosef("Retrying posting the results to the webhook in %s: %v\n", delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+func (r *WebhookReporter) postOnce(body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, r.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if osv.RequestUserAgent != "" {
+		req.Header.Set("User-Agent", osv.RequestUserAgent)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		// the url is removed from the error, leaving only its host, as the rest may include a secret token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("could not post to %s: %w", req.URL.Host, urlErr.Err)
+		}
+
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return webhookStatusError{status: resp.Status, statusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+// StreamingWebhookReporter is a WebhookReporter wrapping a StreamingReporter
+type StreamingWebhookReporter struct {
+	*WebhookReporter
+
+	streamer StreamingReporter
+}
+
+func NewStreamingWebhookReporter(r StreamingReporter, webhookURL string, on string, options output.WebhookOptions) (*StreamingWebhookReporter, error) {
+	webhook, err := NewWebhookReporter(r, webhookURL, on, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamingWebhookReporter{WebhookReporter: webhook, streamer: r}, nil
+}
+
+func (r *StreamingWebhookReporter) PrintSourceResult(source models.PackageSource) error {
+	return r.streamer.PrintSourceResult(source)
+}
@@ -0,0 +1,242 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/osv-scanner/internal/output"
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// webhookServer records the summaries posted to it, responding with each of the statuses
+// in turn and then with 200 OK
+type webhookServer struct {
+	mu        sync.Mutex
+	statuses  []int
+	summaries []output.WebhookSummary
+}
+
+func (s *webhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var summary output.WebhookSummary
+	if err := json.NewDecoder(r.Body).Decode(&summary); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+	s.summaries = append(s.summaries, summary)
+
+	if len(s.statuses) > 0 {
+		w.WriteHeader(s.statuses[0])
+		s.statuses = s.statuses[1:]
+	}
+}
+
+func newWebhookReporter(t *testing.T, server *httptest.Server, on string, stderr io.Writer) reporter.Reporter {
+	t.Helper()
+
+	r, err := reporter.NewWithOptions("json", io.Discard, stderr, reporter.VerboseLevel, 0, reporter.Options{
+		WebhookURL: server.URL + "/secret-token",
+		WebhookOn:  on,
+	})
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	return r
+}
+
+func TestWebhookReporter_PrintResult(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		on        string
+		results   *models.VulnerabilityResults
+		statuses  []int
+		wantPosts int
+		wantWarn  bool
+	}{
+		{
+			name:      "always without findings",
+			on:        reporter.WebhookOnAlways,
+			results:   &models.VulnerabilityResults{},
+			wantPosts: 1,
+		},
+		{
+			name:      "findings without findings",
+			on:        reporter.WebhookOnFindings,
+			results:   &models.VulnerabilityResults{},
+			wantPosts: 0,
+		},
+		{
+			name:      "findings with findings",
+			on:        reporter.WebhookOnFindings,
+			results:   streamingTestResults(),
+			wantPosts: 1,
+		},
+		{
+			name:      "retried after a server error",
+			on:        reporter.WebhookOnAlways,
+			results:   streamingTestResults(),
+			statuses:  []int{http.StatusServiceUnavailable},
+			wantPosts: 2,
+		},
+		{
+			name:      "not retried after a client error",
+			on:        reporter.WebhookOnAlways,
+			results:   streamingTestResults(),
+			statuses:  []int{http.StatusNotFound},
+			wantPosts: 1,
+			wantWarn:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Reinitialize for t.Parallel()
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			webhook := &webhookServer{statuses: tt.statuses}
+			server := httptest.NewServer(webhook)
+			defer server.Close()
+
+			stderr := &bytes.Buffer{}
+			r := newWebhookReporter(t, server, tt.on, stderr)
+
+			if err := r.PrintResult(tt.results); err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+
+			if len(webhook.summaries) != tt.wantPosts {
+				t.Errorf("Expected %d posts to the webhook, but got %d", tt.wantPosts, len(webhook.summaries))
+			}
+
+			if got := strings.Contains(stderr.String(), "Failed to post the results to the webhook"); got != tt.wantWarn {
+				t.Errorf("Expected a warning to be %t, but got:\n%s", tt.wantWarn, stderr.String())
+			}
+
+			if strings.Contains(stderr.String(), "secret-token") {
+				t.Errorf("Expected the webhook url to not be printed, but got:\n%s", stderr.String())
+			}
+		})
+	}
+}
+
+func TestWebhookReporter_PrintResult_Summary(t *testing.T) {
+	t.Parallel()
+
+	webhook := &webhookServer{}
+	server := httptest.NewServer(webhook)
+	defer server.Close()
+
+	r := newWebhookReporter(t, server, reporter.WebhookOnAlways, io.Discard)
+
+	if err := r.PrintResult(streamingTestResults()); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if len(webhook.summaries) != 1 {
+		t.Fatalf("Expected 1 post to the webhook, but got %d", len(webhook.summaries))
+	}
+
+	summary := webhook.summaries[0]
+	if summary.VulnerabilityCount != 2 || len(summary.TopFindings) != 2 {
+		t.Errorf("Expected the summary to have the 2 vulnerabilities, but got %+v", summary)
+	}
+
+	if !strings.HasPrefix(summary.Text, "OSV-Scanner found 2 vulnerabilities affecting 2 packages") {
+		t.Errorf("Expected the summary text to describe the results, but got %q", summary.Text)
+	}
+}
+
+func TestWebhookReporter_Stream(t *testing.T) {
+	t.Parallel()
+
+	r, err := reporter.NewWithOptions("table", io.Discard, io.Discard, reporter.InfoLevel, 0, reporter.Options{
+		Stream:     true,
+		WebhookURL: "https://example.com/webhook",
+	})
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if _, ok := r.(reporter.StreamingReporter); !ok {
+		t.Errorf("Expected the webhook reporter to support streaming when the reporter it wraps does")
+	}
+}
+
+func TestNewWithOptions_InvalidWebhook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		options reporter.Options
+	}{
+		{
+			name:    "invalid trigger",
+			options: reporter.Options{WebhookURL: "https://example.com/webhook", WebhookOn: "sometimes"},
+		},
+		{
+			name:    "relative url",
+			options: reporter.Options{WebhookURL: "example.com/secret-token"},
+		},
+		{
+			name:    "unsupported scheme",
+			options: reporter.Options{WebhookURL: "ftp://example.com/secret-token"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Reinitialize for t.Parallel()
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := reporter.NewWithOptions("table", io.Discard, io.Discard, reporter.InfoLevel, 0, tt.options)
+
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+
+			if strings.Contains(err.Error(), "secret-token") {
+				t.Errorf("Expected the webhook url to not be in the error, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookReporter_PrintResult_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	// closing the server straight away means that connecting to it fails
+	server := httptest.NewServer(&webhookServer{})
+	server.Close()
+
+	stderr := &bytes.Buffer{}
+	r := newWebhookReporter(t, server, reporter.WebhookOnAlways, stderr)
+
+	if err := r.PrintResult(streamingTestResults()); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if !strings.Contains(stderr.String(), "Failed to post the results to the webhook") {
+		t.Errorf("Expected a warning about failing to post, but got:\n%s", stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), strings.TrimPrefix(server.URL, "http://")) {
+		t.Errorf("Expected the host of the webhook to be printed, but got:\n%s", stderr.String())
+	}
+
+	if strings.Contains(stderr.String(), "secret-token") {
+		t.Errorf("Expected the webhook url to not be printed, but got:\n%s", stderr.String())
+	}
+}